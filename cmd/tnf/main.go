@@ -2,12 +2,14 @@ package main
 
 import (
 	"bufio"
+	"fmt"
 	"log"
 	"os"
 	"path"
 	"text/template"
 
 	"github.com/spf13/cobra"
+	"github.com/test-network-function/test-network-function/pkg/config"
 )
 
 type myHandler struct {
@@ -18,6 +20,8 @@ var (
 	pathrelativetoroot string
 	handlerDirectory   string
 	handlername        string
+	configFiles        []string
+	overrides          []string
 
 	rootCmd = &cobra.Command{
 		Use:   "tnf",
@@ -34,6 +38,18 @@ var (
 		Short: "adding new handler.",
 		RunE:  generateHandlerFiles,
 	}
+
+	configCmd = &cobra.Command{
+		Use:   "config",
+		Short: "inspection tool for the test configuration.",
+	}
+
+	show = &cobra.Command{
+		Use:   "show",
+		Short: "print the effective configuration and where each value came from.",
+		Args:  cobra.NoArgs,
+		RunE:  showEffectiveConfig,
+	}
 )
 
 func generateHandlerFiles(cmd *cobra.Command, args []string) error {
@@ -98,9 +114,40 @@ func createfile(pathfile, namefile string, myhandler myHandler, newHandlerDirect
 	return nil
 }
 
+// showEffectiveConfig prints the merged configuration layers as yaml, followed by the origin of each value as comments.
+func showEffectiveConfig(cmd *cobra.Command, args []string) error {
+	layers, err := config.LoadLayers(configFiles, overrides, os.LookupEnv)
+	if err != nil {
+		return err
+	}
+	// Building checks that the merged values fit the configuration.
+	if _, err = layers.Build(); err != nil {
+		return err
+	}
+	contents, err := layers.Marshal()
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprint(out, string(contents))
+	fmt.Fprintln(out, "# origins, in increasing order of precedence: files, environment variables, flags")
+	for _, value := range layers.Values() {
+		fmt.Fprintf(out, "# %s: %s\n", value.Path, value.Origin)
+	}
+	return nil
+}
+
 func main() {
+	show.Flags().StringArrayVar(&configFiles, "config", nil,
+		"a configuration file, replacing those named by TNF_CONFIGURATION_PATH; repeat to apply overlays in order")
+	show.Flags().StringArrayVar(&overrides, "set", nil,
+		"a configuration value as path=value, e.g. settings.nonIntrusiveOnly=true, applied over files and environment")
+
 	rootCmd.AddCommand(generate)
 	generate.AddCommand(handler)
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(show)
 	if err := rootCmd.Execute(); err != nil {
 		log.Fatal(err)
 	}
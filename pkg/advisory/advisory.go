@@ -0,0 +1,211 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package advisory
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/test-network-function/test-network-function/pkg/sbom"
+)
+
+// Severity is the impact rating of a vulnerability.  Severities are ordered, so that they can be compared against a
+// threshold.
+type Severity int
+
+// Severities, from the lowest to the highest, as rated by Red Hat.
+const (
+	SeverityUnknown Severity = iota
+	SeverityLow
+	SeverityModerate
+	SeverityImportant
+	SeverityCritical
+)
+
+const (
+	// RedHatDistribution is the ID of the distribution, as in /etc/os-release, which the Red Hat feeds apply to.
+	RedHatDistribution = "rhel"
+	// minorVersionSeparator separates the major version of a release from its minor version, such as in `8.4`.
+	minorVersionSeparator = "."
+)
+
+// moduleBuildRegex matches the release of the packages built for a module stream, such as
+// `14.18.2-2.module+el8.5.0+13644+8d46dafd`.
+var moduleBuildRegex = regexp.MustCompile(`\.module[+_]`)
+
+// severityNames are the names of the severities, indexed by severity.
+var severityNames = []string{"Unknown", "Low", "Moderate", "Important", "Critical"}
+
+// severityAliases maps the names used by other ratings, such as CVSS, to the matching severity.
+var severityAliases = map[string]Severity{
+	"medium": SeverityModerate,
+	"high":   SeverityImportant,
+	"none":   SeverityUnknown,
+}
+
+// ParseSeverity parses the case-insensitive `name` of a severity, which may also be one of the CVSS ratings `none`,
+// `medium` and `high`.
+func ParseSeverity(name string) (Severity, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for severity, severityName := range severityNames {
+		if name == strings.ToLower(severityName) {
+			return Severity(severity), nil
+		}
+	}
+	if severity, ok := severityAliases[name]; ok {
+		return severity, nil
+	}
+	return SeverityUnknown, fmt.Errorf("unknown severity %q, expected one of %s", name, strings.Join(severityNames, ", "))
+}
+
+// parseSeverityOrUnknown parses the severity `name` as given by a feed, which is unknown when it is not recognized.
+func parseSeverityOrUnknown(name string) Severity {
+	severity, _ := ParseSeverity(name)
+	return severity
+}
+
+// String returns the name of the severity.
+func (s Severity) String() string {
+	if s < SeverityUnknown || int(s) >= len(severityNames) {
+		return severityNames[SeverityUnknown]
+	}
+	return severityNames[s]
+}
+
+// Advisory is the fix of a vulnerability in a package, as read from a feed.
+type Advisory struct {
+	// ID is the identifier of the advisory, such as `RHSA-2022:1065`.
+	ID string
+	// CVE is the identifier of the vulnerability, which is the ID of the advisory when it names no CVE.
+	CVE      string
+	Severity Severity
+	// Package is the name of the binary package.
+	Package string
+	// Arches the fix applies to, which is any architecture when empty.
+	Arches []string
+	// FixedEVR is the first version of the package which is not vulnerable, as `[epoch:]version-release`.
+	FixedEVR string
+	// Distribution is the ID of the distribution the fix applies to, as in /etc/os-release, which is any when empty.
+	Distribution string
+	// Release is the major version of the distribution the fix applies to, such as `8`, which is any when empty.
+	Release string
+	// Module is the module stream the fix applies to, as `name:stream`.  When empty, the fix applies to the packages
+	// which are not built for a module stream.
+	Module string
+}
+
+// appliesToImage returns true if the advisory applies to the distribution, the release and the enabled module streams
+// of `inventory`.
+func (a *Advisory) appliesToImage(inventory *sbom.Inventory) bool {
+	if a.Distribution != "" && inventory.OSID != a.Distribution {
+		return false
+	}
+	if a.Release != "" && strings.SplitN(inventory.OSVersionID, minorVersionSeparator, 2)[0] != a.Release {
+		return false
+	}
+	return a.Module == "" || containsString(inventory.Modules, a.Module)
+}
+
+// appliesTo returns true if the advisory fixes the installed package `pkg`.
+func (a *Advisory) appliesTo(pkg *sbom.Package) bool {
+	if pkg.Manager != sbom.ManagerRPM || pkg.Name != a.Package {
+		return false
+	}
+	if a.Module == "" && moduleBuildRegex.MatchString(pkg.Version) {
+		return false
+	}
+	if len(a.Arches) > 0 && pkg.Arch != "" && !containsString(a.Arches, pkg.Arch) {
+		return false
+	}
+	return CompareEVR(pkg.Version, a.FixedEVR) < 0
+}
+
+// Finding is a vulnerability of an installed package which has a fix.
+type Finding struct {
+	CVE      string
+	Severity Severity
+	// AdvisoryID is the identifier of the advisory which fixes the vulnerability.
+	AdvisoryID string
+	Package    string
+	// InstalledEVR is the version of the package installed in the container.
+	InstalledEVR string
+	// FixedEVR is the first version of the package which fixes the vulnerability.
+	FixedEVR string
+}
+
+// String returns a description of the finding, such as
+// `CVE-2022-0778 (Important) in openssl 1:1.1.1k-5.el8_5, fixed in 1:1.1.1k-6.el8_5 by RHSA-2022:1065`.
+func (f *Finding) String() string {
+	return fmt.Sprintf("%s (%s) in %s %s, fixed in %s by %s", f.CVE, f.Severity, f.Package, f.InstalledEVR, f.FixedEVR,
+		f.AdvisoryID)
+}
+
+// Match returns the vulnerabilities of the rpm packages of `inventory` fixed by `advisories`, sorted by decreasing
+// severity then by CVE and package.  Only the advisories of the distribution and the release of `inventory`, and of the
+// module streams it enables, are taken into account.  A vulnerability fixed by several advisories is reported once per
+// package, with the oldest version which fixes it.
+func Match(inventory *sbom.Inventory, advisories []Advisory) []Finding {
+	byPackage := map[string][]*Advisory{}
+	for i := range advisories {
+		if !advisories[i].appliesToImage(inventory) {
+			continue
+		}
+		byPackage[advisories[i].Package] = append(byPackage[advisories[i].Package], &advisories[i])
+	}
+	var findings []Finding
+	found := map[string]int{}
+	for i := range inventory.Packages {
+		pkg := &inventory.Packages[i]
+		for _, advisory := range byPackage[pkg.Name] {
+			if !advisory.appliesTo(pkg) {
+				continue
+			}
+			key := advisory.CVE + "\x00" + pkg.Name
+			if j, ok := found[key]; ok {
+				if CompareEVR(advisory.FixedEVR, findings[j].FixedEVR) < 0 {
+					findings[j].AdvisoryID, findings[j].FixedEVR = advisory.ID, advisory.FixedEVR
+				}
+				continue
+			}
+			found[key] = len(findings)
+			findings = append(findings, Finding{CVE: advisory.CVE, Severity: advisory.Severity, AdvisoryID: advisory.ID,
+				Package: pkg.Name, InstalledEVR: pkg.Version, FixedEVR: advisory.FixedEVR})
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Severity != findings[j].Severity {
+			return findings[i].Severity > findings[j].Severity
+		}
+		if findings[i].CVE != findings[j].CVE {
+			return findings[i].CVE < findings[j].CVE
+		}
+		return findings[i].Package < findings[j].Package
+	})
+	return findings
+}
+
+// containsString returns true if `values` contains `value`.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package advisory_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/advisory"
+	"github.com/test-network-function/test-network-function/pkg/sbom"
+)
+
+func TestParseSeverity(t *testing.T) {
+	testCases := []struct {
+		name     string
+		severity advisory.Severity
+	}{
+		{"Low", advisory.SeverityLow},
+		{"moderate", advisory.SeverityModerate},
+		{"Medium", advisory.SeverityModerate},
+		{" IMPORTANT ", advisory.SeverityImportant},
+		{"high", advisory.SeverityImportant},
+		{"Critical", advisory.SeverityCritical},
+		{"unknown", advisory.SeverityUnknown},
+	}
+	for _, testCase := range testCases {
+		severity, err := advisory.ParseSeverity(testCase.name)
+		assert.Nil(t, err, testCase.name)
+		assert.Equal(t, testCase.severity, severity, testCase.name)
+	}
+
+	_, err := advisory.ParseSeverity("severe")
+	assert.NotNil(t, err)
+	assert.Equal(t, "Important", advisory.SeverityImportant.String())
+	assert.Equal(t, "Unknown", advisory.Severity(42).String())
+}
+
+func TestMatch(t *testing.T) {
+	inventory := &sbom.Inventory{OSID: "rhel", OSVersionID: "8.4", Packages: []sbom.Package{
+		{Manager: sbom.ManagerRPM, Name: "cyrus-sasl-lib", Version: "2.1.27-5.el8", Arch: "x86_64"},
+		{Manager: sbom.ManagerRPM, Name: "openssl-libs", Version: "1:1.1.1g-15.el8_3", Arch: "x86_64"},
+		{Manager: sbom.ManagerRPM, Name: "rpm", Version: "4.14.3-19.el8", Arch: "x86_64"},
+		{Manager: sbom.ManagerRPM, Name: "zlib", Version: "1.2.11-17.el8", Arch: "aarch64"},
+		{Manager: sbom.ManagerDeb, Name: "openssl", Version: "1.1.1k-1"},
+	}}
+	advisories := []advisory.Advisory{
+		{ID: "RHSA-2022:1065", CVE: "CVE-2022-0778", Severity: advisory.SeverityImportant, Package: "openssl-libs",
+			FixedEVR: "1:1.1.1k-6.el8_5"},
+		// An older advisory fixes the same CVE, and its version is reported.
+		{ID: "RHSA-2022:1020", CVE: "CVE-2022-0778", Severity: advisory.SeverityImportant, Package: "openssl-libs",
+			FixedEVR: "1:1.1.1k-5.el8_5"},
+		{ID: "RHSA-2021:1024", CVE: "CVE-2021-3449", Severity: advisory.SeverityImportant, Package: "openssl-libs",
+			FixedEVR: "1:1.1.1g-15.el8_3"},
+		{ID: "RHSA-2022:0658", CVE: "CVE-2022-24407", Severity: advisory.SeverityCritical, Package: "cyrus-sasl-lib",
+			Arches: []string{"x86_64"}, FixedEVR: "2.1.27-6.el8_5"},
+		{ID: "RHSA-2021:4489", CVE: "CVE-2021-20266", Severity: advisory.SeverityModerate, Package: "rpm",
+			FixedEVR: "0:4.14.3-19.el8"},
+		{ID: "RHSA-2022:2213", CVE: "CVE-2018-25032", Severity: advisory.SeverityImportant, Package: "zlib",
+			Arches: []string{"x86_64"}, FixedEVR: "1.2.11-18.el8_5"},
+	}
+
+	findings := advisory.Match(inventory, advisories)
+	if assert.Len(t, findings, 2) {
+		assert.Equal(t, advisory.Finding{CVE: "CVE-2022-24407", Severity: advisory.SeverityCritical,
+			AdvisoryID: "RHSA-2022:0658", Package: "cyrus-sasl-lib", InstalledEVR: "2.1.27-5.el8",
+			FixedEVR: "2.1.27-6.el8_5"}, findings[0])
+		assert.Equal(t, "CVE-2022-0778 (Important) in openssl-libs 1:1.1.1g-15.el8_3, fixed in 1:1.1.1k-5.el8_5 by "+
+			"RHSA-2022:1020", findings[1].String())
+	}
+
+	assert.Empty(t, advisory.Match(&sbom.Inventory{}, advisories))
+}
+
+func TestMatchRelease(t *testing.T) {
+	inventory := &sbom.Inventory{OSID: "rhel", OSVersionID: "8.4", Modules: []string{"nodejs:14"}, Packages: []sbom.Package{
+		{Manager: sbom.ManagerRPM, Name: "nodejs", Version: "1:14.17.0-1.module+el8.4.0+11087+5c1f6f8f", Arch: "x86_64"},
+		{Manager: sbom.ManagerRPM, Name: "openssl-libs", Version: "1:1.1.1g-15.el8_3", Arch: "x86_64"},
+	}}
+	advisories := []advisory.Advisory{
+		{ID: "RHSA-2022:1065", CVE: "CVE-2022-0778", Package: "openssl-libs", FixedEVR: "1:1.1.1k-6.el8_5",
+			Distribution: "rhel", Release: "8"},
+		// Advisories of another release or distribution do not apply.
+		{ID: "RHSA-2022:1066", CVE: "CVE-2022-0778", Package: "openssl-libs", FixedEVR: "1:1.1.1k-7.el7",
+			Distribution: "rhel", Release: "7"},
+		{ID: "CESA-2022:1065", CVE: "CVE-2022-0778", Package: "openssl-libs", FixedEVR: "1:1.1.1k-6.el8_5",
+			Distribution: "centos"},
+		// Advisories of a module stream only apply to the packages of the streams which are enabled.
+		{ID: "RHSA-2022:0350", CVE: "CVE-2021-44531", Package: "nodejs", FixedEVR: "1:14.18.2-2.module+el8.5.0+13644+8d46dafd",
+			Distribution: "rhel", Release: "8", Module: "nodejs:14"},
+		{ID: "RHSA-2022:0351", CVE: "CVE-2021-44532", Package: "nodejs", FixedEVR: "1:16.13.2-3.module+el8.5.0+13645+9f9a7f48",
+			Distribution: "rhel", Release: "8", Module: "nodejs:16"},
+		// Advisories of the packages which are not built for a module stream do not apply to those which are.
+		{ID: "RHSA-2022:9999", CVE: "CVE-2021-44533", Package: "nodejs", FixedEVR: "1:16.14.0-1.el8",
+			Distribution: "rhel", Release: "8"},
+	}
+	var ids []string
+	for _, finding := range advisory.Match(inventory, advisories) {
+		ids = append(ids, finding.AdvisoryID)
+	}
+	assert.Equal(t, []string{"RHSA-2022:0350", "RHSA-2022:1065"}, ids)
+
+	inventory.OSVersionID = "9.0"
+	assert.Empty(t, advisory.Match(inventory, advisories))
+}
@@ -0,0 +1,228 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package advisory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const (
+	// csafImpact is the category of the threat giving the severity of a vulnerability.
+	csafImpact = "impact"
+	// rpmPURLPrefix prefixes the package URLs of rpms.
+	rpmPURLPrefix = "pkg:rpm/"
+	// sourceArch is the architecture of source rpms, which are never installed.
+	sourceArch = "src"
+	// rpmModuleQualifier is the qualifier of the package URLs of the rpms built for a module stream, such as
+	// `nodejs:14:8050020211213110730:bd1311ed`.
+	rpmModuleQualifier = "rpmmod"
+	// cpePrefix prefixes the CPEs, in the URI binding, which name the products of the product tree.
+	cpePrefix = "cpe:/"
+	// cpeSeparator separates the components of a CPE, and the name and the stream of a module.
+	cpeSeparator = ":"
+	// redHatVendor is the vendor of the CPEs of Red Hat products.
+	redHatVendor = "redhat"
+	// cpeVendorIndex, cpeProductIndex and cpeVersionIndex are the positions of the components of a CPE, such as
+	// `cpe:/o:redhat:enterprise_linux:8::baseos`.
+	cpeVendorIndex  = 2
+	cpeProductIndex = 3
+	cpeVersionIndex = 4
+)
+
+// enterpriseLinuxCPERegex matches the CPE product of the releases of Red Hat Enterprise Linux, including their extended
+// update support streams such as `rhel_eus`.
+var enterpriseLinuxCPERegex = regexp.MustCompile(`^(?:enterprise_linux|rhel_[a-z0-9]+)$`)
+
+// csafDocument is the subset of a CSAF security advisory, as published by Red Hat, needed to find the fixed versions of
+// packages.
+type csafDocument struct {
+	Document struct {
+		Tracking struct {
+			ID string `json:"id"`
+		} `json:"tracking"`
+		AggregateSeverity struct {
+			Text string `json:"text"`
+		} `json:"aggregate_severity"`
+	} `json:"document"`
+	ProductTree struct {
+		Branches      []csafBranch `json:"branches"`
+		Relationships []struct {
+			FullProductName struct {
+				ProductID string `json:"product_id"`
+			} `json:"full_product_name"`
+			ProductReference          string `json:"product_reference"`
+			RelatesToProductReference string `json:"relates_to_product_reference"`
+		} `json:"relationships"`
+	} `json:"product_tree"`
+	Vulnerabilities []struct {
+		CVE           string `json:"cve"`
+		ProductStatus struct {
+			Fixed []string `json:"fixed"`
+		} `json:"product_status"`
+		Threats []struct {
+			Category string `json:"category"`
+			Details  string `json:"details"`
+		} `json:"threats"`
+	} `json:"vulnerabilities"`
+}
+
+type csafBranch struct {
+	Branches []csafBranch `json:"branches"`
+	Product  *struct {
+		ProductID                   string `json:"product_id"`
+		ProductIdentificationHelper struct {
+			PURL string `json:"purl"`
+			CPE  string `json:"cpe"`
+		} `json:"product_identification_helper"`
+	} `json:"product"`
+}
+
+// rpmPackage is a binary rpm named by the product tree of a CSAF advisory.
+type rpmPackage struct {
+	name string
+	evr  string
+	arch string
+	// module is the module stream the rpm is built for, as `name:stream`, or empty.
+	module string
+	// release is the major version of the release of Red Hat Enterprise Linux the rpm is a component of, or empty.
+	release string
+}
+
+// addPackages adds the binary rpms of the branch, and of the branches it nests, to `packages`, and the releases of Red
+// Hat Enterprise Linux named by their CPE to `releases`, both keyed by product ID.
+func (b *csafBranch) addPackages(packages map[string]rpmPackage, releases map[string]string) {
+	if b.Product != nil {
+		if pkg, ok := parseRPMPURL(b.Product.ProductIdentificationHelper.PURL); ok && pkg.arch != sourceArch {
+			packages[b.Product.ProductID] = pkg
+		}
+		if release := cpeRelease(b.Product.ProductIdentificationHelper.CPE); release != "" {
+			releases[b.Product.ProductID] = release
+		}
+	}
+	for i := range b.Branches {
+		b.Branches[i].addPackages(packages, releases)
+	}
+}
+
+// packages returns the binary rpms named by the product tree, keyed by their product ID and by the product IDs of their
+// relationships to a product.  The rpms of a relationship are of the release of the product they relate to.
+func (d *csafDocument) packages() map[string]rpmPackage {
+	packages, releases := map[string]rpmPackage{}, map[string]string{}
+	for i := range d.ProductTree.Branches {
+		d.ProductTree.Branches[i].addPackages(packages, releases)
+	}
+	for _, relationship := range d.ProductTree.Relationships {
+		if pkg, ok := packages[relationship.ProductReference]; ok {
+			pkg.release = releases[relationship.RelatesToProductReference]
+			packages[relationship.FullProductName.ProductID] = pkg
+		}
+	}
+	return packages
+}
+
+// cpeRelease returns the major version of the release of Red Hat Enterprise Linux named by `cpe`, such as `8` for
+// `cpe:/o:redhat:enterprise_linux:8::baseos`, or an empty string if it names none.
+func cpeRelease(cpe string) string {
+	if !strings.HasPrefix(cpe, cpePrefix) {
+		return ""
+	}
+	components := strings.Split(cpe, cpeSeparator)
+	if len(components) <= cpeVersionIndex || components[cpeVendorIndex] != redHatVendor ||
+		!enterpriseLinuxCPERegex.MatchString(components[cpeProductIndex]) {
+		return ""
+	}
+	return strings.SplitN(components[cpeVersionIndex], minorVersionSeparator, 2)[0]
+}
+
+// ParseCSAF parses the CSAF security advisory read from `reader`, and returns an advisory for each CVE and binary rpm
+// it fixes.  The packages are found through the package URLs of the product tree.
+func ParseCSAF(reader io.Reader) ([]Advisory, error) {
+	var document csafDocument
+	if err := json.NewDecoder(reader).Decode(&document); err != nil {
+		return nil, fmt.Errorf("malformed CSAF advisory: %s", err)
+	}
+	id := document.Document.Tracking.ID
+	if id == "" {
+		return nil, errors.New("the CSAF advisory has no tracking ID")
+	}
+	packages := document.packages()
+	var advisories []Advisory
+	for _, vulnerability := range document.Vulnerabilities {
+		cve := vulnerability.CVE
+		if cve == "" {
+			cve = id
+		}
+		severity := parseSeverityOrUnknown(document.Document.AggregateSeverity.Text)
+		for _, threat := range vulnerability.Threats {
+			if threat.Category == csafImpact {
+				severity = parseSeverityOrUnknown(threat.Details)
+			}
+		}
+		seen := map[rpmPackage]bool{}
+		for _, productID := range vulnerability.ProductStatus.Fixed {
+			pkg, ok := packages[productID]
+			if !ok || seen[pkg] {
+				continue
+			}
+			seen[pkg] = true
+			advisory := Advisory{ID: id, CVE: cve, Severity: severity, Package: pkg.name, FixedEVR: pkg.evr,
+				Distribution: RedHatDistribution, Release: pkg.release, Module: pkg.module}
+			if pkg.arch != "" {
+				advisory.Arches = []string{pkg.arch}
+			}
+			advisories = append(advisories, advisory)
+		}
+	}
+	return advisories, nil
+}
+
+// parseRPMPURL parses the package URL of an rpm, such as `pkg:rpm/redhat/openssl@1.1.1k-6.el8_5?arch=x86_64&epoch=1`.
+// The module stream of the rpm is given by the `rpmmod` qualifier, as `name:stream:version:context`.
+func parseRPMPURL(purl string) (rpmPackage, bool) {
+	if !strings.HasPrefix(purl, rpmPURLPrefix) {
+		return rpmPackage{}, false
+	}
+	path, rawQualifiers := purl[len(rpmPURLPrefix):], ""
+	if i := strings.Index(path, "?"); i >= 0 {
+		path, rawQualifiers = path[:i], path[i+1:]
+	}
+	path = path[strings.LastIndex(path, "/")+1:]
+	i := strings.Index(path, "@")
+	if i < 0 {
+		return rpmPackage{}, false
+	}
+	name, errName := url.PathUnescape(path[:i])
+	version, errVersion := url.PathUnescape(path[i+1:])
+	qualifiers, errQualifiers := url.ParseQuery(rawQualifiers)
+	if errName != nil || errVersion != nil || errQualifiers != nil || name == "" || version == "" {
+		return rpmPackage{}, false
+	}
+	pkg := rpmPackage{name: name, evr: version, arch: qualifiers.Get("arch")}
+	if epoch := qualifiers.Get("epoch"); epoch != "" {
+		pkg.evr = epoch + epochSeparator + version
+	}
+	if module := strings.SplitN(qualifiers.Get(rpmModuleQualifier), cpeSeparator, 3); len(module) >= 2 {
+		pkg.module = module[0] + cpeSeparator + module[1]
+	}
+	return pkg, true
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package advisory_test
+
+import (
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/advisory"
+)
+
+func TestParseCSAF(t *testing.T) {
+	file, err := os.Open(path.Join(testdataDirectory, feedDirectory, "rhsa-2022_0658.json"))
+	assert.Nil(t, err)
+	defer file.Close()
+	advisories, err := advisory.ParseCSAF(file)
+	assert.Nil(t, err)
+	// The source rpm is not reported.
+	assert.Equal(t, []advisory.Advisory{
+		{ID: "RHSA-2022:0658", CVE: "CVE-2022-24407", Severity: advisory.SeverityImportant, Package: "cyrus-sasl-lib",
+			Arches: []string{"x86_64"}, FixedEVR: "2.1.27-6.el8_5", Distribution: "rhel", Release: "8"},
+	}, advisories)
+}
+
+func TestParseCSAFEpoch(t *testing.T) {
+	advisories, err := advisory.ParseCSAF(strings.NewReader(`{
+		"document": {"tracking": {"id": "RHSA-2022:1065"}, "aggregate_severity": {"text": "Important"}},
+		"product_tree": {"branches": [{"branches": [{"product": {"product_id": "openssl-libs-1:1.1.1k-6.el8_5.x86_64",
+			"product_identification_helper": {"purl": "pkg:rpm/redhat/openssl-libs@1.1.1k-6.el8_5?arch=x86_64&epoch=1"}}}]}]},
+		"vulnerabilities": [{"cve": "CVE-2022-0778", "product_status": {"fixed": ["openssl-libs-1:1.1.1k-6.el8_5.x86_64"]}}]
+	}`))
+	assert.Nil(t, err)
+	assert.Equal(t, []advisory.Advisory{
+		{ID: "RHSA-2022:1065", CVE: "CVE-2022-0778", Severity: advisory.SeverityImportant, Package: "openssl-libs",
+			Arches: []string{"x86_64"}, FixedEVR: "1:1.1.1k-6.el8_5", Distribution: "rhel"},
+	}, advisories)
+}
+
+func TestParseCSAFModule(t *testing.T) {
+	advisories, err := advisory.ParseCSAF(strings.NewReader(`{
+		"document": {"tracking": {"id": "RHSA-2022:0350"}, "aggregate_severity": {"text": "Moderate"}},
+		"product_tree": {"branches": [{"branches": [
+			{"product": {"product_id": "AppStream-8.4.0.Z.EUS",
+				"product_identification_helper": {"cpe": "cpe:/a:redhat:rhel_eus:8.4::appstream"}}},
+			{"product": {"product_id": "nodejs-1:14.18.2-2.module+el8.4.0+13643+8d46dafd.x86_64",
+				"product_identification_helper": {"purl": "pkg:rpm/redhat/nodejs@14.18.2-2.module%2Bel8.4.0%2B13643%2B8d46dafd?arch=x86_64&epoch=1&rpmmod=nodejs:14:8040020211213111158:522a0ee4"}}}]}],
+			"relationships": [{"full_product_name": {"product_id": "AppStream-8.4.0.Z.EUS:nodejs:14:8040020211213111158:522a0ee4:nodejs-1:14.18.2-2.module+el8.4.0+13643+8d46dafd.x86_64"},
+				"product_reference": "nodejs-1:14.18.2-2.module+el8.4.0+13643+8d46dafd.x86_64",
+				"relates_to_product_reference": "AppStream-8.4.0.Z.EUS"}]},
+		"vulnerabilities": [{"cve": "CVE-2021-44531", "product_status": {"fixed": [
+			"AppStream-8.4.0.Z.EUS:nodejs:14:8040020211213111158:522a0ee4:nodejs-1:14.18.2-2.module+el8.4.0+13643+8d46dafd.x86_64"]}}]
+	}`))
+	assert.Nil(t, err)
+	assert.Equal(t, []advisory.Advisory{
+		{ID: "RHSA-2022:0350", CVE: "CVE-2021-44531", Severity: advisory.SeverityModerate, Package: "nodejs",
+			Arches: []string{"x86_64"}, FixedEVR: "1:14.18.2-2.module+el8.4.0+13643+8d46dafd", Distribution: "rhel",
+			Release: "8", Module: "nodejs:14"},
+	}, advisories)
+}
+
+func TestParseCSAFMalformed(t *testing.T) {
+	for _, document := range []string{`{"document": {`, `{"document": {"tracking": {}}}`} {
+		_, err := advisory.ParseCSAF(strings.NewReader(document))
+		assert.NotNil(t, err, document)
+	}
+}
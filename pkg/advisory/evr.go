@@ -0,0 +1,161 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package advisory
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	epochSeparator   = ":"
+	releaseSeparator = "-"
+	defaultEpoch     = "0"
+	tilde            = "~"
+	caret            = "^"
+)
+
+// CompareEVR compares the rpm versions `a` and `b`, each given as `[epoch:]version[-release]`, the way rpm does.  It
+// returns a negative number when `a` is older than `b`, zero when they are the same, and a positive number otherwise.
+// The release is only compared when both versions have one.
+func CompareEVR(a, b string) int {
+	epochA, versionA, releaseA := splitEVR(a)
+	epochB, versionB, releaseB := splitEVR(b)
+	if result := compareSegments(epochA, epochB); result != 0 {
+		return result
+	}
+	if result := compareSegments(versionA, versionB); result != 0 || releaseA == "" || releaseB == "" {
+		return result
+	}
+	return compareSegments(releaseA, releaseB)
+}
+
+// splitEVR splits `evr` into its epoch, which defaults to 0, its version and its release.
+func splitEVR(evr string) (epoch, version, release string) {
+	epoch, version = defaultEpoch, evr
+	if i := strings.Index(version, epochSeparator); i >= 0 {
+		epoch, version = version[:i], version[i+1:]
+	}
+	if i := strings.LastIndex(version, releaseSeparator); i >= 0 {
+		version, release = version[:i], version[i+1:]
+	}
+	return epoch, version, release
+}
+
+// compareSegments compares two version strings segment by segment, as rpmvercmp does: numeric segments are newer than
+// alphabetic ones, `~` sorts before anything, even the end of the string, and `^` sorts after the end of the string
+// but before anything else.
+func compareSegments(a, b string) int {
+	if a == b {
+		return 0
+	}
+	for a != "" || b != "" {
+		a, b = strings.TrimLeftFunc(a, isSeparator), strings.TrimLeftFunc(b, isSeparator)
+		if found, result := compareMarkers(a, b); found {
+			if result != 0 {
+				return result
+			}
+			a, b = a[1:], b[1:]
+			continue
+		}
+		if a == "" || b == "" {
+			break
+		}
+		var result int
+		if result, a, b = compareSegment(a, b); result != 0 {
+			return result
+		}
+	}
+	switch {
+	case a == "" && b == "":
+		return 0
+	case a == "":
+		return -1
+	}
+	return 1
+}
+
+// compareMarkers compares `a` and `b` when either starts with `~` or `^`.  It returns whether either does, along with
+// the result of the comparison, which is zero when both start with the same marker.
+func compareMarkers(a, b string) (found bool, result int) {
+	for _, marker := range []string{tilde, caret} {
+		hasA, hasB := strings.HasPrefix(a, marker), strings.HasPrefix(b, marker)
+		if !hasA && !hasB {
+			continue
+		}
+		if marker == caret && (a == "" || b == "") {
+			if a == "" {
+				return true, -1
+			}
+			return true, 1
+		}
+		switch {
+		case !hasA:
+			return true, 1
+		case !hasB:
+			return true, -1
+		}
+		return true, 0
+	}
+	return false, 0
+}
+
+// compareSegment compares the leading segments of `a` and `b`, whose type is that of the segment of `a`, and returns
+// the result along with what follows the segments.
+func compareSegment(a, b string) (result int, restA, restB string) {
+	isNumeric := unicode.IsDigit(rune(a[0]))
+	inSegment := unicode.IsLetter
+	if isNumeric {
+		inSegment = unicode.IsDigit
+	}
+	segmentA, segmentB := leadingRun(a, inSegment), leadingRun(b, inSegment)
+	restA, restB = a[len(segmentA):], b[len(segmentB):]
+	if segmentB == "" {
+		// The segments are of different types, and numeric segments are newer.
+		if isNumeric {
+			return 1, restA, restB
+		}
+		return -1, restA, restB
+	}
+	if isNumeric {
+		segmentA, segmentB = strings.TrimLeft(segmentA, "0"), strings.TrimLeft(segmentB, "0")
+		if len(segmentA) != len(segmentB) {
+			return len(segmentA) - len(segmentB), restA, restB
+		}
+	}
+	return strings.Compare(segmentA, segmentB), restA, restB
+}
+
+// isSeparator returns true for the characters which separate the segments of a version.
+func isSeparator(r rune) bool {
+	return !isAlphanumeric(r) && string(r) != tilde && string(r) != caret
+}
+
+// isAlphanumeric returns true for ASCII letters and digits, which are the only ones rpm takes as part of a segment.
+func isAlphanumeric(r rune) bool {
+	return r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+// leadingRun returns the leading characters of `value` for which `in` is true.
+func leadingRun(value string, in func(rune) bool) string {
+	for i, r := range value {
+		if r >= unicode.MaxASCII || !in(r) {
+			return value[:i]
+		}
+	}
+	return value
+}
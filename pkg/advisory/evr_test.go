@@ -0,0 +1,73 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package advisory_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/advisory"
+)
+
+func TestCompareEVR(t *testing.T) {
+	testCases := []struct {
+		a      string
+		b      string
+		result int
+	}{
+		{"1.0", "1.0", 0},
+		{"1.0", "1.1", -1},
+		{"1.10", "1.9", 1},
+		{"1.010", "1.10", 0},
+		{"1.0a", "1.0", 1},
+		{"1.0a", "1.0b", -1},
+		{"1.a", "1.1", -1},
+		{"1.0~rc1", "1.0", -1},
+		{"1.0~rc1", "1.0~rc2", -1},
+		{"1.0^git1", "1.0", 1},
+		{"1.0^git1", "1.0.1", -1},
+		{"1.0_1", "1.0.1", 0},
+		{"1:1.0", "2.0", 1},
+		{"0:1.0", "1.0", 0},
+		{"1.1.1k-5.el8_5", "1.1.1k-6.el8_5", -1},
+		{"1:1.1.1k-5.el8_5", "1:1.1.1k-5.el8_5", 0},
+		{"1:1.1.1g-15.el8_3", "1:1.1.1k-6.el8_5", -1},
+		{"2.28-151.el8", "2.28", 0},
+	}
+	for _, testCase := range testCases {
+		result := advisory.CompareEVR(testCase.a, testCase.b)
+		switch {
+		case testCase.result < 0:
+			assert.Negative(t, result, "%s < %s", testCase.a, testCase.b)
+		case testCase.result > 0:
+			assert.Positive(t, result, "%s > %s", testCase.a, testCase.b)
+		default:
+			assert.Zero(t, result, "%s = %s", testCase.a, testCase.b)
+		}
+		assert.Equal(t, -sign(result), sign(advisory.CompareEVR(testCase.b, testCase.a)), testCase.a)
+	}
+}
+
+func sign(value int) int {
+	switch {
+	case value < 0:
+		return -1
+	case value > 0:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package advisory
+
+import (
+	"compress/bzip2"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Extensions of the feed files, which are read according to their format.
+const (
+	ovalExtension  = ".xml"
+	csafExtension  = ".json"
+	bzip2Extension = ".bz2"
+)
+
+// feedParsers maps the extension of a feed file to the parser of its format.
+var feedParsers = map[string]func(io.Reader) ([]Advisory, error){
+	ovalExtension: ParseOVAL,
+	csafExtension: ParseCSAF,
+}
+
+// LoadFeed reads the advisories of the feed files at `paths`, each of which is either a file or a directory searched
+// recursively.  Files ending in `.xml` are read as OVAL definitions and files ending in `.json` as CSAF advisories,
+// either of which may be compressed with bzip2, as Red Hat publishes them; other files of a directory are ignored.
+func LoadFeed(paths []string) ([]Advisory, error) {
+	var files []string
+	for _, path := range paths {
+		err := filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() && (file == path || feedParser(file) != nil) {
+				files = append(files, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the advisory feed %s: %s", path, err)
+		}
+	}
+	sort.Strings(files)
+	var advisories []Advisory
+	for _, file := range files {
+		fileAdvisories, err := loadFeedFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the advisory feed %s: %s", file, err)
+		}
+		advisories = append(advisories, fileAdvisories...)
+	}
+	return advisories, nil
+}
+
+// feedParser returns the parser of the feed `file`, according to its extension, or nil if it is not a feed file.
+func feedParser(file string) func(io.Reader) ([]Advisory, error) {
+	return feedParsers[strings.ToLower(filepath.Ext(strings.TrimSuffix(file, bzip2Extension)))]
+}
+
+// loadFeedFile reads the advisories of the feed `file`.
+func loadFeedFile(file string) ([]Advisory, error) {
+	parse := feedParser(file)
+	if parse == nil {
+		return nil, fmt.Errorf("unknown format, expected a %s or a %s file", ovalExtension, csafExtension)
+	}
+	f, err := os.Open(filepath.Clean(file))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var reader io.Reader = f
+	if strings.HasSuffix(file, bzip2Extension) {
+		reader = bzip2.NewReader(f)
+	}
+	return parse(reader)
+}
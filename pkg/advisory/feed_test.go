@@ -0,0 +1,58 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package advisory_test
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/advisory"
+)
+
+func TestLoadFeed(t *testing.T) {
+	advisories, err := advisory.LoadFeed([]string{path.Join(testdataDirectory, feedDirectory)})
+	assert.Nil(t, err)
+	// Files are read in order, and README.txt is ignored.
+	var ids []string
+	for _, a := range advisories {
+		ids = append(ids, a.ID)
+	}
+	assert.Equal(t, []string{"RHSA-2022:1065", "RHSA-2022:1065", "RHSA-2021:4489", "RHSA-2021:4489", "RHSA-2022:0350",
+		"RHSA-2022:0658"}, ids)
+
+	advisories, err = advisory.LoadFeed([]string{path.Join(testdataDirectory, feedDirectory, "rhsa-2022_0658.json")})
+	assert.Nil(t, err)
+	assert.Len(t, advisories, 1)
+}
+
+func TestLoadFeedErrors(t *testing.T) {
+	for _, feedPath := range []string{
+		path.Join(testdataDirectory, "missing"),
+		path.Join(testdataDirectory, "malformed.json"),
+		path.Join(testdataDirectory, feedDirectory, "README.txt"),
+	} {
+		_, err := advisory.LoadFeed([]string{feedPath})
+		assert.NotNil(t, err, feedPath)
+	}
+}
+
+func TestLoadFeedBzip2(t *testing.T) {
+	advisories, err := advisory.LoadFeed([]string{path.Join(testdataDirectory, "rhel-8.oval.xml.bz2")})
+	assert.Nil(t, err)
+	assert.Len(t, advisories, 5)
+}
@@ -0,0 +1,206 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package advisory
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+const (
+	// ovalLessThan is the operation of the states matching the versions an OVAL definition fixes.
+	ovalLessThan = "less than"
+	// ovalAdvisorySource is the source of the reference naming the advisory of an OVAL definition.
+	ovalAdvisorySource = "RHSA"
+	// ovalArchSeparator separates the architectures of an OVAL arch pattern, such as `aarch64|ppc64le|x86_64`.
+	ovalArchSeparator = "|"
+	// ovalAnd is the operator of the criteria which all must be true, which is the default.
+	ovalAnd = "AND"
+)
+
+var (
+	// ovalReleaseRegex matches the comment of the criterion requiring a release, capturing its major version.
+	ovalReleaseRegex = regexp.MustCompile(`^Red Hat Enterprise Linux (\d+) is installed$`)
+	// ovalModuleRegex matches the comment of the criterion requiring a module stream, capturing it as `name:stream`.
+	ovalModuleRegex = regexp.MustCompile(`^Module (\S+:\S+) is enabled$`)
+)
+
+// ovalDefinitions is the subset of an OVAL definitions document, as published by Red Hat, needed to find the fixed
+// versions of packages.
+type ovalDefinitions struct {
+	Definitions []ovalDefinition `xml:"definitions>definition"`
+	Tests       []ovalTest       `xml:"tests>rpminfo_test"`
+	Objects     []ovalObject     `xml:"objects>rpminfo_object"`
+	States      []ovalState      `xml:"states>rpminfo_state"`
+}
+
+type ovalDefinition struct {
+	ID         string          `xml:"id,attr"`
+	References []ovalReference `xml:"metadata>reference"`
+	Severity   string          `xml:"metadata>advisory>severity"`
+	CVEs       []string        `xml:"metadata>advisory>cve"`
+	Criteria   ovalCriteria    `xml:"criteria"`
+}
+
+type ovalReference struct {
+	ID     string `xml:"ref_id,attr"`
+	Source string `xml:"source,attr"`
+}
+
+type ovalCriteria struct {
+	Operator  string         `xml:"operator,attr"`
+	Criteria  []ovalCriteria `xml:"criteria"`
+	Criterion []struct {
+		TestRef string `xml:"test_ref,attr"`
+		Comment string `xml:"comment,attr"`
+	} `xml:"criterion"`
+}
+
+type ovalTest struct {
+	ID     string `xml:"id,attr"`
+	Object struct {
+		Ref string `xml:"object_ref,attr"`
+	} `xml:"object"`
+	State struct {
+		Ref string `xml:"state_ref,attr"`
+	} `xml:"state"`
+}
+
+type ovalObject struct {
+	ID   string `xml:"id,attr"`
+	Name string `xml:"name"`
+}
+
+type ovalState struct {
+	ID   string `xml:"id,attr"`
+	Arch string `xml:"arch"`
+	EVR  struct {
+		Operation string `xml:"operation,attr"`
+		Value     string `xml:",chardata"`
+	} `xml:"evr"`
+}
+
+// ovalTestRef is a reference to a test of the criteria of a definition, along with the release and the module stream
+// which the criteria require for the test to apply.
+type ovalTestRef struct {
+	ref     string
+	release string
+	module  string
+}
+
+// testRefs returns the references to the tests of the criteria, and of the criteria they nest.  The release and the
+// module stream required by `context` are narrowed by those which criteria of the AND operator require of each of
+// their criteria.
+func (c *ovalCriteria) testRefs(context ovalTestRef) []ovalTestRef {
+	if c.Operator == "" || strings.EqualFold(c.Operator, ovalAnd) {
+		for _, criterion := range c.Criterion {
+			if matches := ovalReleaseRegex.FindStringSubmatch(criterion.Comment); matches != nil {
+				context.release = matches[1]
+			}
+			if matches := ovalModuleRegex.FindStringSubmatch(criterion.Comment); matches != nil {
+				context.module = matches[1]
+			}
+		}
+	}
+	var refs []ovalTestRef
+	for _, criterion := range c.Criterion {
+		refs = append(refs, ovalTestRef{ref: criterion.TestRef, release: context.release, module: context.module})
+	}
+	for i := range c.Criteria {
+		refs = append(refs, c.Criteria[i].testRefs(context)...)
+	}
+	return refs
+}
+
+// ParseOVAL parses the OVAL definitions read from `reader`, such as the Red Hat OVAL feed of a release, and returns an
+// advisory for each CVE and package the definitions fix.  The `rpminfo` tests against an evr lower than the fixed one
+// give the packages, which apply to the release and to the module stream that the criteria require, as named by the
+// comments of their criteria.  Tests of the signing key are not taken into account.
+func ParseOVAL(reader io.Reader) ([]Advisory, error) {
+	var document ovalDefinitions
+	if err := xml.NewDecoder(reader).Decode(&document); err != nil {
+		return nil, fmt.Errorf("malformed OVAL definitions: %s", err)
+	}
+	tests := map[string]*ovalTest{}
+	for i := range document.Tests {
+		tests[document.Tests[i].ID] = &document.Tests[i]
+	}
+	objects := map[string]string{}
+	for _, object := range document.Objects {
+		objects[object.ID] = strings.TrimSpace(object.Name)
+	}
+	states := map[string]*ovalState{}
+	for i := range document.States {
+		states[document.States[i].ID] = &document.States[i]
+	}
+
+	var advisories []Advisory
+	for i := range document.Definitions {
+		definition := &document.Definitions[i]
+		id, cves := definition.identifiers()
+		severity := parseSeverityOrUnknown(definition.Severity)
+		for _, ref := range definition.Criteria.testRefs(ovalTestRef{}) {
+			test, ok := tests[ref.ref]
+			if !ok {
+				continue
+			}
+			name, state := objects[test.Object.Ref], states[test.State.Ref]
+			if name == "" || state == nil || state.EVR.Operation != ovalLessThan {
+				continue
+			}
+			for _, cve := range cves {
+				advisories = append(advisories, Advisory{ID: id, CVE: cve, Severity: severity, Package: name,
+					Arches: ovalArches(state.Arch), FixedEVR: strings.TrimSpace(state.EVR.Value),
+					Distribution: RedHatDistribution, Release: ref.release, Module: ref.module})
+			}
+		}
+	}
+	return advisories, nil
+}
+
+// identifiers returns the identifier of the advisory of the definition, which is its own identifier when it references
+// no advisory, and the CVEs it fixes, which is the identifier of the advisory when it names none.
+func (d *ovalDefinition) identifiers() (id string, cves []string) {
+	id = d.ID
+	for _, reference := range d.References {
+		if reference.Source == ovalAdvisorySource {
+			id = reference.ID
+			break
+		}
+	}
+	for _, cve := range d.CVEs {
+		if cve = strings.TrimSpace(cve); cve != "" {
+			cves = append(cves, cve)
+		}
+	}
+	if len(cves) == 0 {
+		cves = []string{id}
+	}
+	return id, cves
+}
+
+// ovalArches returns the architectures matched by the arch `pattern` of an OVAL state, which is any when it is empty.
+func ovalArches(pattern string) []string {
+	pattern = strings.Trim(strings.TrimSpace(pattern), "^$()")
+	if pattern == "" {
+		return nil
+	}
+	return strings.Split(pattern, ovalArchSeparator)
+}
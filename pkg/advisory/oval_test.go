@@ -0,0 +1,60 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package advisory_test
+
+import (
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/advisory"
+)
+
+const (
+	testdataDirectory = "testdata"
+	feedDirectory     = "feed"
+)
+
+func TestParseOVAL(t *testing.T) {
+	file, err := os.Open(path.Join(testdataDirectory, feedDirectory, "rhel-8.oval.xml"))
+	assert.Nil(t, err)
+	defer file.Close()
+	advisories, err := advisory.ParseOVAL(file)
+	assert.Nil(t, err)
+	arches := []string{"aarch64", "i686", "ppc64le", "s390x", "x86_64"}
+	// The release and the module stream are those required by the criteria of each package, if any.
+	assert.Equal(t, []advisory.Advisory{
+		{ID: "RHSA-2022:1065", CVE: "CVE-2022-0778", Severity: advisory.SeverityImportant, Package: "openssl",
+			Arches: arches, FixedEVR: "1:1.1.1k-6.el8_5", Distribution: "rhel", Release: "8"},
+		{ID: "RHSA-2022:1065", CVE: "CVE-2022-0778", Severity: advisory.SeverityImportant, Package: "openssl-libs",
+			Arches: arches, FixedEVR: "1:1.1.1k-6.el8_5", Distribution: "rhel", Release: "8"},
+		{ID: "RHSA-2021:4489", CVE: "CVE-2021-20266", Severity: advisory.SeverityModerate, Package: "rpm",
+			FixedEVR: "0:4.14.3-19.el8", Distribution: "rhel"},
+		{ID: "RHSA-2021:4489", CVE: "CVE-2021-20271", Severity: advisory.SeverityModerate, Package: "rpm",
+			FixedEVR: "0:4.14.3-19.el8", Distribution: "rhel"},
+		{ID: "RHSA-2022:0350", CVE: "CVE-2021-44531", Severity: advisory.SeverityModerate, Package: "nodejs",
+			Arches: []string{"aarch64", "ppc64le", "s390x", "x86_64"}, FixedEVR: "1:14.18.2-2.module+el8.5.0+13644+8d46dafd",
+			Distribution: "rhel", Release: "8", Module: "nodejs:14"},
+	}, advisories)
+}
+
+func TestParseOVALMalformed(t *testing.T) {
+	_, err := advisory.ParseOVAL(strings.NewReader("<oval_definitions><definitions>"))
+	assert.NotNil(t, err)
+}
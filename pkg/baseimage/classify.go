@@ -0,0 +1,179 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package baseimage
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Classes of base images.
+const (
+	// ClassUBI is the standard Red Hat Universal Base Image, or its init variant.
+	ClassUBI = "ubi"
+	// ClassUBIMinimal is the minimal Red Hat Universal Base Image, which ships microdnf.
+	ClassUBIMinimal = "ubi-minimal"
+	// ClassUBIMicro is the micro Red Hat Universal Base Image, which ships no package manager.
+	ClassUBIMicro = "ubi-micro"
+	// ClassRHEL is a Red Hat Enterprise Linux image which is not a Universal Base Image.
+	ClassRHEL = "rhel"
+	// ClassOther is an image of another distribution.
+	ClassOther = "other"
+	// ClassDistroless is an image without a distribution, which has neither /etc/os-release nor a package manager.
+	ClassDistroless = "distroless"
+	// ClassUnknown is an image which could neither be inspected nor identified by its labels.
+	ClassUnknown = "unknown"
+)
+
+// Classes are the classes of base images, in order of preference for Red Hat based workloads.
+var Classes = []string{ClassUBIMicro, ClassUBIMinimal, ClassUBI, ClassRHEL, ClassOther, ClassDistroless, ClassUnknown}
+
+const (
+	// componentLabel names the component of a Red Hat image, such as `ubi8-minimal-container`.
+	componentLabel = "com.redhat.component"
+	// nameLabel names the repository of an image, such as `ubi8/ubi-minimal`.
+	nameLabel = "name"
+	// dockerfilePrefix prefixes the Dockerfiles of /root/buildinfo, which are named after the component, version and
+	// release of the image they built, such as `Dockerfile-ubi8-minimal-8.5-218`.
+	dockerfilePrefix = "Dockerfile-"
+	// rhelID is the ID of Red Hat Enterprise Linux in /etc/os-release, which Universal Base Images share.
+	rhelID = "rhel"
+	// distrolessName is part of the PRETTY_NAME of the distroless images, which keep the /etc/os-release of the
+	// distribution their files come from.
+	distrolessName = "distroless"
+	// microdnf is the package manager of the minimal Universal Base Image.
+	microdnf = "microdnf"
+)
+
+var (
+	// ubiRegex matches the component, repository name or Dockerfile of a Universal Base Image, such as
+	// `ubi8-minimal-container`, `ubi8/ubi-micro` or `ubi9`, with its major version and variant.
+	ubiRegex = regexp.MustCompile(`^ubi(\d+)(?:/ubi)?(?:[-/](minimal|micro|init))?(?:-container)?$`)
+	// dockerfileRegex matches the Dockerfile of /root/buildinfo built by a component, which precedes the version and
+	// release.
+	dockerfileRegex = regexp.MustCompile(`^` + dockerfilePrefix + `(.+)-[^-]+-[^-]+$`)
+	// ubiVariantClasses maps the variant of a Universal Base Image to its class.
+	ubiVariantClasses = map[string]string{"": ClassUBI, "init": ClassUBI, "minimal": ClassUBIMinimal, "micro": ClassUBIMicro}
+	// Managers are the package managers of the distributions, which are looked for in the PATH of a container.
+	Managers = []string{"dnf", "yum", microdnf, "rpm", "apt-get", "dpkg", "apk", "zypper"}
+)
+
+// Classification is the class of the base image of a container.
+type Classification struct {
+	// Class is one of Classes.
+	Class string `json:"class"`
+	// Distro is the ID of the distribution, as in /etc/os-release, when it is known.
+	Distro string `json:"distro,omitempty"`
+	// Version is the VERSION_ID of the distribution, or the major version of a Universal Base Image.
+	Version string `json:"version,omitempty"`
+	// Reason is the evidence the classification is based on.
+	Reason string `json:"reason"`
+}
+
+// String returns the class of the base image, followed by its distribution and version when they are known.
+func (c *Classification) String() string {
+	switch {
+	case c.Distro != "" && c.Version != "":
+		return fmt.Sprintf("%s (%s %s)", c.Class, c.Distro, c.Version)
+	case c.Distro != "":
+		return fmt.Sprintf("%s (%s)", c.Class, c.Distro)
+	}
+	return c.Class
+}
+
+// Classify classifies the base image of a container from the `evidence` collected in the container, which is nil when
+// it could not be inspected, and the labels of its image config `imageLabels`, which may be nil too.  The build
+// information of Red Hat images takes precedence over the image labels, which the image may override, and both over
+// /etc/os-release, which Universal Base Images share with Red Hat Enterprise Linux.  Without either, a Red Hat image
+// which only has microdnf is taken for a minimal Universal Base Image, and one which has no package manager for a
+// micro one.
+func Classify(evidence *Evidence, imageLabels map[string]string) Classification {
+	if classification, ok := classifyUBI(evidence, imageLabels); ok {
+		return classification
+	}
+	if evidence == nil {
+		return Classification{Class: ClassUnknown, Reason: "the container could not be inspected, and its image labels " +
+			"name no Universal Base Image"}
+	}
+	id, version := evidence.OSRelease["ID"], evidence.OSRelease["VERSION_ID"]
+	switch {
+	case id == "":
+		return Classification{Class: ClassDistroless, Reason: "no /etc/os-release"}
+	case strings.Contains(strings.ToLower(evidence.OSRelease["PRETTY_NAME"]), distrolessName):
+		return Classification{Class: ClassDistroless, Distro: id, Version: version,
+			Reason: fmt.Sprintf("/etc/os-release PRETTY_NAME %s", evidence.OSRelease["PRETTY_NAME"])}
+	case id == rhelID && !evidence.hasManager(Managers...):
+		return Classification{Class: ClassUBIMicro, Distro: id, Version: version,
+			Reason: "/etc/os-release ID rhel without a package manager"}
+	case id == rhelID && evidence.hasManager(microdnf) && !evidence.hasManager("dnf", "yum"):
+		return Classification{Class: ClassUBIMinimal, Distro: id, Version: version,
+			Reason: "/etc/os-release ID rhel with microdnf as the only package manager"}
+	case id == rhelID:
+		return Classification{Class: ClassRHEL, Distro: id, Version: version, Reason: "/etc/os-release ID rhel"}
+	case !evidence.hasManager(Managers...):
+		return Classification{Class: ClassDistroless, Distro: id, Version: version,
+			Reason: fmt.Sprintf("/etc/os-release ID %s without a package manager", id)}
+	}
+	return Classification{Class: ClassOther, Distro: id, Version: version, Reason: "/etc/os-release ID " + id}
+}
+
+// classifyUBI classifies a Universal Base Image from the build information and labels of its Red Hat base image, or
+// from the labels of its image config.  It returns false if none of them names a Universal Base Image.
+func classifyUBI(evidence *Evidence, imageLabels map[string]string) (Classification, bool) {
+	type source struct {
+		name  string
+		value string
+	}
+	var sources []source
+	if evidence != nil {
+		sources = append(sources,
+			source{componentLabel + " label of /root/buildinfo/labels.json", evidence.Labels[componentLabel]},
+			source{nameLabel + " label of /root/buildinfo/labels.json", evidence.Labels[nameLabel]})
+		for _, file := range evidence.BuildInfo {
+			if matched := dockerfileRegex.FindStringSubmatch(file); matched != nil {
+				sources = append(sources, source{"/root/buildinfo/" + file, matched[1]})
+			}
+		}
+	}
+	sources = append(sources,
+		source{componentLabel + " image label", imageLabels[componentLabel]},
+		source{nameLabel + " image label", imageLabels[nameLabel]})
+	for _, s := range sources {
+		matched := ubiRegex.FindStringSubmatch(strings.ToLower(s.value))
+		if matched == nil {
+			continue
+		}
+		classification := Classification{Class: ubiVariantClasses[matched[2]], Version: matched[1],
+			Reason: fmt.Sprintf("%s %s", s.name, s.value)}
+		if evidence != nil && evidence.OSRelease["ID"] != "" {
+			classification.Distro, classification.Version = evidence.OSRelease["ID"], evidence.OSRelease["VERSION_ID"]
+		}
+		return classification, true
+	}
+	return Classification{}, false
+}
+
+// IsClass returns true if `class` is one of Classes.
+func IsClass(class string) bool {
+	for _, c := range Classes {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
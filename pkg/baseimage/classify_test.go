@@ -0,0 +1,93 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package baseimage_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/baseimage"
+)
+
+func rhelEvidence(managers ...string) *baseimage.Evidence {
+	return &baseimage.Evidence{
+		OSRelease: map[string]string{"ID": "rhel", "VERSION_ID": "8.5", "PRETTY_NAME": "Red Hat Enterprise Linux 8.5 (Ootpa)"},
+		Managers:  managers,
+	}
+}
+
+func TestClassify(t *testing.T) {
+	ubiMinimal := rhelEvidence("microdnf", "rpm")
+	ubiMinimal.BuildInfo = []string{"Dockerfile-myapp-1.0-3", "Dockerfile-ubi8-minimal-8.5-218"}
+	ubiLabels := rhelEvidence("dnf", "yum", "rpm")
+	ubiLabels.Labels = map[string]string{"com.redhat.component": "ubi8-container", "name": "ubi8"}
+	debian := &baseimage.Evidence{OSRelease: map[string]string{"ID": "debian", "VERSION_ID": "11"},
+		Managers: []string{"apt-get", "dpkg"}}
+	distroless := &baseimage.Evidence{OSRelease: map[string]string{"ID": "debian", "VERSION_ID": "11",
+		"PRETTY_NAME": "Distroless"}}
+
+	testCases := []struct {
+		name           string
+		evidence       *baseimage.Evidence
+		imageLabels    map[string]string
+		classification baseimage.Classification
+	}{
+		{"buildinfo", ubiMinimal, map[string]string{"com.redhat.component": "myapp-container"},
+			baseimage.Classification{Class: baseimage.ClassUBIMinimal, Distro: "rhel", Version: "8.5",
+				Reason: "/root/buildinfo/Dockerfile-ubi8-minimal-8.5-218 ubi8-minimal"}},
+		{"labels.json", ubiLabels, nil, baseimage.Classification{Class: baseimage.ClassUBI, Distro: "rhel",
+			Version: "8.5", Reason: "com.redhat.component label of /root/buildinfo/labels.json ubi8-container"}},
+		{"image labels", nil, map[string]string{"name": "ubi9/ubi-micro"}, baseimage.Classification{
+			Class: baseimage.ClassUBIMicro, Version: "9", Reason: "name image label ubi9/ubi-micro"}},
+		{"init", nil, map[string]string{"com.redhat.component": "ubi8-init-container"},
+			baseimage.Classification{Class: baseimage.ClassUBI, Version: "8",
+				Reason: "com.redhat.component image label ubi8-init-container"}},
+		{"rhel", rhelEvidence("yum", "rpm"), map[string]string{"com.redhat.component": "rhel-server-container"},
+			baseimage.Classification{Class: baseimage.ClassRHEL, Distro: "rhel", Version: "8.5",
+				Reason: "/etc/os-release ID rhel"}},
+		{"microdnf", rhelEvidence("microdnf", "rpm"), nil, baseimage.Classification{Class: baseimage.ClassUBIMinimal,
+			Distro: "rhel", Version: "8.5", Reason: "/etc/os-release ID rhel with microdnf as the only package manager"}},
+		{"no package manager", rhelEvidence(), nil, baseimage.Classification{Class: baseimage.ClassUBIMicro,
+			Distro: "rhel", Version: "8.5", Reason: "/etc/os-release ID rhel without a package manager"}},
+		{"debian", debian, nil, baseimage.Classification{Class: baseimage.ClassOther, Distro: "debian", Version: "11",
+			Reason: "/etc/os-release ID debian"}},
+		{"distroless", distroless, nil, baseimage.Classification{Class: baseimage.ClassDistroless, Distro: "debian",
+			Version: "11", Reason: "/etc/os-release PRETTY_NAME Distroless"}},
+		{"no os-release", &baseimage.Evidence{}, nil, baseimage.Classification{Class: baseimage.ClassDistroless,
+			Reason: "no /etc/os-release"}},
+		{"unknown", nil, map[string]string{"name": "myapp"}, baseimage.Classification{Class: baseimage.ClassUnknown,
+			Reason: "the container could not be inspected, and its image labels name no Universal Base Image"}},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.classification, baseimage.Classify(testCase.evidence, testCase.imageLabels),
+			testCase.name)
+	}
+}
+
+func TestClassification_String(t *testing.T) {
+	assert.Equal(t, "ubi-minimal (rhel 8.5)", (&baseimage.Classification{Class: baseimage.ClassUBIMinimal,
+		Distro: "rhel", Version: "8.5"}).String())
+	assert.Equal(t, "other (arch)", (&baseimage.Classification{Class: baseimage.ClassOther, Distro: "arch"}).String())
+	assert.Equal(t, "ubi-micro", (&baseimage.Classification{Class: baseimage.ClassUBIMicro, Version: "9"}).String())
+}
+
+func TestIsClass(t *testing.T) {
+	for _, class := range baseimage.Classes {
+		assert.True(t, baseimage.IsClass(class), class)
+	}
+	assert.False(t, baseimage.IsClass("ubi8"))
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package baseimage
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Records of the evidence collected from a container, each given as a tab separated line starting with its type.
+const (
+	// osReleaseRecord is a line of /etc/os-release, as `os-release<TAB>KEY=VALUE`.
+	osReleaseRecord = "os-release"
+	// buildInfoRecord is a file of /root/buildinfo, as `buildinfo<TAB>name`.
+	buildInfoRecord = "buildinfo"
+	// labelsRecord is /root/buildinfo/labels.json on a single line, as `labels<TAB>{...}`.
+	labelsRecord = "labels"
+	// managerRecord is a package manager found in the PATH, as `manager<TAB>name`.
+	managerRecord = "manager"
+	// recordSeparator separates the type of a record from its value.
+	recordSeparator = "\t"
+	// osReleaseSeparator separates the key of an /etc/os-release line from its value.
+	osReleaseSeparator = "="
+)
+
+// Evidence is what a container reveals about its base image.
+type Evidence struct {
+	// OSRelease are the variables of /etc/os-release, such as `ID` and `VERSION_ID`, unquoted.
+	OSRelease map[string]string
+	// BuildInfo are the names of the files of /root/buildinfo, such as `Dockerfile-ubi8-minimal-8.5-218`.
+	BuildInfo []string
+	// Labels are the labels of /root/buildinfo/labels.json, which are those of the Red Hat image the container is
+	// built from, even when the labels of its own image override them.
+	Labels map[string]string
+	// Managers are the package managers found in the PATH of the container, such as `dnf`, `microdnf` or `rpm`.
+	Managers []string
+}
+
+// ParseEvidence parses the tab separated `output` listing the evidence of a container.  Lines of /etc/os-release are
+// listed as `os-release<TAB>KEY=VALUE`, files of /root/buildinfo as `buildinfo<TAB>name`, the labels of
+// /root/buildinfo/labels.json as `labels<TAB>{...}` and package managers as `manager<TAB>name`.  Any other line, such
+// as the echo of the command, is ignored.
+func ParseEvidence(output string) *Evidence {
+	evidence := &Evidence{OSRelease: map[string]string{}, Labels: map[string]string{}}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.SplitN(strings.TrimRight(line, "\r"), recordSeparator, 2)
+		if len(fields) != 2 || fields[1] == "" {
+			continue
+		}
+		switch fields[0] {
+		case osReleaseRecord:
+			if i := strings.Index(fields[1], osReleaseSeparator); i > 0 && !strings.HasPrefix(fields[1], "#") {
+				evidence.OSRelease[fields[1][:i]] = unquote(fields[1][i+1:])
+			}
+		case buildInfoRecord:
+			evidence.BuildInfo = append(evidence.BuildInfo, fields[1])
+		case labelsRecord:
+			// Labels which cannot be parsed are no evidence.
+			_ = json.Unmarshal([]byte(fields[1]), &evidence.Labels)
+		case managerRecord:
+			evidence.Managers = append(evidence.Managers, fields[1])
+		}
+	}
+	return evidence
+}
+
+// hasManager returns true if the container has any of the package managers `managers`.
+func (e *Evidence) hasManager(managers ...string) bool {
+	for _, manager := range e.Managers {
+		for _, m := range managers {
+			if manager == m {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// unquote removes the quotes around an /etc/os-release `value`.
+func unquote(value string) string {
+	for _, quote := range []string{`"`, `'`} {
+		if len(value) >= 2 && strings.HasPrefix(value, quote) && strings.HasSuffix(value, quote) {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
@@ -0,0 +1,49 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package baseimage_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/baseimage"
+)
+
+func TestParseEvidence(t *testing.T) {
+	evidence := baseimage.ParseEvidence(`(cat /etc/os-release || cat /usr/lib/os-release) 2>/dev/null | ...
+os-release	# Comment
+os-release	NAME="Red Hat Enterprise Linux"
+os-release	ID='rhel'
+os-release	VERSION_ID=8.5
+os-release	
+buildinfo	Dockerfile-ubi8-micro-8.5-437
+buildinfo	labels.json
+labels	{"com.redhat.component": "ubi8-micro-container",  "name": "ubi8/ubi-micro"}
+manager	rpm
+BASEIMAGE_EXIT_STATUS=0
+`)
+	assert.Equal(t, map[string]string{"NAME": "Red Hat Enterprise Linux", "ID": "rhel", "VERSION_ID": "8.5"},
+		evidence.OSRelease)
+	assert.Equal(t, []string{"Dockerfile-ubi8-micro-8.5-437", "labels.json"}, evidence.BuildInfo)
+	assert.Equal(t, map[string]string{"com.redhat.component": "ubi8-micro-container", "name": "ubi8/ubi-micro"},
+		evidence.Labels)
+	assert.Equal(t, []string{"rpm"}, evidence.Managers)
+
+	evidence = baseimage.ParseEvidence("labels\t{\"name\": \r\nBASEIMAGE_EXIT_STATUS=0\r\n")
+	assert.Empty(t, evidence.OSRelease)
+	assert.Empty(t, evidence.Labels)
+}
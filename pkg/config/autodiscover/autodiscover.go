@@ -18,29 +18,20 @@ package autodiscover
 
 import (
 	"fmt"
-	"os"
 	"os/exec"
-	"strconv"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/test-network-function/test-network-function/pkg/config/configsections"
 )
 
 const (
-	disableAutodiscoverEnvVar = "TNF_DISABLE_CONFIG_AUTODISCOVER"
-	tnfNamespace              = "test-network-function.com"
-	labelTemplate             = "%s/%s"
+	tnfNamespace  = "test-network-function.com"
+	labelTemplate = "%s/%s"
 
 	// anyLabelValue is the value that will allow any value for a label when building the label query.
 	anyLabelValue = ""
 )
 
-// PerformAutoDiscovery checks the environment variable to see if autodiscovery should be performed
-func PerformAutoDiscovery() (doAuto bool) {
-	doAuto, _ = strconv.ParseBool(os.Getenv(disableAutodiscoverEnvVar))
-	return !doAuto
-}
-
 func buildLabelName(labelNS, labelName string) string {
 	if labelNS == "" {
 		return labelName
@@ -60,9 +51,15 @@ func buildLabelQuery(label configsections.Label) string {
 	return namespacedLabel
 }
 
+// makeGetCommand builds the command to get resources of a type in all namespaces, restricted to those matching
+// `labelQuery` unless it is empty.
 func makeGetCommand(resourceType, labelQuery string) *exec.Cmd {
 	// TODO: shell expecter
-	cmd := exec.Command("oc", "get", resourceType, "-A", "-o", "json", "-l", labelQuery)
+	args := []string{"get", resourceType, "-A", "-o", "json"}
+	if labelQuery != "" {
+		args = append(args, "-l", labelQuery)
+	}
+	cmd := exec.Command("oc", args...)
 	log.Debug("Issuing get command ", cmd.Args)
 
 	return cmd
@@ -113,15 +110,28 @@ func buildContainersFromPodResource(pr *PodResource) (containers []configsection
 		container.Namespace = pr.Metadata.Namespace
 		container.PodName = pr.Metadata.Name
 		container.ContainerName = containerResource.Name
+		container.Image = containerResource.Image
+		container.ImageID = pr.getContainerImageID(containerResource.Name)
+		container.NodeName = pr.Spec.NodeName
+		for _, port := range containerResource.Ports {
+			protocol := port.Protocol
+			if protocol == "" {
+				protocol = configsections.ProtocolTCP
+			}
+			container.Ports = append(container.Ports, configsections.ContainerPort{Name: port.Name, Port: port.ContainerPort, Protocol: protocol})
+		}
+		container.ResourceRequests = containerResource.Resources.Requests
+		container.ResourceLimits = containerResource.Resources.Limits
 		container.DefaultNetworkDevice, err = pr.getDefaultNetworkDeviceFromAnnotations()
 		if err != nil {
 			log.Warnf("error encountered getting default network device: %s", err)
 		}
-		container.MultusIPAddresses, err = pr.getPodIPs()
+		ips, err := pr.getPodIPs()
 		if err != nil {
 			log.Warnf("error encountered getting multus IPs: %s", err)
 			err = nil
 		}
+		container.MultusIPAddresses, container.MultusIPv6Addresses = splitIPsByFamily(ips)
 
 		containers = append(containers, container)
 	}
@@ -38,12 +38,14 @@ var (
 // using labels and annotations to populate the data.
 func FindTestTarget(labels []configsections.Label) (target configsections.TestTarget) {
 	// find pods by label
+	var podResources []*PodResource
 	for _, l := range labels {
 		pods, err := GetPodsByLabel(l)
 		if err == nil {
 			for i := range pods.Items {
 				target.PodsUnderTest = append(target.PodsUnderTest, buildPodUnderTest(&pods.Items[i]))
 				target.ContainersUnderTest = append(target.ContainersUnderTest, buildContainersFromPodResource(&pods.Items[i])...)
+				podResources = append(podResources, &pods.Items[i])
 			}
 		} else {
 			log.Warnf("failed to query by label: %v %v", l, err)
@@ -57,10 +59,47 @@ func FindTestTarget(labels []configsections.Label) (target configsections.TestTa
 		log.Warnf("an error (%s) occurred when getting the containers to exclude from connectivity tests. Attempting to continue", err)
 	}
 
+	if len(podResources) > 0 {
+		services, err := GetServices()
+		if err == nil {
+			target.Services = findServicesForPods(services, podResources)
+		} else {
+			log.Warnf("an error (%s) occurred when looking for the services of the pods under test", err)
+		}
+		if len(target.Services) > 0 {
+			endpoints, err := GetEndpoints()
+			if err == nil {
+				fillServiceEndpoints(target.Services, endpoints)
+			} else {
+				log.Warnf("an error (%s) occurred when looking for the endpoints of the services under test", err)
+			}
+		}
+	}
+
+	if len(podResources) > 0 {
+		policies, err := GetNetworkPolicies()
+		if err == nil {
+			target.NetworkPolicies = findNetworkPoliciesForPods(policies, podResources)
+		} else {
+			log.Warnf("an error (%s) occurred when looking for the network policies of the pods under test", err)
+		}
+	}
+
+	if hasRequestedNetworks(target.PodsUnderTest) {
+		definitions, err := GetNetworkAttachmentDefinitions()
+		if err == nil {
+			target.NetworkAttachmentDefinitions = findNetworkAttachmentDefinitionsForPods(definitions, target.PodsUnderTest)
+		} else {
+			log.Warnf("an error (%s) occurred when looking for the network attachment definitions of the pods under test", err)
+		}
+	}
+
 	csvs, err := GetCSVsByLabel(operatorLabelName, anyLabelValue)
 	if err == nil {
 		for i := range csvs.Items {
-			target.Operators = append(target.Operators, buildOperatorFromCSVResource(&csvs.Items[i]))
+			op := buildOperatorFromCSVResource(&csvs.Items[i])
+			fillCRInstances(&op)
+			target.Operators = append(target.Operators, op)
 		}
 	} else {
 		log.Warnf("an error (%s) occurred when looking for operaters by label", err)
@@ -74,6 +113,7 @@ func buildPodUnderTest(pr *PodResource) (cnf configsections.Pod) {
 	var err error
 	cnf.Namespace = pr.Metadata.Namespace
 	cnf.Name = pr.Metadata.Name
+	cnf.Labels = pr.Metadata.Labels
 
 	var tests []string
 	err = pr.GetAnnotationValue(podTestsAnnotationName, &tests)
@@ -83,6 +123,14 @@ func buildPodUnderTest(pr *PodResource) (cnf configsections.Pod) {
 	} else {
 		cnf.Tests = tests
 	}
+	cnf.Networks, err = pr.getNetworks()
+	if err != nil {
+		log.Warnf("unable to extract the requested networks of '%s/%s' (error: %s)", cnf.Namespace, cnf.Name, err)
+	}
+	cnf.NetworksStatus, err = pr.getNetworksStatus()
+	if err != nil {
+		log.Warnf("unable to extract the network status of '%s/%s' (error: %s)", cnf.Namespace, cnf.Name, err)
+	}
 	return
 }
 
@@ -123,9 +171,28 @@ func buildOperatorFromCSVResource(csv *CSVResource) (op configsections.Operator)
 	}
 	op.SubscriptionName = subscriptionName
 
+	op.InstallModes = csv.Spec.InstallModes
+	op.OwnedCrds = csv.Spec.CustomResourceDefinitions.Owned
+	op.RequiredCrds = csv.Spec.CustomResourceDefinitions.Required
+	op.Deployments = csv.getDeployments()
+	op.Permissions = csv.getPermissions()
+
 	return op
 }
 
+// fillCRInstances lists the live instances of the CRDs owned by an operator, so that tests can compare them with what
+// the operator declares.
+func fillCRInstances(op *configsections.Operator) {
+	for i := range op.OwnedCrds {
+		instances, err := GetCRInstances(op.OwnedCrds[i].Name)
+		if err != nil {
+			log.Warnf("unable to list the instances of CRD %s owned by operator %s/%s (error: %s)", op.OwnedCrds[i].Name, op.Namespace, op.Name, err)
+			continue
+		}
+		op.OwnedCrds[i].Instances = instances
+	}
+}
+
 // getConfiguredOperatorTests loads the `configuredTestFile` used by the `operator` specs and extracts
 // the names of test groups from it.
 func getConfiguredOperatorTests() (opTests []string) {
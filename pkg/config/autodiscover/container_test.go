@@ -48,4 +48,29 @@ func TestBuildContainersFromPodResource(t *testing.T) {
 	assert.Equal(t, 2, len(subjectContainers[0].MultusIPAddresses))
 	assert.Equal(t, "3.3.3.3", subjectContainers[0].MultusIPAddresses[0])
 	assert.Equal(t, "4.4.4.4", subjectContainers[0].MultusIPAddresses[1])
+	// IPv6 addresses are kept apart from IPv4 ones.
+	assert.Equal(t, 0, len(orchestratorContainers[0].MultusIPv6Addresses))
+	assert.Equal(t, []string{"fd00:10::3"}, subjectContainers[0].MultusIPv6Addresses)
+
+	// Check image and node placement are recorded when present.
+	assert.Equal(t, "quay.io/testnetworkfunction/cnf-test-partner:latest", subjectContainers[0].Image)
+	assert.Equal(t, "quay.io/testnetworkfunction/cnf-test-partner@sha256:1c0f2c5cd4e0e5d7bbcb7d6a8c3f3a07f5d4dd4aa8b9bd7d3cfd1d5b1e4f7a2d",
+		subjectContainers[0].ImageID)
+	assert.Equal(t, "worker-0", subjectContainers[0].NodeName)
+	assert.Equal(t, "", orchestratorContainers[0].ImageID)
+	assert.Equal(t, "", orchestratorContainers[0].NodeName)
+
+	// Check resource requests and limits, such as SR-IOV VFs, are recorded.
+	assert.Equal(t, map[string]string{"cpu": "100m", "openshift.io/sriov_nics": "1"}, subjectContainers[0].ResourceRequests)
+	assert.Equal(t, map[string]string{"cpu": "100m", "openshift.io/sriov_nics": "1"}, subjectContainers[0].ResourceLimits)
+	assert.Nil(t, orchestratorContainers[0].ResourceRequests)
+}
+
+func TestSplitIPsByFamily(t *testing.T) {
+	ipv4, ipv6 := splitIPsByFamily([]string{"10.217.1.89", "fd00:10::3", "not-an-ip", "192.168.1.1", "::1"})
+	assert.Equal(t, []string{"10.217.1.89", "192.168.1.1"}, ipv4)
+	assert.Equal(t, []string{"fd00:10::3", "::1"}, ipv6)
+	ipv4, ipv6 = splitIPsByFamily(nil)
+	assert.Nil(t, ipv4)
+	assert.Nil(t, ipv6)
 }
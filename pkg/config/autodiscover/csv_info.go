@@ -40,6 +40,41 @@ type CSVResource struct {
 		Labels      map[string]string `json:"labels"`
 		Annotations map[string]string `json:"annotations"`
 	} `json:"metadata"`
+	Spec struct {
+		InstallModes              []configsections.InstallMode `json:"installModes"`
+		CustomResourceDefinitions struct {
+			Owned    []configsections.Crd `json:"owned"`
+			Required []configsections.Crd `json:"required"`
+		} `json:"customresourcedefinitions"`
+		Install struct {
+			Spec struct {
+				Deployments []struct {
+					Name string `json:"name"`
+					Spec struct {
+						Replicas *int `json:"replicas"`
+					} `json:"spec"`
+				} `json:"deployments"`
+				Permissions        []csvPermission `json:"permissions"`
+				ClusterPermissions []csvPermission `json:"clusterPermissions"`
+			} `json:"spec"`
+		} `json:"install"`
+	} `json:"spec"`
+}
+
+// csvPermission is a set of rules granted to a service account in a CSV
+type csvPermission struct {
+	ServiceAccountName string                      `json:"serviceAccountName"`
+	Rules              []configsections.PolicyRule `json:"rules"`
+}
+
+// ResourceList holds the metadata of the items from an `oc get <resource> -o json` command
+type ResourceList struct {
+	Items []struct {
+		Metadata struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+	} `json:"items"`
 }
 
 func (csv *CSVResource) hasAnnotation(annotationKey string) (present bool) {
@@ -61,6 +96,29 @@ func (csv *CSVResource) GetAnnotationValue(annotationKey string, v interface{})
 	return
 }
 
+// getDeployments returns the deployments declared by the CSV.  Kubernetes defaults an unset replica count to 1.
+func (csv *CSVResource) getDeployments() (deployments []configsections.Deployment) {
+	for _, d := range csv.Spec.Install.Spec.Deployments {
+		replicas := 1
+		if d.Spec.Replicas != nil {
+			replicas = *d.Spec.Replicas
+		}
+		deployments = append(deployments, configsections.Deployment{Name: d.Name, Replicas: replicas})
+	}
+	return
+}
+
+// getPermissions returns the cluster and namespace permissions declared by the CSV.
+func (csv *CSVResource) getPermissions() (permissions []configsections.Permission) {
+	for _, p := range csv.Spec.Install.Spec.ClusterPermissions {
+		permissions = append(permissions, configsections.Permission{Name: p.ServiceAccountName, Role: configsections.ClusterRole, Rules: p.Rules})
+	}
+	for _, p := range csv.Spec.Install.Spec.Permissions {
+		permissions = append(permissions, configsections.Permission{Name: p.ServiceAccountName, Role: configsections.NamespaceRole, Rules: p.Rules})
+	}
+	return
+}
+
 func (csv *CSVResource) annotationUnmarshalError(annotationKey string, err error) error {
 	return fmt.Errorf("error (%s) attempting to unmarshal value of annotation '%s' on CSV '%s/%s'",
 		err, annotationKey, csv.Metadata.Namespace, csv.Metadata.Name)
@@ -84,3 +142,25 @@ func GetCSVsByLabel(labelName, labelValue string) (*CSVList, error) {
 
 	return &csvList, nil
 }
+
+// GetCRInstances returns the live custom resources of a CRD, given as plural.group, in all namespaces.
+func GetCRInstances(crdName string) ([]configsections.Instance, error) {
+	cmd := makeGetCommand(crdName, "")
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var resourceList ResourceList
+	err = json.Unmarshal(out, &resourceList)
+	if err != nil {
+		return nil, err
+	}
+
+	instances := []configsections.Instance{}
+	for _, item := range resourceList.Items {
+		instances = append(instances, configsections.Instance{Name: item.Metadata.Name, Namespace: item.Metadata.Namespace})
+	}
+	return instances, nil
+}
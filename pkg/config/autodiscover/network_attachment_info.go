@@ -0,0 +1,97 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package autodiscover
+
+import (
+	"encoding/json"
+
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+const (
+	resourceTypeNetworkAttachmentDefinitions = "network-attachment-definitions"
+	// resourceNameAnnotationKey names the device plugin resource that a network attachment allocates devices from.
+	resourceNameAnnotationKey = "k8s.v1.cni.cncf.io/resourceName"
+)
+
+// NetworkAttachmentDefinitionList holds the data from an `oc get network-attachment-definitions -o json` command
+type NetworkAttachmentDefinitionList struct {
+	Items []NetworkAttachmentDefinitionResource `json:"items"`
+}
+
+// NetworkAttachmentDefinitionResource is a single NetworkAttachmentDefinition from an
+// `oc get network-attachment-definitions -o json` command
+type NetworkAttachmentDefinitionResource struct {
+	Metadata struct {
+		Name        string            `json:"name"`
+		Namespace   string            `json:"namespace"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"metadata"`
+	Spec struct {
+		Config string `json:"config"`
+	} `json:"spec"`
+}
+
+// GetNetworkAttachmentDefinitions returns the network attachment definitions of all namespaces.
+func GetNetworkAttachmentDefinitions() (*NetworkAttachmentDefinitionList, error) {
+	cmd := makeGetCommand(resourceTypeNetworkAttachmentDefinitions, "")
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var definitionList NetworkAttachmentDefinitionList
+	err = json.Unmarshal(out, &definitionList)
+	if err != nil {
+		return nil, err
+	}
+
+	return &definitionList, nil
+}
+
+// hasRequestedNetworks returns true if any of `pods` requests a secondary network.
+func hasRequestedNetworks(pods []configsections.Pod) bool {
+	for i := range pods {
+		if len(pods[i].Networks) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// findNetworkAttachmentDefinitionsForPods returns the network attachment definitions requested by `pods`.
+func findNetworkAttachmentDefinitionsForPods(definitions *NetworkAttachmentDefinitionList, pods []configsections.Pod) (found []configsections.NetworkAttachmentDefinition) {
+	requested := make(map[string]bool)
+	for i := range pods {
+		for _, network := range pods[i].Networks {
+			requested[network.Namespace+"/"+network.Name] = true
+		}
+	}
+	for i := range definitions.Items {
+		item := &definitions.Items[i]
+		if requested[item.Metadata.Namespace+"/"+item.Metadata.Name] {
+			found = append(found, configsections.NetworkAttachmentDefinition{
+				Name:         item.Metadata.Name,
+				Namespace:    item.Metadata.Namespace,
+				ResourceName: item.Metadata.Annotations[resourceNameAnnotationKey],
+				Config:       item.Spec.Config,
+			})
+		}
+	}
+	return
+}
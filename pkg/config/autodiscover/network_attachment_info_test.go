@@ -0,0 +1,60 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package autodiscover
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+const (
+	testNetworkAttachmentDefinitionsFile = "networkattachmentdefinitions.json"
+)
+
+var (
+	testNetworkAttachmentDefinitionsFilePath = path.Join(filePath, testNetworkAttachmentDefinitionsFile)
+)
+
+func TestFindNetworkAttachmentDefinitionsForPods(t *testing.T) {
+	contents, err := ioutil.ReadFile(testNetworkAttachmentDefinitionsFilePath)
+	assert.Nil(t, err)
+	definitions := &NetworkAttachmentDefinitionList{}
+	assert.Nil(t, json.Unmarshal(contents, definitions))
+	subjectPod := loadPodResource(testSubjectFilePath)
+	pods := []configsections.Pod{buildPodUnderTest(&subjectPod)}
+	assert.True(t, hasRequestedNetworks(pods))
+
+	// macvlan-net is requested from another namespace, so only sriov-net is found.
+	found := findNetworkAttachmentDefinitionsForPods(definitions, pods)
+	assert.Equal(t, []configsections.NetworkAttachmentDefinition{
+		{
+			Name:         "sriov-net",
+			Namespace:    "tnf",
+			ResourceName: "openshift.io/sriov_nics",
+			Config:       `{"cniVersion": "0.3.1", "name": "sriov-net", "type": "sriov", "vlan": 100, "ipam": {"type": "static"}}`,
+		},
+	}, found)
+	assert.True(t, found[0].IsSRIOV())
+
+	pods[0].Networks = nil
+	assert.False(t, hasRequestedNetworks(pods))
+}
@@ -0,0 +1,125 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package autodiscover
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+const (
+	resourceTypeNetworkPolicies = "networkpolicies"
+)
+
+// NetworkPolicyList holds the data from an `oc get networkpolicies -o json` command
+type NetworkPolicyList struct {
+	Items []NetworkPolicyResource `json:"items"`
+}
+
+// NetworkPolicyResource is a single NetworkPolicy from an `oc get networkpolicies -o json` command
+type NetworkPolicyResource struct {
+	Metadata struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	} `json:"metadata"`
+	Spec struct {
+		PodSelector configsections.LabelSelector `json:"podSelector"`
+		PolicyTypes []string                     `json:"policyTypes"`
+		Ingress     []struct {
+			From []struct {
+				PodSelector       *configsections.LabelSelector `json:"podSelector"`
+				NamespaceSelector *configsections.LabelSelector `json:"namespaceSelector"`
+				IPBlock           *struct {
+					CIDR string `json:"cidr"`
+				} `json:"ipBlock"`
+			} `json:"from"`
+			Ports []struct {
+				Protocol string `json:"protocol"`
+				// Port is either a port number or a port name.
+				Port    json.RawMessage `json:"port"`
+				EndPort int             `json:"endPort"`
+			} `json:"ports"`
+		} `json:"ingress"`
+	} `json:"spec"`
+}
+
+// GetNetworkPolicies returns the network policies of all namespaces.
+func GetNetworkPolicies() (*NetworkPolicyList, error) {
+	cmd := makeGetCommand(resourceTypeNetworkPolicies, "")
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var policyList NetworkPolicyList
+	err = json.Unmarshal(out, &policyList)
+	if err != nil {
+		return nil, err
+	}
+
+	return &policyList, nil
+}
+
+// buildNetworkPolicy builds a `configsections.NetworkPolicy` from a NetworkPolicyResource.
+func buildNetworkPolicy(npr *NetworkPolicyResource) configsections.NetworkPolicy {
+	policy := configsections.NetworkPolicy{
+		Name:        npr.Metadata.Name,
+		Namespace:   npr.Metadata.Namespace,
+		PodSelector: npr.Spec.PodSelector,
+		PolicyTypes: npr.Spec.PolicyTypes,
+	}
+	for _, ingress := range npr.Spec.Ingress {
+		var rule configsections.NetworkPolicyIngressRule
+		for _, from := range ingress.From {
+			peer := configsections.NetworkPolicyPeer{PodSelector: from.PodSelector, NamespaceSelector: from.NamespaceSelector}
+			if from.IPBlock != nil {
+				peer.IPBlock = from.IPBlock.CIDR
+			}
+			rule.From = append(rule.From, peer)
+		}
+		for _, port := range ingress.Ports {
+			protocol := port.Protocol
+			if protocol == "" {
+				protocol = configsections.ProtocolTCP
+			}
+			rule.Ports = append(rule.Ports, configsections.NetworkPolicyPort{
+				Protocol: protocol,
+				Port:     strings.Trim(strings.TrimPrefix(string(port.Port), "null"), `"`),
+				EndPort:  port.EndPort,
+			})
+		}
+		policy.Ingress = append(policy.Ingress, rule)
+	}
+	return policy
+}
+
+// findNetworkPoliciesForPods returns the network policies of the namespaces of `pods`.
+func findNetworkPoliciesForPods(policies *NetworkPolicyList, pods []*PodResource) (found []configsections.NetworkPolicy) {
+	namespaces := make(map[string]bool)
+	for _, pod := range pods {
+		namespaces[pod.Metadata.Namespace] = true
+	}
+	for i := range policies.Items {
+		if namespaces[policies.Items[i].Metadata.Namespace] {
+			found = append(found, buildNetworkPolicy(&policies.Items[i]))
+		}
+	}
+	return
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package autodiscover
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+const (
+	testNetworkPoliciesFile = "networkpolicies.json"
+)
+
+var (
+	testNetworkPoliciesFilePath = path.Join(filePath, testNetworkPoliciesFile)
+)
+
+func TestFindNetworkPoliciesForPods(t *testing.T) {
+	contents, err := ioutil.ReadFile(testNetworkPoliciesFilePath)
+	assert.Nil(t, err)
+	policies := &NetworkPolicyList{}
+	assert.Nil(t, json.Unmarshal(contents, policies))
+	subjectPod := loadPodResource(testSubjectFilePath)
+
+	found := findNetworkPoliciesForPods(policies, []*PodResource{&subjectPod})
+	assert.Equal(t, []configsections.NetworkPolicy{
+		{
+			Name:        "default-deny",
+			Namespace:   "tnf",
+			PolicyTypes: []string{configsections.PolicyTypeIngress},
+		},
+		{
+			Name:        "allow-http",
+			Namespace:   "tnf",
+			PodSelector: configsections.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+			PolicyTypes: []string{configsections.PolicyTypeIngress},
+			Ingress: []configsections.NetworkPolicyIngressRule{
+				{
+					From: []configsections.NetworkPolicyPeer{
+						{NamespaceSelector: &configsections.LabelSelector{MatchLabels: map[string]string{"name": "tnf"}}},
+						{IPBlock: "10.0.0.0/8"},
+					},
+					Ports: []configsections.NetworkPolicyPort{
+						{Protocol: configsections.ProtocolTCP, Port: "http"},
+						{Protocol: configsections.ProtocolUDP, Port: "5353"},
+					},
+				},
+			},
+		},
+	}, found)
+
+	assert.True(t, found[0].IsDefaultDenyIngress())
+	pod := buildPodUnderTest(&subjectPod)
+	assert.True(t, found[1].SelectsPod(&pod))
+	assert.True(t, found[1].AllowsIngressPort(configsections.ProtocolTCP, 8080, "http"))
+}
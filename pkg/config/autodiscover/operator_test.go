@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
 )
 
 func TestBuildOperatorFromCSVResource(t *testing.T) {
@@ -29,4 +30,19 @@ func TestBuildOperatorFromCSVResource(t *testing.T) {
 	assert.Equal(t, "CSVNamespace", operator.Namespace)
 	assert.Equal(t, "CSVName", operator.Name)
 	assert.Equal(t, []string{"OPERATOR_STATUS", "ANOTHER_TEST"}, operator.Tests)
+
+	assert.Equal(t, []configsections.InstallMode{{Type: "OwnNamespace", Supported: true}, {Type: "AllNamespaces", Supported: false}},
+		operator.InstallModes)
+	assert.Equal(t, []configsections.Crd{{Name: "etcdclusters.etcd.database.coreos.com", Kind: "EtcdCluster", Version: "v1beta2"}},
+		operator.OwnedCrds)
+	assert.Equal(t, []configsections.Crd{{Name: "backups.example.com", Kind: "Backup", Version: "v1"}}, operator.RequiredCrds)
+	// An unset replica count defaults to 1.
+	assert.Equal(t, []configsections.Deployment{{Name: "etcd-operator", Replicas: 2}, {Name: "etcd-webhook", Replicas: 1}},
+		operator.Deployments)
+	assert.Equal(t, 2, len(operator.Permissions))
+	assert.Equal(t, configsections.ClusterRole, operator.Permissions[0].Role)
+	assert.Equal(t, []string{"nodes"}, operator.Permissions[0].Rules[0].Resources)
+	assert.Equal(t, configsections.NamespaceRole, operator.Permissions[1].Role)
+	assert.Equal(t, "etcd-operator", operator.Permissions[1].Name)
+	assert.Equal(t, []string{"*"}, operator.Permissions[1].Rules[0].Verbs)
 }
@@ -0,0 +1,240 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package autodiscover
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+const (
+	defaultPartnerNamespace               = "tnf-partner"
+	defaultPartnerImage                   = "quay.io/testnetworkfunction/cnf-test-partner:latest"
+	defaultPartnerReadinessTimeoutSeconds = 300
+	defaultPartnerNetworkInterface        = "eth0"
+
+	partnerServiceAccountName  = "tnf-partner"
+	orchestratorDeploymentName = "tnf-orchestrator"
+	fsDiffMasterDeploymentName = "tnf-fs-diff-master"
+	deployedByLabelName        = "deployed-by"
+	deployedByLabelValue       = "tnf"
+	hostContainerStoragePath   = "/var/lib/containers"
+)
+
+// DeployedPartner records the partner workloads deployed by DeployTestPartner, so that they can be torn down after
+// the run.
+type DeployedPartner struct {
+	// manifest is the list of resources to delete on teardown.
+	manifest []byte
+	// namespace is the namespace the partner workloads are deployed in.
+	namespace string
+	// sccGranted is set once the partner service account has been granted the privileged SCC, which must be revoked
+	// on teardown as it outlives the service account.
+	sccGranted bool
+}
+
+// IsTestOrchestratorMissing returns true when the test partner does not name a test orchestrator and none can be found
+// in the cluster through its label.
+func IsTestOrchestratorMissing(tp *configsections.TestPartner) (bool, error) {
+	if tp.TestOrchestrator.ContainerName != "" {
+		return false, nil
+	}
+	pods, err := GetPodsByLabel(configsections.Label{Namespace: tnfNamespace, Name: genericLabelName, Value: orchestratorValue})
+	if err != nil {
+		return false, err
+	}
+	return len(pods.Items) == 0, nil
+}
+
+// DeployTestPartner creates the test orchestrator and fs diff master workloads described by `deployment`, labeled so
+// that FillTestPartner discovers them, and waits for them to become ready.
+func DeployTestPartner(deployment configsections.PartnerDeployment) (*DeployedPartner, error) {
+	deployment = withPartnerDeploymentDefaults(deployment)
+	log.Infof("Deploying the test partner in namespace %s", deployment.Namespace)
+
+	createNamespace := !namespaceExists(deployment.Namespace)
+	manifest, err := buildPartnerManifest(&deployment, createNamespace)
+	if err != nil {
+		return nil, err
+	}
+	// A failed apply may still have created some of the resources, which Teardown removes.
+	deployed := &DeployedPartner{manifest: manifest, namespace: deployment.Namespace}
+	err = runOcWithInput(manifest, "apply", "-f", "-")
+	if err != nil {
+		return deployed, err
+	}
+
+	// The fs diff master container is privileged, which OpenShift only allows once granted by a security context
+	// constraint.  It is granted to the partner's own service account, never to one the namespace already had.  Other
+	// clusters have no such command, and need nothing granted.
+	err = runOcWithInput(nil, "adm", "policy", "add-scc-to-user", "privileged", "-z", partnerServiceAccountName,
+		"-n", deployment.Namespace)
+	if err != nil {
+		log.Warnf("unable to grant the privileged SCC to the test partner, attempting to continue: %s", err)
+	} else {
+		deployed.sccGranted = true
+	}
+
+	err = runOcWithInput(nil, "wait", "--for=condition=Available", "-n", deployment.Namespace,
+		fmt.Sprintf("--timeout=%ds", deployment.ReadinessTimeoutSeconds),
+		"deployment/"+orchestratorDeploymentName, "deployment/"+fsDiffMasterDeploymentName)
+	if err != nil {
+		return deployed, fmt.Errorf("the test partner did not become ready: %s", err)
+	}
+	return deployed, nil
+}
+
+// Teardown revokes the SCC granted to the partner service account, then deletes the deployed partner workloads, along
+// with their namespace if it was created for them.
+func (d *DeployedPartner) Teardown() error {
+	log.Info("Removing the deployed test partner")
+	if d.sccGranted {
+		err := runOcWithInput(nil, "adm", "policy", "remove-scc-from-user", "privileged", "-z", partnerServiceAccountName,
+			"-n", d.namespace)
+		if err != nil {
+			return err
+		}
+		d.sccGranted = false
+	}
+	return runOcWithInput(d.manifest, "delete", "--ignore-not-found", "--wait=false", "-f", "-")
+}
+
+// withPartnerDeploymentDefaults fills the empty fields of `deployment` with their default values.
+func withPartnerDeploymentDefaults(deployment configsections.PartnerDeployment) configsections.PartnerDeployment {
+	if deployment.Namespace == "" {
+		deployment.Namespace = defaultPartnerNamespace
+	}
+	if deployment.OrchestratorImage == "" {
+		deployment.OrchestratorImage = defaultPartnerImage
+	}
+	if deployment.FsDiffImage == "" {
+		deployment.FsDiffImage = defaultPartnerImage
+	}
+	if deployment.ReadinessTimeoutSeconds <= 0 {
+		deployment.ReadinessTimeoutSeconds = defaultPartnerReadinessTimeoutSeconds
+	}
+	return deployment
+}
+
+// buildPartnerManifest renders the partner workloads as a JSON list of resources, including their namespace when
+// `createNamespace` is set.
+func buildPartnerManifest(deployment *configsections.PartnerDeployment, createNamespace bool) ([]byte, error) {
+	var items []interface{}
+	if createNamespace {
+		items = append(items, map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Namespace",
+			"metadata":   map[string]interface{}{"name": deployment.Namespace},
+		})
+	}
+	items = append(items, map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ServiceAccount",
+		"metadata":   map[string]interface{}{"name": partnerServiceAccountName, "namespace": deployment.Namespace},
+	})
+	orchestratorContainer := map[string]interface{}{
+		"name":  "orchestrator",
+		"image": deployment.OrchestratorImage,
+	}
+	fsDiffContainer := map[string]interface{}{
+		"name":            "fs-diff-master",
+		"image":           deployment.FsDiffImage,
+		"securityContext": map[string]interface{}{"privileged": true},
+		"volumeMounts": []interface{}{
+			map[string]interface{}{"name": "container-storage", "mountPath": hostContainerStoragePath},
+		},
+	}
+	fsDiffVolumes := []interface{}{
+		map[string]interface{}{
+			"name":     "container-storage",
+			"hostPath": map[string]interface{}{"path": hostContainerStoragePath},
+		},
+	}
+	items = append(items,
+		buildPartnerDeployment(deployment, orchestratorDeploymentName, orchestratorValue, orchestratorContainer, nil),
+		buildPartnerDeployment(deployment, fsDiffMasterDeploymentName, fsDiffMasterValue, fsDiffContainer, fsDiffVolumes))
+	return json.Marshal(map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "List",
+		"items":      items,
+	})
+}
+
+// buildPartnerDeployment renders a single replica Deployment whose pods carry the generic partner label `role`.
+func buildPartnerDeployment(deployment *configsections.PartnerDeployment, name, role string, container map[string]interface{},
+	volumes []interface{}) map[string]interface{} {
+	labels := map[string]interface{}{
+		buildLabelName(tnfNamespace, genericLabelName):    role,
+		buildLabelName(tnfNamespace, deployedByLabelName): deployedByLabelValue,
+	}
+	// Partner pods have a single interface, which is named explicitly as not every cluster reports it.
+	defaultInterface, _ := json.Marshal(defaultPartnerNetworkInterface)
+	podSpec := map[string]interface{}{
+		"serviceAccountName": partnerServiceAccountName,
+		"containers":         []interface{}{container},
+	}
+	if len(deployment.NodeSelector) > 0 {
+		podSpec["nodeSelector"] = deployment.NodeSelector
+	}
+	if len(volumes) > 0 {
+		podSpec["volumes"] = volumes
+	}
+	return map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": deployment.Namespace,
+			"labels":    labels,
+		},
+		"spec": map[string]interface{}{
+			"replicas": 1,
+			"selector": map[string]interface{}{"matchLabels": labels},
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"labels":      labels,
+					"annotations": map[string]interface{}{namespacedDefaultNetworkInterfaceKey: string(defaultInterface)},
+				},
+				"spec": podSpec,
+			},
+		},
+	}
+}
+
+// namespaceExists returns true if the namespace can be found in the cluster.
+func namespaceExists(namespace string) bool {
+	return runOcWithInput(nil, "get", "namespace", namespace) == nil
+}
+
+// runOcWithInput runs an oc command with `input` as its standard input, returning its output as part of any error.
+func runOcWithInput(input []byte, args ...string) error {
+	cmd := exec.Command("oc", args...)
+	log.Debug("Issuing command ", cmd.Args)
+	if input != nil {
+		cmd.Stdin = bytes.NewReader(input)
+	}
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s: %s (%s)", cmd.Args, err, bytes.TrimSpace(out))
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package autodiscover
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+// partnerManifest mirrors the parts of the rendered partner manifest that are checked.
+type partnerManifest struct {
+	Kind  string `json:"kind"`
+	Items []struct {
+		Kind     string `json:"kind"`
+		Metadata struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+		Spec struct {
+			Template struct {
+				Metadata struct {
+					Labels      map[string]string `json:"labels"`
+					Annotations map[string]string `json:"annotations"`
+				} `json:"metadata"`
+				Spec struct {
+					ServiceAccountName string            `json:"serviceAccountName"`
+					NodeSelector       map[string]string `json:"nodeSelector"`
+					Containers         []struct {
+						Image string `json:"image"`
+					} `json:"containers"`
+				} `json:"spec"`
+			} `json:"template"`
+		} `json:"spec"`
+	} `json:"items"`
+}
+
+func TestWithPartnerDeploymentDefaults(t *testing.T) {
+	deployment := withPartnerDeploymentDefaults(configsections.PartnerDeployment{})
+	assert.Equal(t, defaultPartnerNamespace, deployment.Namespace)
+	assert.Equal(t, defaultPartnerImage, deployment.OrchestratorImage)
+	assert.Equal(t, defaultPartnerImage, deployment.FsDiffImage)
+	assert.Equal(t, defaultPartnerReadinessTimeoutSeconds, deployment.ReadinessTimeoutSeconds)
+
+	deployment = withPartnerDeploymentDefaults(configsections.PartnerDeployment{Namespace: "lab", FsDiffImage: "fsdiff:1"})
+	assert.Equal(t, "lab", deployment.Namespace)
+	assert.Equal(t, "fsdiff:1", deployment.FsDiffImage)
+}
+
+func TestBuildPartnerManifest(t *testing.T) {
+	deployment := withPartnerDeploymentDefaults(configsections.PartnerDeployment{
+		Namespace:         "lab",
+		OrchestratorImage: "partner:1",
+		NodeSelector:      map[string]string{"kubernetes.io/hostname": "worker-0"},
+	})
+
+	contents, err := buildPartnerManifest(&deployment, true)
+	assert.Nil(t, err)
+	var manifest partnerManifest
+	assert.Nil(t, json.Unmarshal(contents, &manifest))
+	assert.Equal(t, "List", manifest.Kind)
+	assert.Equal(t, 4, len(manifest.Items))
+	assert.Equal(t, "Namespace", manifest.Items[0].Kind)
+	assert.Equal(t, "lab", manifest.Items[0].Metadata.Name)
+	assert.Equal(t, "ServiceAccount", manifest.Items[1].Kind)
+	assert.Equal(t, partnerServiceAccountName, manifest.Items[1].Metadata.Name)
+	assert.Equal(t, "lab", manifest.Items[1].Metadata.Namespace)
+
+	orchestrator := manifest.Items[2]
+	assert.Equal(t, "Deployment", orchestrator.Kind)
+	assert.Equal(t, "lab", orchestrator.Metadata.Namespace)
+	assert.Equal(t, orchestratorValue, orchestrator.Spec.Template.Metadata.Labels["test-network-function.com/generic"])
+	assert.Equal(t, `"eth0"`, orchestrator.Spec.Template.Metadata.Annotations[namespacedDefaultNetworkInterfaceKey])
+	assert.Equal(t, "worker-0", orchestrator.Spec.Template.Spec.NodeSelector["kubernetes.io/hostname"])
+	assert.Equal(t, "partner:1", orchestrator.Spec.Template.Spec.Containers[0].Image)
+
+	fsDiffMaster := manifest.Items[3]
+	assert.Equal(t, fsDiffMasterValue, fsDiffMaster.Spec.Template.Metadata.Labels["test-network-function.com/generic"])
+	assert.Equal(t, defaultPartnerImage, fsDiffMaster.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, partnerServiceAccountName, fsDiffMaster.Spec.Template.Spec.ServiceAccountName)
+
+	// An existing namespace is neither created nor deleted.
+	contents, err = buildPartnerManifest(&deployment, false)
+	assert.Nil(t, err)
+	assert.Nil(t, json.Unmarshal(contents, &manifest))
+	assert.Equal(t, 3, len(manifest.Items))
+	assert.Equal(t, "ServiceAccount", manifest.Items[0].Kind)
+}
@@ -19,6 +19,8 @@ package autodiscover
 import (
 	"encoding/json"
 	"fmt"
+	"net"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/test-network-function/test-network-function/pkg/config/configsections"
@@ -27,7 +29,9 @@ import (
 const (
 	cnfDefaultNetworkInterfaceKey = "defaultnetworkinterface"
 	cnfIPsKey                     = "multusips"
+	cniNetworksKey                = "k8s.v1.cni.cncf.io/networks"
 	cniNetworksStatusKey          = "k8s.v1.cni.cncf.io/networks-status"
+	cniNetworkStatusKey           = "k8s.v1.cni.cncf.io/network-status"
 	resourceTypePods              = "pods"
 )
 
@@ -50,12 +54,27 @@ type PodResource struct {
 		Annotations map[string]string `json:"annotations"`
 	} `json:"metadata"`
 	Spec struct {
+		NodeName   string `json:"nodeName"`
 		Containers []struct {
-			Name string `json:"name"`
+			Name  string `json:"name"`
+			Image string `json:"image"`
+			Ports []struct {
+				Name          string `json:"name"`
+				ContainerPort int    `json:"containerPort"`
+				Protocol      string `json:"protocol"`
+			} `json:"ports"`
+			Resources struct {
+				Requests map[string]string `json:"requests"`
+				Limits   map[string]string `json:"limits"`
+			} `json:"resources"`
 		} `json:"containers"`
 	} `json:"spec"`
 	Status struct {
-		PodIPs []map[string]string `json:"podIPs"`
+		PodIPs            []map[string]string `json:"podIPs"`
+		ContainerStatuses []struct {
+			Name    string `json:"name"`
+			ImageID string `json:"imageID"`
+		} `json:"containerStatuses"`
 	} `json:"status"`
 }
 
@@ -65,6 +84,19 @@ type cniNetworkInterface struct {
 	IPs       []string               `json:"ips"`
 	Default   bool                   `json:"default"`
 	DNS       map[string]interface{} `json:"dns"`
+	// DeviceInfo is set by the device plugin of the network, such as the SR-IOV one.
+	DeviceInfo *struct {
+		PCI *struct {
+			PCIAddress string `json:"pci-address"`
+		} `json:"pci"`
+	} `json:"device-info"`
+}
+
+// cniNetworkSelection is an entry of the `k8s.v1.cni.cncf.io/networks` annotation when given in its JSON form.
+type cniNetworkSelection struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Interface string `json:"interface"`
 }
 
 func (pr *PodResource) hasAnnotation(annotationKey string) (present bool) {
@@ -142,6 +174,111 @@ func (pr *PodResource) getPodIPs() (ips []string, err error) {
 	return
 }
 
+// splitIPsByFamily separates `ips` into IPv4 and IPv6 addresses, preserving their order.  Anything that is not an IP
+// address is dropped with a warning.
+func splitIPsByFamily(ips []string) (ipv4, ipv6 []string) {
+	for _, ip := range ips {
+		parsed := net.ParseIP(ip)
+		switch {
+		case parsed == nil:
+			log.Warnf("Ignoring %q, which is not an IP address", ip)
+		case parsed.To4() != nil:
+			ipv4 = append(ipv4, ip)
+		default:
+			ipv6 = append(ipv6, ip)
+		}
+	}
+	return
+}
+
+// getNetworks returns the secondary networks requested through the "k8s.v1.cni.cncf.io/networks" annotation, which
+// is either a JSON list of network selections or a comma separated list of `[namespace/]name[@interface]`.  Networks
+// without a namespace are in that of the pod.
+func (pr *PodResource) getNetworks() (networks []configsections.NetworkAttachment, err error) {
+	val := strings.TrimSpace(pr.Metadata.Annotations[cniNetworksKey])
+	if val == "" {
+		return nil, nil
+	}
+	if strings.HasPrefix(val, "[") {
+		var selections []cniNetworkSelection
+		err = json.Unmarshal([]byte(val), &selections)
+		if err != nil {
+			return nil, pr.annotationUnmarshalError(cniNetworksKey, err)
+		}
+		for _, selection := range selections {
+			networks = append(networks, configsections.NetworkAttachment{Name: selection.Name, Namespace: selection.Namespace, Interface: selection.Interface})
+		}
+	} else {
+		for _, item := range strings.Split(val, ",") {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+			var network configsections.NetworkAttachment
+			if at := strings.Index(item, "@"); at >= 0 {
+				item, network.Interface = item[:at], item[at+1:]
+			}
+			if slash := strings.Index(item, "/"); slash >= 0 {
+				network.Namespace, item = item[:slash], item[slash+1:]
+			}
+			network.Name = item
+			networks = append(networks, network)
+		}
+	}
+	for i := range networks {
+		if networks[i].Name == "" {
+			return nil, fmt.Errorf("a network without a name is requested in annotation '%s' on pod '%s/%s'", cniNetworksKey, pr.Metadata.Namespace, pr.Metadata.Name)
+		}
+		if networks[i].Namespace == "" {
+			networks[i].Namespace = pr.Metadata.Namespace
+		}
+	}
+	return networks, nil
+}
+
+// getNetworksStatus returns the status of the networks of the pod, as reported by Multus in the
+// "k8s.v1.cni.cncf.io/networks-status" annotation, or in the "k8s.v1.cni.cncf.io/network-status" one which replaces
+// it in later versions.
+func (pr *PodResource) getNetworksStatus() (status []configsections.NetworkStatus, err error) {
+	key := cniNetworksStatusKey
+	if !pr.hasAnnotation(key) {
+		key = cniNetworkStatusKey
+	}
+	val, present := pr.Metadata.Annotations[key]
+	if !present {
+		return nil, nil
+	}
+	var cniInfo []cniNetworkInterface
+	err = json.Unmarshal([]byte(val), &cniInfo)
+	if err != nil {
+		return nil, pr.annotationUnmarshalError(key, err)
+	}
+	for _, cniInterface := range cniInfo {
+		network := configsections.NetworkStatus{
+			Name:      cniInterface.Name,
+			Interface: cniInterface.Interface,
+			IPs:       cniInterface.IPs,
+			Default:   cniInterface.Default,
+		}
+		if cniInterface.DeviceInfo != nil && cniInterface.DeviceInfo.PCI != nil {
+			network.PCIAddress = cniInterface.DeviceInfo.PCI.PCIAddress
+		}
+		status = append(status, network)
+	}
+	return status, nil
+}
+
+// getContainerImageID returns the image ID reported in the pod status for the named container, or an empty string if
+// the container has no status yet.
+func (pr *PodResource) getContainerImageID(containerName string) string {
+	for _, status := range pr.Status.ContainerStatuses {
+		if status.Name == containerName {
+			return status.ImageID
+		}
+	}
+	return ""
+}
+
 func (pr *PodResource) annotationUnmarshalError(annotationKey string, err error) error {
 	return fmt.Errorf("error (%s) attempting to unmarshal value of annotation '%s' on pod '%s/%s'",
 		err, annotationKey, pr.Metadata.Namespace, pr.Metadata.Name)
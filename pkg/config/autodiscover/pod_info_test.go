@@ -24,6 +24,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
 )
 
 const (
@@ -60,3 +61,44 @@ func TestPodGetAnnotationValue(t *testing.T) {
 	assert.Equal(t, "eth0", val)
 	assert.Nil(t, err)
 }
+
+func TestPodGetNetworks(t *testing.T) {
+	pod := loadPodResource(testOrchestratorFilePath)
+	pod.Metadata.Namespace = "tnf"
+	pod.Metadata.Annotations = map[string]string{
+		cniNetworksKey: `[{"name": "sriov-net", "interface": "net1"}, {"name": "bridge-net", "namespace": "other-ns"}]`,
+	}
+	networks, err := pod.getNetworks()
+	assert.Nil(t, err)
+	assert.Equal(t, []configsections.NetworkAttachment{
+		{Name: "sriov-net", Namespace: "tnf", Interface: "net1"},
+		{Name: "bridge-net", Namespace: "other-ns"},
+	}, networks)
+
+	pod.Metadata.Annotations[cniNetworksKey] = "[{"
+	_, err = pod.getNetworks()
+	assert.NotNil(t, err)
+
+	pod.Metadata.Annotations[cniNetworksKey] = "other-ns/@net2"
+	_, err = pod.getNetworks()
+	assert.NotNil(t, err)
+
+	delete(pod.Metadata.Annotations, cniNetworksKey)
+	networks, err = pod.getNetworks()
+	assert.Nil(t, err)
+	assert.Empty(t, networks)
+}
+
+func TestPodGetNetworksStatus(t *testing.T) {
+	pod := loadPodResource(testOrchestratorFilePath)
+	pod.Metadata.Annotations = map[string]string{
+		cniNetworkStatusKey: `[{"name": "tnf/sriov-net", "interface": "net1", "device-info": {"type": "pci", "pci": {"pci-address": "0000:3b:02.2"}}}]`,
+	}
+	status, err := pod.getNetworksStatus()
+	assert.Nil(t, err)
+	assert.Equal(t, []configsections.NetworkStatus{{Name: "tnf/sriov-net", Interface: "net1", PCIAddress: "0000:3b:02.2"}}, status)
+
+	pod.Metadata.Annotations[cniNetworkStatusKey] = "{"
+	_, err = pod.getNetworksStatus()
+	assert.NotNil(t, err)
+}
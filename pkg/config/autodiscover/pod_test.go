@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
 )
 
 func TestBuildPodUnderTest(t *testing.T) {
@@ -38,4 +39,13 @@ func TestBuildPodUnderTest(t *testing.T) {
 	assert.Equal(t, "tnf", subjectPod.Namespace)
 	assert.Equal(t, "test", subjectPod.Name)
 	assert.Equal(t, []string{"OneTestName", "AnotherTestName"}, subjectPod.Tests)
+	assert.Equal(t, []configsections.NetworkAttachment{
+		{Name: "sriov-net", Namespace: "tnf", Interface: "net1"},
+		{Name: "macvlan-net", Namespace: "other-ns"},
+	}, subjectPod.Networks)
+	assert.Equal(t, []configsections.NetworkStatus{
+		{Interface: "eth1", IPs: []string{"10.217.1.89"}, Default: true},
+		{Name: "tnf/sriov-net", Interface: "net1", IPs: []string{"192.168.10.5"}, PCIAddress: "0000:3b:02.1"},
+	}, subjectPod.NetworksStatus)
+	assert.Empty(t, orchestratorPod.Networks)
 }
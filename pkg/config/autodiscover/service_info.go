@@ -0,0 +1,175 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package autodiscover
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+const (
+	resourceTypeServices  = "services"
+	resourceTypeEndpoints = "endpoints"
+)
+
+// ServiceList holds the data from an `oc get services -o json` command
+type ServiceList struct {
+	Items []ServiceResource `json:"items"`
+}
+
+// ServiceResource is a single Service from an `oc get services -o json` command
+type ServiceResource struct {
+	Metadata struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	} `json:"metadata"`
+	Spec struct {
+		ClusterIP  string            `json:"clusterIP"`
+		ClusterIPs []string          `json:"clusterIPs"`
+		Selector   map[string]string `json:"selector"`
+		Ports      []struct {
+			Name     string `json:"name"`
+			Port     int    `json:"port"`
+			Protocol string `json:"protocol"`
+			// TargetPort is either a port number or a port name.
+			TargetPort json.RawMessage `json:"targetPort"`
+		} `json:"ports"`
+	} `json:"spec"`
+}
+
+// EndpointsList holds the data from an `oc get endpoints -o json` command
+type EndpointsList struct {
+	Items []EndpointsResource `json:"items"`
+}
+
+// EndpointsResource is a single Endpoints from an `oc get endpoints -o json` command
+type EndpointsResource struct {
+	Metadata struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	} `json:"metadata"`
+	Subsets []struct {
+		Addresses []struct {
+			IP string `json:"ip"`
+		} `json:"addresses"`
+	} `json:"subsets"`
+}
+
+// GetServices returns the services of all namespaces.
+func GetServices() (*ServiceList, error) {
+	cmd := makeGetCommand(resourceTypeServices, "")
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var serviceList ServiceList
+	err = json.Unmarshal(out, &serviceList)
+	if err != nil {
+		return nil, err
+	}
+
+	return &serviceList, nil
+}
+
+// GetEndpoints returns the endpoints of all namespaces.
+func GetEndpoints() (*EndpointsList, error) {
+	cmd := makeGetCommand(resourceTypeEndpoints, "")
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var endpointsList EndpointsList
+	err = json.Unmarshal(out, &endpointsList)
+	if err != nil {
+		return nil, err
+	}
+
+	return &endpointsList, nil
+}
+
+// selectsPod returns true if the service selector matches the labels of the pod.  A service without a selector has
+// manually managed endpoints, and selects no pod.
+func (sr *ServiceResource) selectsPod(pr *PodResource) bool {
+	if sr.Metadata.Namespace != pr.Metadata.Namespace || len(sr.Spec.Selector) == 0 {
+		return false
+	}
+	for name, value := range sr.Spec.Selector {
+		if podValue, ok := pr.Metadata.Labels[name]; !ok || podValue != value {
+			return false
+		}
+	}
+	return true
+}
+
+// buildService builds a `configsections.Service` from a ServiceResource.
+func buildService(sr *ServiceResource) configsections.Service {
+	service := configsections.Service{
+		Name:       sr.Metadata.Name,
+		Namespace:  sr.Metadata.Namespace,
+		ClusterIP:  sr.Spec.ClusterIP,
+		ClusterIPs: sr.Spec.ClusterIPs,
+	}
+	for _, port := range sr.Spec.Ports {
+		protocol := port.Protocol
+		if protocol == "" {
+			protocol = configsections.ProtocolTCP
+		}
+		service.Ports = append(service.Ports, configsections.ServicePort{
+			Name:       port.Name,
+			Port:       port.Port,
+			Protocol:   protocol,
+			TargetPort: strings.Trim(string(port.TargetPort), `"`),
+		})
+	}
+	return service
+}
+
+// findServicesForPods returns the services that select at least one of `pods`.
+func findServicesForPods(services *ServiceList, pods []*PodResource) (selected []configsections.Service) {
+	for i := range services.Items {
+		for _, pod := range pods {
+			if services.Items[i].selectsPod(pod) {
+				selected = append(selected, buildService(&services.Items[i]))
+				break
+			}
+		}
+	}
+	return
+}
+
+// fillServiceEndpoints sets the ready endpoint IPs of each service, from the endpoints of the same name.
+func fillServiceEndpoints(services []configsections.Service, endpoints *EndpointsList) {
+	for i := range services {
+		for j := range endpoints.Items {
+			item := &endpoints.Items[j]
+			if item.Metadata.Namespace != services[i].Namespace || item.Metadata.Name != services[i].Name {
+				continue
+			}
+			for _, subset := range item.Subsets {
+				for _, address := range subset.Addresses {
+					services[i].Endpoints = append(services[i].Endpoints, address.IP)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package autodiscover
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+const (
+	testServicesFile  = "services.json"
+	testEndpointsFile = "endpoints.json"
+)
+
+var (
+	testServicesFilePath  = path.Join(filePath, testServicesFile)
+	testEndpointsFilePath = path.Join(filePath, testEndpointsFile)
+)
+
+func loadServiceList(t *testing.T) *ServiceList {
+	contents, err := ioutil.ReadFile(testServicesFilePath)
+	assert.Nil(t, err)
+	services := &ServiceList{}
+	err = json.Unmarshal(contents, services)
+	assert.Nil(t, err)
+	return services
+}
+
+func TestFindServicesForPods(t *testing.T) {
+	services := loadServiceList(t)
+	subjectPod := loadPodResource(testSubjectFilePath)
+
+	selected := findServicesForPods(services, []*PodResource{&subjectPod})
+	assert.Equal(t, []configsections.Service{
+		{
+			Name:       "test-http",
+			Namespace:  "tnf",
+			ClusterIP:  "172.30.12.34",
+			ClusterIPs: []string{"172.30.12.34", "fd02::1234"},
+			Ports: []configsections.ServicePort{
+				{Name: "web", Port: 80, Protocol: configsections.ProtocolTCP, TargetPort: "http"},
+				{Port: 53, Protocol: configsections.ProtocolUDP, TargetPort: "5353"},
+			},
+		},
+	}, selected)
+
+	assert.Nil(t, findServicesForPods(services, nil))
+}
+
+func TestFillServiceEndpoints(t *testing.T) {
+	contents, err := ioutil.ReadFile(testEndpointsFilePath)
+	assert.Nil(t, err)
+	endpoints := &EndpointsList{}
+	assert.Nil(t, json.Unmarshal(contents, endpoints))
+
+	services := []configsections.Service{
+		{Name: "test-http", Namespace: "tnf"},
+		{Name: "test-http", Namespace: "default"},
+	}
+	fillServiceEndpoints(services, endpoints)
+	assert.Equal(t, []string{"10.217.1.89", "fd00:10::59"}, services[0].Endpoints)
+	assert.Nil(t, services[1].Endpoints)
+}
+
+func TestService_IsHeadless(t *testing.T) {
+	assert.True(t, (&configsections.Service{ClusterIP: configsections.HeadlessClusterIP}).IsHeadless())
+	assert.False(t, (&configsections.Service{ClusterIP: "172.30.12.34"}).IsHeadless())
+}
+
+func TestBuildContainerPorts(t *testing.T) {
+	subjectPod := loadPodResource(testSubjectFilePath)
+	containers := buildContainersFromPodResource(&subjectPod)
+	assert.Equal(t, []configsections.ContainerPort{
+		{Name: "http", Port: 8080, Protocol: configsections.ProtocolTCP},
+		{Port: 5353, Protocol: configsections.ProtocolUDP},
+	}, containers[0].Ports)
+
+	orchestratorPod := loadPodResource(testOrchestratorFilePath)
+	containers = buildContainersFromPodResource(&orchestratorPod)
+	assert.Nil(t, containers[0].Ports)
+}
@@ -0,0 +1,92 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package autodiscover
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+const (
+	snapshotFilePermissions = 0644
+	// snapshotTimeFormat is the directive used to format the snapshot creation time according to ISO 8601.
+	snapshotTimeFormat = "2006-01-02T15:04:05+00:00"
+
+	// ClaimRawResultsKey is the key of the Snapshot in the raw results of a claim file.
+	ClaimRawResultsKey = "discoverySnapshot"
+)
+
+// Snapshot is a serializable record of the topology found by autodiscovery. It is recorded in the claim file at the end
+// of a run, and can be supplied to a later run in place of autodiscovery so that the same resources are tested again,
+// or compared between certification attempts.
+type Snapshot struct {
+	// CreationTime is the time the snapshot was taken.
+	CreationTime string `yaml:"creationTime" json:"creationTime"`
+	// TestTarget contains the discovered pods, containers and operators under test.
+	TestTarget configsections.TestTarget `yaml:"testTarget" json:"testTarget"`
+	// TestPartner contains the discovered partner containers.
+	TestPartner configsections.TestPartner `yaml:"testPartner" json:"testPartner"`
+}
+
+// NewSnapshot creates a Snapshot of the given test target and partner, stamped with the current time.
+func NewSnapshot(target *configsections.TestTarget, partner *configsections.TestPartner) *Snapshot {
+	return &Snapshot{
+		CreationTime: time.Now().UTC().Format(snapshotTimeFormat),
+		TestTarget:   *target,
+		TestPartner:  *partner,
+	}
+}
+
+// Save writes the snapshot to `filePath` as JSON.
+func (s *Snapshot) Save(filePath string) error {
+	contents, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filePath, contents, snapshotFilePermissions)
+}
+
+// claimSnapshot is the part of a claim file which holds a Snapshot.
+type claimSnapshot struct {
+	Claim struct {
+		RawResults struct {
+			DiscoverySnapshot *Snapshot `json:"discoverySnapshot"`
+		} `json:"rawResults"`
+	} `json:"claim"`
+}
+
+// LoadSnapshot reads a Snapshot from `filePath`, which is either a claim file or a file written by `Save`.
+func LoadSnapshot(filePath string) (*Snapshot, error) {
+	contents, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	claim := claimSnapshot{}
+	if err = json.Unmarshal(contents, &claim); err == nil && claim.Claim.RawResults.DiscoverySnapshot != nil {
+		return claim.Claim.RawResults.DiscoverySnapshot, nil
+	}
+	snapshot := &Snapshot{}
+	err = json.Unmarshal(contents, snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("error (%s) attempting to unmarshal discovery snapshot %s", err, filePath)
+	}
+	return snapshot, nil
+}
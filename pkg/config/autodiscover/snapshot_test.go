@@ -0,0 +1,86 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package autodiscover
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+func TestSnapshotSaveAndLoad(t *testing.T) {
+	subjectPod := loadPodResource(testSubjectFilePath)
+	orchestratorPod := loadPodResource(testOrchestratorFilePath)
+	target := configsections.TestTarget{
+		PodsUnderTest:       []configsections.Pod{buildPodUnderTest(&subjectPod)},
+		ContainersUnderTest: buildContainersFromPodResource(&subjectPod),
+	}
+	orchestrator := buildContainersFromPodResource(&orchestratorPod)[0]
+	partner := configsections.TestPartner{
+		PartnerContainers: []configsections.Container{orchestrator},
+		TestOrchestrator:  orchestrator.ContainerIdentifier,
+	}
+
+	dir, err := ioutil.TempDir("", "snapshot")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	snapshotPath := path.Join(dir, "discovery-snapshot.json")
+
+	snapshot := NewSnapshot(&target, &partner)
+	assert.NotEmpty(t, snapshot.CreationTime)
+	assert.Nil(t, snapshot.Save(snapshotPath))
+
+	loaded, err := LoadSnapshot(snapshotPath)
+	assert.Nil(t, err)
+	assert.Equal(t, snapshot, loaded)
+	assert.Equal(t, "worker-0", loaded.TestTarget.ContainersUnderTest[0].NodeName)
+	assert.Equal(t, orchestrator.ContainerIdentifier, loaded.TestPartner.TestOrchestrator)
+}
+
+func TestLoadSnapshotFromClaim(t *testing.T) {
+	partner := configsections.TestPartner{
+		TestOrchestrator: configsections.ContainerIdentifier{Namespace: "tnf", PodName: "partner", ContainerName: "partner"},
+	}
+	snapshot := NewSnapshot(&configsections.TestTarget{}, &partner)
+	claim := map[string]interface{}{
+		"claim": map[string]interface{}{
+			"rawResults": map[string]interface{}{ClaimRawResultsKey: snapshot},
+		},
+	}
+	contents, err := json.Marshal(claim)
+	assert.Nil(t, err)
+
+	dir, err := ioutil.TempDir("", "snapshot")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	claimPath := path.Join(dir, "claim.json")
+	assert.Nil(t, ioutil.WriteFile(claimPath, contents, snapshotFilePermissions))
+
+	loaded, err := LoadSnapshot(claimPath)
+	assert.Nil(t, err)
+	assert.Equal(t, snapshot, loaded)
+}
+
+func TestLoadSnapshotErrors(t *testing.T) {
+	_, err := LoadSnapshot(path.Join(filePath, "doesNotExist.json"))
+	assert.NotNil(t, err)
+}
@@ -20,6 +20,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"sync"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/test-network-function/test-network-function/pkg/config/autodiscover"
@@ -32,71 +34,304 @@ const (
 	defaultConfigurationFilePath                = "tnf_config.yml"
 )
 
-var (
-	// configInstance is the singleton instance of loaded config, accessed through GetConfigInstance
-	configInstance configsections.TestConfiguration
-	// loaded tracks if the config has been loaded to prevent it being reloaded.
-	loaded = false
-	// set when an intrusive test has done something that would cause Pod/Container to be recreated
-	needsRefresh = false
-)
+// Provider supplies a test configuration.  Implementations are safe for concurrent use, and several may be held at
+// once, so that a suite can be handed the configuration it should run against rather than reaching for shared state.
+type Provider interface {
+	// Load reads the configuration from its source.  Loading a Provider that is already loaded is an error.
+	Load() error
+	// Refresh rebuilds the parts of the configuration that may have changed since it was loaded, such as the pods and
+	// containers found by autodiscovery after an intrusive test has caused them to be recreated.
+	Refresh() error
+	// GetConfig returns the current configuration.  The zero configuration is returned until the Provider is loaded.
+	GetConfig() configsections.TestConfiguration
+	// Close releases anything the Provider created for the run, such as deployed partner workloads.
+	Close() error
+}
 
-// getConfigurationFilePathFromEnvironment returns the test configuration file.
-func getConfigurationFilePathFromEnvironment() string {
+// getConfigurationFilePathsFromEnvironment returns the test configuration files.  TNF_CONFIGURATION_PATH may list
+// several files, separated as in PATH, the first being the base file and the others overlays applied in order.
+func getConfigurationFilePathsFromEnvironment() []string {
 	environmentSourcedConfigurationFilePath := os.Getenv(configurationFilePathEnvironmentVariableKey)
 	if environmentSourcedConfigurationFilePath != "" {
-		return environmentSourcedConfigurationFilePath
+		return filepath.SplitList(environmentSourcedConfigurationFilePath)
 	}
-	return defaultConfigurationFilePath
+	return []string{defaultConfigurationFilePath}
+}
+
+// FileProvider is a Provider that reads the configuration from a yaml file, as is.
+type FileProvider struct {
+	filePath string
+	lock     sync.RWMutex
+	loaded   bool
+	config   configsections.TestConfiguration
+}
+
+// NewFileProvider creates a FileProvider for the configuration file at `filePath`.
+func NewFileProvider(filePath string) *FileProvider {
+	return &FileProvider{filePath: filePath}
+}
+
+// Load reads the configuration file.
+func (p *FileProvider) Load() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.loadFromFile()
+}
+
+// Refresh does nothing, as the contents of the file are not expected to change during a run.
+func (p *FileProvider) Refresh() error {
+	return nil
 }
 
-// loadConfigFromFile loads a config file once.
-func loadConfigFromFile(filePath string) error {
-	if loaded {
+// Close does nothing, as a FileProvider creates nothing.
+func (p *FileProvider) Close() error {
+	return nil
+}
+
+// GetConfig returns the configuration read from the file.
+func (p *FileProvider) GetConfig() configsections.TestConfiguration {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.config
+}
+
+// loadFromFile loads the config file once.  The caller must hold the lock.
+func (p *FileProvider) loadFromFile() error {
+	if p.loaded {
 		return fmt.Errorf("cannot load config from file when a config is already loaded")
 	}
-	log.Info("Loading config from file: ", filePath)
+	log.Info("Loading config from file: ", p.filePath)
 
-	contents, err := ioutil.ReadFile(filePath)
+	contents, err := ioutil.ReadFile(p.filePath)
 	if err != nil {
 		return err
 	}
 
-	err = yaml.Unmarshal(contents, &configInstance)
+	conf := configsections.TestConfiguration{}
+	err = yaml.Unmarshal(contents, &conf)
 	if err != nil {
 		return err
 	}
-	loaded = true
+	p.config = conf
+	p.loaded = true
 	return nil
 }
 
-// GetConfigInstance provides access to the singleton ConfigFile instance.
-func GetConfigInstance() configsections.TestConfiguration {
-	if !loaded {
-		filePath := getConfigurationFilePathFromEnvironment()
-		log.Debugf("GetConfigInstance before config loaded, loading from file: %s", filePath)
-		err := loadConfigFromFile(filePath)
+// EnvProvider is the Provider used for a normal test run.  It merges the configuration files, `TNF_*` environment
+// variables and command line overrides, then either completes the result through autodiscovery or, when a discovery
+// snapshot is set, replaces the test target and partner with those of the snapshot.  When the deployPartner setting is
+// set and no test orchestrator is found, the partner workloads are deployed before autodiscovery, and removed on Close.
+type EnvProvider struct {
+	files           []string
+	overrides       []string
+	layersOnce      sync.Once
+	layers          configsections.TestConfiguration
+	layersErr       error
+	lock            sync.RWMutex
+	loaded          bool
+	config          configsections.TestConfiguration
+	deployedPartner *autodiscover.DeployedPartner
+}
+
+// NewEnvProvider creates an EnvProvider for the configuration files named by the environment.
+func NewEnvProvider() *EnvProvider {
+	return NewLayeredProvider(nil, nil)
+}
+
+// NewLayeredProvider creates an EnvProvider which reads `files` in place of the configuration files named by the
+// environment, and applies `overrides`, each given as `path=value`, over the environment.  The layers are merged once,
+// when the configuration is first needed.
+func NewLayeredProvider(files, overrides []string) *EnvProvider {
+	return &EnvProvider{files: files, overrides: overrides}
+}
+
+// Load merges the configuration layers, then performs autodiscovery or loads the discovery snapshot.
+func (p *EnvProvider) Load() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.loaded {
+		return fmt.Errorf("cannot load config when a config is already loaded")
+	}
+	conf, err := p.getLayers()
+	if err != nil {
+		return err
+	}
+	p.config = conf
+	if p.config.Settings.DiscoverySnapshot != "" {
+		err = p.loadSnapshot()
 		if err != nil {
-			log.Fatalf("unable to load configuration file: %s", err)
+			return err
 		}
-		autodiscover.FillTestPartner(&configInstance.TestPartner)
-		doAutodiscover()
-	} else if needsRefresh {
-		configInstance.TestPartner = configsections.TestPartner{}
-		doAutodiscover()
+	} else {
+		err = p.deployTestPartner()
+		if err != nil {
+			return err
+		}
+		autodiscover.FillTestPartner(&p.config.TestPartner)
+		p.doAutodiscover()
+	}
+	p.loaded = true
+	return nil
+}
+
+// Close removes the partner workloads, if they were deployed by Load.
+func (p *EnvProvider) Close() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.deployedPartner == nil {
+		return nil
 	}
-	return configInstance
+	err := p.deployedPartner.Teardown()
+	p.deployedPartner = nil
+	return err
+}
+
+// deployTestPartner deploys the partner workloads when so configured and no test orchestrator can be found, removing
+// them again if they cannot be deployed completely.  The caller must hold the lock.
+func (p *EnvProvider) deployTestPartner() error {
+	if !p.config.Settings.DeployPartner {
+		return nil
+	}
+	missing, err := autodiscover.IsTestOrchestratorMissing(&p.config.TestPartner)
+	if err != nil {
+		return err
+	}
+	if !missing {
+		log.Info("a test orchestrator was found, the test partner will not be deployed")
+		return nil
+	}
+	deployed, err := autodiscover.DeployTestPartner(p.config.Settings.PartnerDeployment)
+	if err != nil {
+		// Load fails, so Close will not be called: remove a partial deployment now.
+		if deployed != nil {
+			if teardownErr := deployed.Teardown(); teardownErr != nil {
+				log.Errorf("unable to remove the partially deployed test partner: %s", teardownErr)
+			}
+		}
+		return err
+	}
+	p.deployedPartner = deployed
+	return nil
+}
+
+// Refresh redoes autodiscovery.  A configuration loaded from a discovery snapshot is never refreshed.
+func (p *EnvProvider) Refresh() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if !p.loaded {
+		return fmt.Errorf("cannot refresh a config that has not been loaded")
+	}
+	if p.config.Settings.DiscoverySnapshot != "" {
+		log.Debug("the configuration was loaded from a discovery snapshot and is not refreshed")
+		return nil
+	}
+	if !p.config.Settings.DisableAutodiscover {
+		p.config.TestPartner = configsections.TestPartner{}
+		p.doAutodiscover()
+	}
+	return nil
+}
+
+// GetConfig returns the configuration.  Until the EnvProvider is loaded, this is the merged configuration layers
+// without autodiscovery, so that the settings are available while the test suites are being set up, or the zero
+// configuration when the layers cannot be merged, in which case Load fails.
+func (p *EnvProvider) GetConfig() configsections.TestConfiguration {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	if p.loaded {
+		return p.config
+	}
+	conf, _ := p.getLayers()
+	return conf
+}
+
+// getLayers merges the configuration layers the first time it is called, and returns the same result from then on.
+func (p *EnvProvider) getLayers() (configsections.TestConfiguration, error) {
+	p.layersOnce.Do(func() {
+		p.layers, p.layersErr = p.buildLayers()
+		if p.layersErr != nil {
+			log.Errorf("unable to merge the configuration: %s", p.layersErr)
+		}
+	})
+	return p.layers, p.layersErr
+}
+
+// buildLayers merges the configuration files, environment and overrides.
+func (p *EnvProvider) buildLayers() (configsections.TestConfiguration, error) {
+	layers, err := LoadLayers(p.files, p.overrides, os.LookupEnv)
+	if err != nil {
+		return configsections.TestConfiguration{}, err
+	}
+	return layers.Build()
+}
+
+// loadSnapshot replaces the test target and partner with the contents of the discovery snapshot.  The caller must
+// hold the lock.
+func (p *EnvProvider) loadSnapshot() error {
+	snapshotPath := p.config.Settings.DiscoverySnapshot
+	log.Info("Loading discovery snapshot from file: ", snapshotPath)
+	snapshot, err := autodiscover.LoadSnapshot(snapshotPath)
+	if err != nil {
+		return err
+	}
+	p.config.TestTarget = snapshot.TestTarget
+	p.config.TestPartner = snapshot.TestPartner
+	return nil
+}
+
+// doAutodiscover fills the test target and partner from the cluster.  The caller must hold the lock.
+func (p *EnvProvider) doAutodiscover() {
+	if !p.config.Settings.DisableAutodiscover {
+		p.config.TestTarget = autodiscover.FindTestTarget(p.config.TargetPodLabels)
+		autodiscover.FillTestPartner(&p.config.TestPartner)
+	}
+}
+
+// MemoryProvider is a Provider holding a configuration built in code, which is useful for tests.
+type MemoryProvider struct {
+	lock   sync.RWMutex
+	loaded bool
+	config configsections.TestConfiguration
+}
+
+// NewMemoryProvider creates a MemoryProvider that will supply `conf` once loaded.
+func NewMemoryProvider(conf *configsections.TestConfiguration) *MemoryProvider {
+	return &MemoryProvider{config: *conf}
+}
+
+// Load marks the configuration as loaded.
+func (p *MemoryProvider) Load() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.loaded {
+		return fmt.Errorf("cannot load config when a config is already loaded")
+	}
+	p.loaded = true
+	return nil
+}
+
+// Refresh does nothing, as there is nothing to rebuild the configuration from.
+func (p *MemoryProvider) Refresh() error {
+	return nil
+}
+
+// Close does nothing, as a MemoryProvider creates nothing.
+func (p *MemoryProvider) Close() error {
+	return nil
 }
 
-func doAutodiscover() {
-	if autodiscover.PerformAutoDiscovery() {
-		configInstance.TestTarget = autodiscover.FindTestTarget(configInstance.TargetPodLabels)
-		autodiscover.FillTestPartner(&configInstance.TestPartner)
+// GetConfig returns the configuration given on creation.
+func (p *MemoryProvider) GetConfig() configsections.TestConfiguration {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	if !p.loaded {
+		return configsections.TestConfiguration{}
 	}
-	needsRefresh = false
+	return p.config
 }
 
-// SetNeedsRefresh marks the config stale so that the next getInstance call will redo discovery
-func SetNeedsRefresh() {
-	needsRefresh = true
+// GetDiscoverySnapshot returns a snapshot of the test target and partner supplied by `provider`.
+func GetDiscoverySnapshot(provider Provider) *autodiscover.Snapshot {
+	conf := provider.GetConfig()
+	return autodiscover.NewSnapshot(&conf.TestTarget, &conf.TestPartner)
 }
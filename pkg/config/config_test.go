@@ -17,26 +17,101 @@
 package config
 
 import (
+	"io/ioutil"
+	"os"
+	"path"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/autodiscover"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
 )
 
 const (
 	filePath = "testdata/tnf_test_config.yml"
 )
 
-func TestLoadConfigFromFile(t *testing.T) {
-	assert.Nil(t, loadConfigFromFile(filePath))
-	assert.NotNil(t, loadConfigFromFile(filePath)) // Loading when already loaded is an error case
-	conf := GetConfigInstance()
+func TestFileProvider(t *testing.T) {
+	provider := NewFileProvider(filePath)
+	assert.Equal(t, configsections.TestConfiguration{}, provider.GetConfig())
+	assert.Nil(t, provider.Load())
+	assert.NotNil(t, provider.Load()) // Loading when already loaded is an error case
+	conf := provider.GetConfig()
 	assert.Equal(t, conf.TestOrchestrator.Namespace, "default")
 	assert.Equal(t, conf.TestOrchestrator.ContainerName, "partner")
 	assert.Equal(t, conf.TestOrchestrator.PodName, "partner")
+	assert.Nil(t, provider.Refresh())
+	assert.Equal(t, conf, provider.GetConfig())
+
+	assert.NotNil(t, NewFileProvider("testdata/doesNotExist.yml").Load())
+}
+
+func TestMemoryProvider(t *testing.T) {
+	conf := configsections.TestConfiguration{}
+	conf.TestOrchestrator = configsections.ContainerIdentifier{Namespace: "tnf", PodName: "partner", ContainerName: "partner"}
+	provider := NewMemoryProvider(&conf)
+	assert.Equal(t, configsections.TestConfiguration{}, provider.GetConfig())
+	assert.Nil(t, provider.Load())
+	assert.NotNil(t, provider.Load())
+	assert.Nil(t, provider.Refresh())
+	assert.Equal(t, conf, provider.GetConfig())
+
+	// Providers are independent of each other.
+	other := NewMemoryProvider(&configsections.TestConfiguration{})
+	assert.Nil(t, other.Load())
+	assert.NotEqual(t, provider.GetConfig(), other.GetConfig())
 }
 
-func TestGetConfigInstance(t *testing.T) {
-	_ = loadConfigFromFile(filePath)
-	assert.NotNil(t, GetConfigInstance())
-	assert.Equal(t, GetConfigInstance(), GetConfigInstance())
+func TestEnvProviderFromSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	target := configsections.TestTarget{
+		ContainersUnderTest: []configsections.Container{{
+			ContainerIdentifier: configsections.ContainerIdentifier{Namespace: "tnf", PodName: "test", ContainerName: "test"},
+			NodeName:            "worker-0",
+		}},
+	}
+	partner := configsections.TestPartner{
+		TestOrchestrator: configsections.ContainerIdentifier{Namespace: "tnf", PodName: "partner", ContainerName: "partner"},
+	}
+	snapshotPath := path.Join(dir, "discovery-snapshot.json")
+	assert.Nil(t, autodiscover.NewSnapshot(&target, &partner).Save(snapshotPath))
+
+	os.Setenv(configurationFilePathEnvironmentVariableKey, filePath)
+	os.Setenv("TNF_DISCOVERY_SNAPSHOT", snapshotPath)
+	defer os.Unsetenv(configurationFilePathEnvironmentVariableKey)
+	defer os.Unsetenv("TNF_DISCOVERY_SNAPSHOT")
+
+	var provider Provider = NewEnvProvider()
+	assert.NotNil(t, provider.Refresh()) // Refreshing before loading is an error case
+	assert.Nil(t, provider.Load())
+	conf := provider.GetConfig()
+	assert.Equal(t, target, conf.TestTarget)
+	assert.Equal(t, partner, conf.TestPartner)
+	// Settings outside of the target and partner still come from the file.
+	assert.Equal(t, "etcd-operator", conf.CertifiedOperatorInfo[0].Name)
+
+	// A snapshot is never refreshed.
+	assert.Nil(t, provider.Refresh())
+	assert.Equal(t, conf, provider.GetConfig())
+	assert.Equal(t, target, GetDiscoverySnapshot(provider).TestTarget)
+}
+
+func TestEnvProviderLayers(t *testing.T) {
+	provider := NewLayeredProvider([]string{filePath}, []string{"settings.nonIntrusiveOnly=true"})
+	conf := provider.GetConfig()
+	assert.True(t, conf.Settings.NonIntrusiveOnly)
+	assert.False(t, conf.Settings.MinikubeOnly)
+	assert.Equal(t, "etcd-operator", conf.CertifiedOperatorInfo[0].Name)
+	// The layers are merged once, so a later change of the environment is not seen.
+	os.Setenv("TNF_MINIKUBE_ONLY", "true")
+	defer os.Unsetenv("TNF_MINIKUBE_ONLY")
+	assert.Equal(t, conf, provider.GetConfig())
+
+	// Layers which cannot be merged give the zero configuration until Load reports them.
+	provider = NewLayeredProvider([]string{"testdata/doesNotExist.yml"}, nil)
+	assert.Equal(t, configsections.TestConfiguration{}, provider.GetConfig())
+	assert.NotNil(t, provider.Load())
 }
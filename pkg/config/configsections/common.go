@@ -38,6 +38,21 @@ type Operator struct {
 
 	// Subscription name is required field, Name of used subscription.
 	SubscriptionName string `yaml:"subscriptionName" json:"subscriptionName"`
+
+	// InstallModes are the install modes declared in the csv (Auto populated).
+	InstallModes []InstallMode `yaml:"installModes,omitempty" json:"installModes,omitempty"`
+
+	// OwnedCrds are the CRDs owned by the operator, with their live instances (Auto populated).
+	OwnedCrds []Crd `yaml:"ownedCrds,omitempty" json:"ownedCrds,omitempty"`
+
+	// RequiredCrds are the CRDs the operator requires from other operators (Auto populated).
+	RequiredCrds []Crd `yaml:"requiredCrds,omitempty" json:"requiredCrds,omitempty"`
+
+	// Deployments are the deployments declared in the csv (Auto populated).
+	Deployments []Deployment `yaml:"deployments,omitempty" json:"deployments,omitempty"`
+
+	// Permissions are the roles and cluster roles declared in the csv (Auto populated).
+	Permissions []Permission `yaml:"permissions,omitempty" json:"permissions,omitempty"`
 }
 
 // TestConfiguration provides test related configuration
@@ -52,6 +67,31 @@ type TestConfiguration struct {
 	CertifiedContainerInfo []CertifiedContainerRequestInfo `yaml:"certifiedcontainerinfo,omitempty" json:"certifiedcontainerinfo,omitempty"`
 	// CertifiedOperatorInfo is list of operator bundle names that are queried for certification status.
 	CertifiedOperatorInfo []CertifiedOperatorRequestInfo `yaml:"certifiedoperatorinfo,omitempty" json:"certifiedoperatorinfo,omitempty"`
+	// UDPProbes are the probes used to check that the declared UDP ports answer.
+	UDPProbes []UDPProbe `yaml:"udpProbes,omitempty" json:"udpProbes,omitempty"`
+	// AllowedListeningPorts are the ports that may listen in any pod under test without being declared, such as those of
+	// known sidecars.
+	AllowedListeningPorts []ContainerPort `yaml:"allowedListeningPorts,omitempty" json:"allowedListeningPorts,omitempty"`
+	// HostResourceRules are the IDs of the host resource rules which each pod under test is checked against.  When empty,
+	// all of them are checked.
+	HostResourceRules []string `yaml:"hostResourceRules,omitempty" json:"hostResourceRules,omitempty"`
+	// AllowedCapabilities are the capabilities that the containers under test may add back after dropping ALL, in addition
+	// to NET_BIND_SERVICE which is always allowed.
+	AllowedCapabilities []string `yaml:"allowedCapabilities,omitempty" json:"allowedCapabilities,omitempty"`
+	// SCCLadder names the SecurityContextConstraints which the SCC of each pod under test is classified against, from
+	// the least to the most permissive.  When empty, the SCCs shipped with OpenShift are used.
+	SCCLadder []string `yaml:"sccLadder,omitempty" json:"sccLadder,omitempty"`
+	// ImagePolicy states how the images of the containers under test must be referenced.
+	ImagePolicy ImagePolicy `yaml:"imagePolicy,omitempty" json:"imagePolicy,omitempty"`
+	// VulnerabilityFeed locates the security advisories which the packages of the containers under test are matched
+	// against.
+	VulnerabilityFeed VulnerabilityFeed `yaml:"vulnerabilityFeed,omitempty" json:"vulnerabilityFeed,omitempty"`
+	// LatencyThresholds are the limits on the round trip time and packet loss of the connectivity tests.
+	LatencyThresholds LatencyThresholds `yaml:"latencyThresholds,omitempty" json:"latencyThresholds,omitempty"`
+	// ThroughputTest configures the throughput measurements, and the minimum throughput of each path.
+	ThroughputTest ThroughputTest `yaml:"throughputTest,omitempty" json:"throughputTest,omitempty"`
+	// Settings contains the switches that change how the test suites run.
+	Settings Settings `yaml:"settings,omitempty" json:"settings,omitempty"`
 }
 
 // TestPartner contains the helper containers that can be used to facilitate tests
@@ -74,4 +114,10 @@ type TestTarget struct {
 	ExcludeContainersFromConnectivityTests []ContainerIdentifier `yaml:"excludeContainersFromConnectivityTests" json:"excludeContainersFromConnectivityTests"`
 	// Operator is the list of operator objects that needs to be tested.
 	Operators []Operator `yaml:"operators,omitempty"  json:"operators,omitempty"`
+	// Services is the list of services that select pods under test.
+	Services []Service `yaml:"services,omitempty" json:"services,omitempty"`
+	// NetworkPolicies is the list of network policies of the namespaces of the pods under test.
+	NetworkPolicies []NetworkPolicy `yaml:"networkPolicies,omitempty" json:"networkPolicies,omitempty"`
+	// NetworkAttachmentDefinitions is the list of the network attachment definitions requested by the pods under test.
+	NetworkAttachmentDefinitions []NetworkAttachmentDefinition `yaml:"networkAttachmentDefinitions,omitempty" json:"networkAttachmentDefinitions,omitempty"`
 }
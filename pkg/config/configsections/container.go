@@ -28,6 +28,20 @@ type Container struct {
 	ContainerIdentifier `yaml:",inline"`
 	// OpenShift Default network interface name (i.e., eth0)
 	DefaultNetworkDevice string `yaml:"defaultNetworkDevice" json:"defaultNetworkDevice"`
-	// MultusIPAddresses are the overlay IPs.
+	// MultusIPAddresses are the IPv4 overlay IPs.
 	MultusIPAddresses []string `yaml:"multusIpAddresses" json:"multusIpAddresses"`
+	// MultusIPv6Addresses are the IPv6 overlay IPs.
+	MultusIPv6Addresses []string `yaml:"multusIpv6Addresses,omitempty" json:"multusIpv6Addresses,omitempty"`
+	// Image is the image reference used in the pod spec for this container.
+	Image string `yaml:"image,omitempty" json:"image,omitempty"`
+	// ImageID is the resolved image reported in the pod status, usually pinned by digest.
+	ImageID string `yaml:"imageID,omitempty" json:"imageID,omitempty"`
+	// NodeName is the name of the node the pod has been scheduled on.
+	NodeName string `yaml:"nodeName,omitempty" json:"nodeName,omitempty"`
+	// Ports are the container ports declared in the pod spec.
+	Ports []ContainerPort `yaml:"ports,omitempty" json:"ports,omitempty"`
+	// ResourceRequests are the resources requested in the pod spec, such as SR-IOV VFs, by resource name.
+	ResourceRequests map[string]string `yaml:"resourceRequests,omitempty" json:"resourceRequests,omitempty"`
+	// ResourceLimits are the resource limits set in the pod spec, by resource name.
+	ResourceLimits map[string]string `yaml:"resourceLimits,omitempty" json:"resourceLimits,omitempty"`
 }
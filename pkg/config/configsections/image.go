@@ -0,0 +1,38 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections
+
+// ImagePolicy states how the images of the containers under test must be referenced.
+type ImagePolicy struct {
+	// RequireDigest requires each image to be pinned by digest, rather than referenced by tag alone.
+	RequireDigest bool `yaml:"requireDigest,omitempty" json:"requireDigest,omitempty"`
+	// AllowedRegistries are the registries, such as `quay.io`, or registry paths, such as `quay.io/org`, which images may
+	// be pulled from.  A registry given as `*.example.com` allows each subdomain of example.com.  When empty, every
+	// registry is allowed.
+	AllowedRegistries []string `yaml:"allowedRegistries,omitempty" json:"allowedRegistries,omitempty"`
+}
+
+// VulnerabilityFeed locates the security advisories which the packages of the containers under test are matched against,
+// so that no network access is needed.
+type VulnerabilityFeed struct {
+	// Paths are the OVAL definitions, such as the Red Hat OVAL feed of a release, and CSAF advisories to read, or the
+	// directories holding them.  When empty, the packages are not matched.
+	Paths []string `yaml:"paths,omitempty" json:"paths,omitempty"`
+	// FailSeverity is the lowest severity, one of Low, Moderate, Important and Critical, of the fixable vulnerabilities
+	// which fail the test.  Vulnerabilities of a lower severity are only reported.  Defaults to Important.
+	FailSeverity string `yaml:"failSeverity,omitempty" json:"failSeverity,omitempty"`
+}
@@ -21,39 +21,3 @@ package configsections
 
 // CNFType defines a type to be either Operator or Container
 type CNFType string
-
-// Crd struct defines Custom Resource Definition of the operator
-type Crd struct {
-	// Name is the name of the CRD populated by the operator config generator
-	Name string `yaml:"name" json:"name"`
-
-	// Namespace is the namespace where above CRD is installed(For all namespace this will be ALL_NAMESPACE)
-	Namespace string `yaml:"namespace" json:"namespace"`
-
-	// Instances is the instance of CR matching for the above CRD KIND
-	Instances []Instance `yaml:"instances" json:"instances"`
-}
-
-// Deployment defines deployment resources
-type Deployment struct {
-	// Name is the name of the deployment specified in the CSV
-	Name string `yaml:"name" json:"name"`
-
-	// Replicas is no of replicas that are expected for this deployment as specified in the CSV
-	Replicas string `yaml:"replicas" json:"replicas"`
-}
-
-// Permission defines roles and cluster roles resources
-type Permission struct {
-	// Name is the name of Roles and Cluster Roles that is specified in the CSV
-	Name string `yaml:"name" json:"name"`
-
-	// Role is the role type either CLUSTER_ROLE or ROLE
-	Role string `yaml:"role" json:"role"`
-}
-
-// Instance defines crd instances in the cluster
-type Instance struct {
-	// Name is the name of the instance of custom resource (Auto populated)
-	Name string `yaml:"name" json:"name"`
-}
@@ -0,0 +1,126 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections
+
+import "fmt"
+
+const (
+	// ProtocolTCP is the protocol of a TCP port.
+	ProtocolTCP = "TCP"
+	// ProtocolUDP is the protocol of a UDP port.
+	ProtocolUDP = "UDP"
+	// HeadlessClusterIP is the cluster IP of a headless service.
+	HeadlessClusterIP = "None"
+)
+
+// ContainerPort is a port declared in the pod spec of a container.
+type ContainerPort struct {
+	// Name is the optional name of the port, which services may target.
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+	// Port is the port number.
+	Port int `yaml:"port" json:"port"`
+	// Protocol is one of TCP, UDP and SCTP.
+	Protocol string `yaml:"protocol" json:"protocol"`
+}
+
+// Service is a Service whose selector matches one or more pods under test.
+type Service struct {
+	// Name is the name of the service.
+	Name string `yaml:"name" json:"name"`
+	// Namespace is the namespace of the service.
+	Namespace string `yaml:"namespace" json:"namespace"`
+	// ClusterIP is the cluster IP of the service, or "None" for a headless service.
+	ClusterIP string `yaml:"clusterIP" json:"clusterIP"`
+	// ClusterIPs are the cluster IPs of the service, one per address family on dual-stack clusters.
+	ClusterIPs []string `yaml:"clusterIPs,omitempty" json:"clusterIPs,omitempty"`
+	// Ports are the ports exposed by the service.
+	Ports []ServicePort `yaml:"ports,omitempty" json:"ports,omitempty"`
+	// Endpoints are the IPs of the ready pods backing the service.
+	Endpoints []string `yaml:"endpoints,omitempty" json:"endpoints,omitempty"`
+}
+
+// IsHeadless returns true if the service has no cluster IP, so that its name resolves to its endpoints.
+func (s *Service) IsHeadless() bool {
+	return s.ClusterIP == HeadlessClusterIP
+}
+
+// ServicePort is a port exposed by a Service.
+type ServicePort struct {
+	// Name is the optional name of the port.
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+	// Port is the port number exposed on the cluster IP.
+	Port int `yaml:"port" json:"port"`
+	// Protocol is one of TCP, UDP and SCTP.
+	Protocol string `yaml:"protocol" json:"protocol"`
+	// TargetPort is the number or name of the container port the traffic is sent to.
+	TargetPort string `yaml:"targetPort,omitempty" json:"targetPort,omitempty"`
+}
+
+// UDPProbe defines how to check that a UDP port answers.  UDP is connectionless, so a UDP port is only checked when it
+// has a probe, which sends a payload the listening application is known to reply to.
+type UDPProbe struct {
+	// Port is the UDP port number the probe applies to.
+	Port int `yaml:"port" json:"port"`
+	// Payload is the text sent to the port.
+	Payload string `yaml:"payload" json:"payload"`
+}
+
+// LatencyThresholds are the limits on the round trip time and packet loss of the pings exchanged with the pods under
+// test.  A threshold which is not set is not checked.
+type LatencyThresholds struct {
+	// MaxAverageRTTMilliseconds is the largest average round trip time allowed on a path.
+	MaxAverageRTTMilliseconds *float64 `yaml:"maxAverageRttMs,omitempty" json:"maxAverageRttMs,omitempty"`
+	// MaxJitterMilliseconds is the largest deviation of the round trip times allowed on a path, as reported by the
+	// `mdev` ping statistic.
+	MaxJitterMilliseconds *float64 `yaml:"maxJitterMs,omitempty" json:"maxJitterMs,omitempty"`
+	// MaxPacketLossPercent is the largest share of requests allowed to go unanswered on a path.  When set, it replaces
+	// the default requirement that every request is answered.
+	MaxPacketLossPercent *float64 `yaml:"maxPacketLossPercent,omitempty" json:"maxPacketLossPercent,omitempty"`
+}
+
+// Violations returns a description of each threshold exceeded by the given average round trip time, jitter and
+// packet loss.
+func (l *LatencyThresholds) Violations(averageRTT, jitter, packetLoss float64) []string {
+	var violations []string
+	if l.MaxAverageRTTMilliseconds != nil && averageRTT > *l.MaxAverageRTTMilliseconds {
+		violations = append(violations, fmt.Sprintf("average round trip time %.3f ms exceeds %.3f ms", averageRTT,
+			*l.MaxAverageRTTMilliseconds))
+	}
+	if l.MaxJitterMilliseconds != nil && jitter > *l.MaxJitterMilliseconds {
+		violations = append(violations, fmt.Sprintf("jitter %.3f ms exceeds %.3f ms", jitter, *l.MaxJitterMilliseconds))
+	}
+	if l.MaxPacketLossPercent != nil && packetLoss > *l.MaxPacketLossPercent {
+		violations = append(violations, fmt.Sprintf("packet loss %.1f%% exceeds %.1f%%", packetLoss,
+			*l.MaxPacketLossPercent))
+	}
+	return violations
+}
+
+// ThroughputTest configures the iperf3 throughput measurements between the test orchestrator and the containers under
+// test.  Empty fields take the default values, and a minimum which is not set is not checked.
+type ThroughputTest struct {
+	// Port is the port the iperf3 server listens on.
+	Port int `yaml:"port,omitempty" json:"port,omitempty"`
+	// DurationSeconds is how long each measurement sends traffic for.
+	DurationSeconds int `yaml:"durationSeconds,omitempty" json:"durationSeconds,omitempty"`
+	// UDPBitrate is the rate UDP traffic is sent at, as given to `iperf3 --bitrate`, such as `1G`.
+	UDPBitrate string `yaml:"udpBitrate,omitempty" json:"udpBitrate,omitempty"`
+	// MinTCPMbps is the smallest TCP throughput allowed on a path, in megabits per second.
+	MinTCPMbps *float64 `yaml:"minTcpMbps,omitempty" json:"minTcpMbps,omitempty"`
+	// MinUDPMbps is the smallest UDP throughput allowed on a path, in megabits per second.
+	MinUDPMbps *float64 `yaml:"minUdpMbps,omitempty" json:"minUdpMbps,omitempty"`
+}
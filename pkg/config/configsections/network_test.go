@@ -0,0 +1,44 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_IsHeadless(t *testing.T) {
+	assert.True(t, (&Service{ClusterIP: HeadlessClusterIP}).IsHeadless())
+	assert.False(t, (&Service{ClusterIP: "172.30.0.10"}).IsHeadless())
+}
+
+func TestLatencyThresholds_Violations(t *testing.T) {
+	unset := LatencyThresholds{}
+	assert.Empty(t, unset.Violations(1000, 1000, 100))
+
+	maxRTT, maxJitter, maxLoss := 10.0, 2.0, 5.0
+	thresholds := LatencyThresholds{
+		MaxAverageRTTMilliseconds: &maxRTT,
+		MaxJitterMilliseconds:     &maxJitter,
+		MaxPacketLossPercent:      &maxLoss,
+	}
+	assert.Empty(t, thresholds.Violations(10, 2, 5))
+	assert.Equal(t, []string{"average round trip time 10.500 ms exceeds 10.000 ms"}, thresholds.Violations(10.5, 1, 0))
+	assert.Equal(t, []string{"jitter 2.100 ms exceeds 2.000 ms", "packet loss 20.0% exceeds 5.0%"},
+		thresholds.Violations(1, 2.1, 20))
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	// SRIOVPluginType is the type of the SR-IOV CNI plugin.
+	SRIOVPluginType = "sriov"
+)
+
+// NetworkAttachment is a secondary network requested by a pod through the `k8s.v1.cni.cncf.io/networks` annotation.
+type NetworkAttachment struct {
+	// Name is the name of the requested NetworkAttachmentDefinition.
+	Name string `yaml:"name" json:"name"`
+	// Namespace is the namespace of the requested NetworkAttachmentDefinition, which defaults to that of the pod.
+	Namespace string `yaml:"namespace" json:"namespace"`
+	// Interface is the name requested for the interface in the pod, if any.
+	Interface string `yaml:"interface,omitempty" json:"interface,omitempty"`
+}
+
+// NetworkStatus is the status of a network of a pod, as reported in the `k8s.v1.cni.cncf.io/networks-status`
+// annotation.
+type NetworkStatus struct {
+	// Name is the namespaced name of the NetworkAttachmentDefinition, or the name of the default network.
+	Name string `yaml:"name" json:"name"`
+	// Interface is the name of the interface in the pod.
+	Interface string `yaml:"interface,omitempty" json:"interface,omitempty"`
+	// IPs are the addresses assigned to the interface.
+	IPs []string `yaml:"ips,omitempty" json:"ips,omitempty"`
+	// Default is true for the default network of the pod.
+	Default bool `yaml:"default,omitempty" json:"default,omitempty"`
+	// PCIAddress is the address of the PCI device backing the interface, such as an SR-IOV VF, if any.
+	PCIAddress string `yaml:"pciAddress,omitempty" json:"pciAddress,omitempty"`
+}
+
+// NetworkAttachmentDefinition is a Multus NetworkAttachmentDefinition requested by a pod under test.
+type NetworkAttachmentDefinition struct {
+	Name      string `yaml:"name" json:"name"`
+	Namespace string `yaml:"namespace" json:"namespace"`
+	// ResourceName is the device plugin resource the attachment allocates devices from, such as a pool of SR-IOV VFs.
+	ResourceName string `yaml:"resourceName,omitempty" json:"resourceName,omitempty"`
+	// Config is the CNI configuration of the attachment, as JSON.
+	Config string `yaml:"config" json:"config"`
+}
+
+// cniConfig holds the fields of a CNI network configuration, or of a configuration list, which are validated.
+type cniConfig struct {
+	CNIVersion string `json:"cniVersion"`
+	Type       string `json:"type"`
+	Plugins    []struct {
+		Type string `json:"type"`
+	} `json:"plugins"`
+}
+
+// NamespacedName returns the name of the definition prefixed by its namespace, as in the network status.
+func (n *NetworkAttachmentDefinition) NamespacedName() string {
+	return n.Namespace + "/" + n.Name
+}
+
+// PluginTypes parses the CNI configuration, which is either a single network configuration or a configuration list,
+// and returns the types of its plugins.  An error is returned if the configuration is not valid.
+func (n *NetworkAttachmentDefinition) PluginTypes() ([]string, error) {
+	if n.Config == "" {
+		return nil, errors.New("the CNI configuration is empty")
+	}
+	config := cniConfig{}
+	if err := json.Unmarshal([]byte(n.Config), &config); err != nil {
+		return nil, fmt.Errorf("the CNI configuration is not valid JSON: %s", err)
+	}
+	if config.CNIVersion == "" {
+		return nil, errors.New("the CNI configuration has no cniVersion")
+	}
+	if config.Type != "" {
+		return []string{config.Type}, nil
+	}
+	if len(config.Plugins) == 0 {
+		return nil, errors.New("the CNI configuration has neither a type nor plugins")
+	}
+	types := make([]string, 0, len(config.Plugins))
+	for i, plugin := range config.Plugins {
+		if plugin.Type == "" {
+			return nil, fmt.Errorf("plugin %d of the CNI configuration has no type", i)
+		}
+		types = append(types, plugin.Type)
+	}
+	return types, nil
+}
+
+// IsSRIOV returns true if the CNI configuration is valid and uses the SR-IOV plugin.
+func (n *NetworkAttachmentDefinition) IsSRIOV() bool {
+	types, err := n.PluginTypes()
+	return err == nil && contains(types, SRIOVPluginType)
+}
@@ -0,0 +1,60 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkAttachmentDefinition_PluginTypes(t *testing.T) {
+	testCases := []struct {
+		config        string
+		expectedTypes []string
+		expectedError string
+	}{
+		{`{"cniVersion": "0.3.1", "type": "macvlan", "master": "ens3"}`, []string{"macvlan"}, ""},
+		{`{"cniVersion": "0.4.0", "plugins": [{"type": "sriov"}, {"type": "tuning"}]}`, []string{"sriov", "tuning"}, ""},
+		{``, nil, "the CNI configuration is empty"},
+		{`{"cniVersion": "0.3.1", "type": `, nil, "the CNI configuration is not valid JSON: unexpected end of JSON input"},
+		{`{"type": "macvlan"}`, nil, "the CNI configuration has no cniVersion"},
+		{`{"cniVersion": "0.3.1"}`, nil, "the CNI configuration has neither a type nor plugins"},
+		{`{"cniVersion": "0.4.0", "plugins": [{"type": "bridge"}, {}]}`, nil, "plugin 1 of the CNI configuration has no type"},
+	}
+	for _, testCase := range testCases {
+		nad := NetworkAttachmentDefinition{Name: "net", Namespace: "tnf", Config: testCase.config}
+		types, err := nad.PluginTypes()
+		assert.Equal(t, testCase.expectedTypes, types)
+		if testCase.expectedError == "" {
+			assert.Nil(t, err)
+		} else {
+			assert.EqualError(t, err, testCase.expectedError)
+		}
+	}
+}
+
+func TestNetworkAttachmentDefinition_IsSRIOV(t *testing.T) {
+	nad := NetworkAttachmentDefinition{Name: "sriov-net", Namespace: "tnf",
+		Config: `{"cniVersion": "0.3.1", "type": "sriov", "vlan": 100}`}
+	assert.True(t, nad.IsSRIOV())
+	assert.Equal(t, "tnf/sriov-net", nad.NamespacedName())
+	nad.Config = `{"cniVersion": "0.3.1", "type": "macvlan"}`
+	assert.False(t, nad.IsSRIOV())
+	nad.Config = `{"type": "sriov"}`
+	assert.False(t, nad.IsSRIOV())
+}
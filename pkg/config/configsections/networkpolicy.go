@@ -0,0 +1,197 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections
+
+import (
+	"strconv"
+)
+
+const (
+	// PolicyTypeIngress is the policy type of a NetworkPolicy which restricts the traffic to the pods it selects.
+	PolicyTypeIngress = "Ingress"
+	// PolicyTypeEgress is the policy type of a NetworkPolicy which restricts the traffic from the pods it selects.
+	PolicyTypeEgress = "Egress"
+
+	labelSelectorOpIn           = "In"
+	labelSelectorOpNotIn        = "NotIn"
+	labelSelectorOpExists       = "Exists"
+	labelSelectorOpDoesNotExist = "DoesNotExist"
+)
+
+// LabelSelector selects resources by their labels.  An empty LabelSelector selects every resource.
+type LabelSelector struct {
+	// MatchLabels are labels that must all be set to the given values.
+	MatchLabels map[string]string `yaml:"matchLabels,omitempty" json:"matchLabels,omitempty"`
+	// MatchExpressions are requirements that must all be met.
+	MatchExpressions []LabelSelectorRequirement `yaml:"matchExpressions,omitempty" json:"matchExpressions,omitempty"`
+}
+
+// LabelSelectorRequirement is a requirement on the value of a label.
+type LabelSelectorRequirement struct {
+	// Key is the label name.
+	Key string `yaml:"key" json:"key"`
+	// Operator is one of In, NotIn, Exists and DoesNotExist.
+	Operator string `yaml:"operator" json:"operator"`
+	// Values are the values of the In and NotIn operators.
+	Values []string `yaml:"values,omitempty" json:"values,omitempty"`
+}
+
+// NetworkPolicy is a NetworkPolicy of a namespace under test.
+type NetworkPolicy struct {
+	// Name is the name of the policy.
+	Name string `yaml:"name" json:"name"`
+	// Namespace is the namespace of the policy.
+	Namespace string `yaml:"namespace" json:"namespace"`
+	// PodSelector selects the pods of the namespace the policy applies to.
+	PodSelector LabelSelector `yaml:"podSelector" json:"podSelector"`
+	// PolicyTypes are Ingress, Egress or both.
+	PolicyTypes []string `yaml:"policyTypes,omitempty" json:"policyTypes,omitempty"`
+	// Ingress are the rules of the traffic allowed to the selected pods.
+	Ingress []NetworkPolicyIngressRule `yaml:"ingress,omitempty" json:"ingress,omitempty"`
+}
+
+// NetworkPolicyIngressRule allows traffic from the peers to the ports.  Empty peers or ports allow any.
+type NetworkPolicyIngressRule struct {
+	// From are the sources the traffic is allowed from.
+	From []NetworkPolicyPeer `yaml:"from,omitempty" json:"from,omitempty"`
+	// Ports are the ports the traffic is allowed to.
+	Ports []NetworkPolicyPort `yaml:"ports,omitempty" json:"ports,omitempty"`
+}
+
+// NetworkPolicyPeer is a source of traffic.
+type NetworkPolicyPeer struct {
+	// PodSelector selects the source pods.
+	PodSelector *LabelSelector `yaml:"podSelector,omitempty" json:"podSelector,omitempty"`
+	// NamespaceSelector selects the namespaces of the source pods.
+	NamespaceSelector *LabelSelector `yaml:"namespaceSelector,omitempty" json:"namespaceSelector,omitempty"`
+	// IPBlock is the CIDR of the source IPs.
+	IPBlock string `yaml:"ipBlock,omitempty" json:"ipBlock,omitempty"`
+}
+
+// NetworkPolicyPort is a port, or range of ports, traffic is allowed to.
+type NetworkPolicyPort struct {
+	// Protocol is one of TCP, UDP and SCTP.
+	Protocol string `yaml:"protocol" json:"protocol"`
+	// Port is the number or the name of the port.  An empty port matches every port of the protocol.
+	Port string `yaml:"port,omitempty" json:"port,omitempty"`
+	// EndPort is the last port of a range starting at Port.
+	EndPort int `yaml:"endPort,omitempty" json:"endPort,omitempty"`
+}
+
+// Matches returns true if `labels` meet every requirement of the selector.
+func (s *LabelSelector) Matches(labels map[string]string) bool {
+	for name, value := range s.MatchLabels {
+		if labelValue, ok := labels[name]; !ok || labelValue != value {
+			return false
+		}
+	}
+	for _, requirement := range s.MatchExpressions {
+		value, ok := labels[requirement.Key]
+		switch requirement.Operator {
+		case labelSelectorOpIn:
+			if !ok || !contains(requirement.Values, value) {
+				return false
+			}
+		case labelSelectorOpNotIn:
+			if ok && contains(requirement.Values, value) {
+				return false
+			}
+		case labelSelectorOpExists:
+			if !ok {
+				return false
+			}
+		case labelSelectorOpDoesNotExist:
+			if ok {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// IsEmpty returns true if the selector selects every resource.
+func (s *LabelSelector) IsEmpty() bool {
+	return len(s.MatchLabels) == 0 && len(s.MatchExpressions) == 0
+}
+
+// SelectsPod returns true if the policy applies to `pod`.
+func (np *NetworkPolicy) SelectsPod(pod *Pod) bool {
+	return np.Namespace == pod.Namespace && np.PodSelector.Matches(pod.Labels)
+}
+
+// AppliesToIngress returns true if the policy restricts the traffic to the pods it selects.  A policy without policy
+// types always applies to ingress.
+func (np *NetworkPolicy) AppliesToIngress() bool {
+	return len(np.PolicyTypes) == 0 || contains(np.PolicyTypes, PolicyTypeIngress)
+}
+
+// IsDefaultDenyIngress returns true if the policy selects every pod of its namespace and allows no ingress traffic, so
+// that only the traffic allowed by other policies reaches the pods.
+func (np *NetworkPolicy) IsDefaultDenyIngress() bool {
+	return np.PodSelector.IsEmpty() && np.AppliesToIngress() && len(np.Ingress) == 0
+}
+
+// AllowsIngressPort returns true if a rule of the policy allows traffic, from any peer, to `port` of `protocol`, which
+// may be known by `portName`.
+func (np *NetworkPolicy) AllowsIngressPort(protocol string, port int, portName string) bool {
+	if !np.AppliesToIngress() {
+		return true
+	}
+	for _, rule := range np.Ingress {
+		if len(rule.Ports) == 0 {
+			return true
+		}
+		for _, allowed := range rule.Ports {
+			if allowed.matches(protocol, port, portName) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (p *NetworkPolicyPort) matches(protocol string, port int, portName string) bool {
+	allowedProtocol := p.Protocol
+	if allowedProtocol == "" {
+		allowedProtocol = ProtocolTCP
+	}
+	if allowedProtocol != protocol {
+		return false
+	}
+	if p.Port == "" {
+		return true
+	}
+	number, err := strconv.Atoi(p.Port)
+	if err != nil {
+		return portName != "" && p.Port == portName
+	}
+	if p.EndPort > 0 {
+		return port >= number && port <= p.EndPort
+	}
+	return port == number
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
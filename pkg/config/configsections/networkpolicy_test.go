@@ -0,0 +1,83 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabelSelector_Matches(t *testing.T) {
+	labels := map[string]string{"app": "test", "tier": "backend"}
+	testCases := []struct {
+		selector LabelSelector
+		expected bool
+	}{
+		{LabelSelector{}, true},
+		{LabelSelector{MatchLabels: map[string]string{"app": "test"}}, true},
+		{LabelSelector{MatchLabels: map[string]string{"app": "other"}}, false},
+		{LabelSelector{MatchExpressions: []LabelSelectorRequirement{{Key: "tier", Operator: "In", Values: []string{"frontend", "backend"}}}}, true},
+		{LabelSelector{MatchExpressions: []LabelSelectorRequirement{{Key: "tier", Operator: "NotIn", Values: []string{"backend"}}}}, false},
+		{LabelSelector{MatchExpressions: []LabelSelectorRequirement{{Key: "app", Operator: "Exists"}}}, true},
+		{LabelSelector{MatchExpressions: []LabelSelectorRequirement{{Key: "app", Operator: "DoesNotExist"}}}, false},
+		{LabelSelector{MatchExpressions: []LabelSelectorRequirement{{Key: "zone", Operator: "NotIn", Values: []string{"a"}}}}, true},
+		{LabelSelector{MatchExpressions: []LabelSelectorRequirement{{Key: "app", Operator: "Unknown"}}}, false},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, testCase.selector.Matches(labels), "%+v", testCase.selector)
+	}
+}
+
+func TestNetworkPolicy_SelectsPod(t *testing.T) {
+	policy := NetworkPolicy{Namespace: "tnf", PodSelector: LabelSelector{MatchLabels: map[string]string{"app": "test"}}}
+	assert.True(t, policy.SelectsPod(&Pod{Namespace: "tnf", Labels: map[string]string{"app": "test"}}))
+	assert.False(t, policy.SelectsPod(&Pod{Namespace: "default", Labels: map[string]string{"app": "test"}}))
+	assert.False(t, policy.SelectsPod(&Pod{Namespace: "tnf"}))
+}
+
+func TestNetworkPolicy_IsDefaultDenyIngress(t *testing.T) {
+	assert.True(t, (&NetworkPolicy{}).IsDefaultDenyIngress())
+	assert.True(t, (&NetworkPolicy{PolicyTypes: []string{PolicyTypeIngress}}).IsDefaultDenyIngress())
+	assert.False(t, (&NetworkPolicy{PolicyTypes: []string{PolicyTypeEgress}}).IsDefaultDenyIngress())
+	assert.False(t, (&NetworkPolicy{Ingress: []NetworkPolicyIngressRule{{}}}).IsDefaultDenyIngress())
+	assert.False(t, (&NetworkPolicy{PodSelector: LabelSelector{MatchLabels: map[string]string{"app": "test"}}}).IsDefaultDenyIngress())
+}
+
+func TestNetworkPolicy_AllowsIngressPort(t *testing.T) {
+	policy := NetworkPolicy{Ingress: []NetworkPolicyIngressRule{
+		{Ports: []NetworkPolicyPort{
+			{Protocol: ProtocolTCP, Port: "8080"},
+			{Protocol: ProtocolTCP, Port: "metrics"},
+			{Protocol: ProtocolTCP, Port: "9000", EndPort: 9010},
+			{Protocol: ProtocolUDP},
+		}},
+	}}
+	assert.True(t, policy.AllowsIngressPort(ProtocolTCP, 8080, ""))
+	assert.False(t, policy.AllowsIngressPort(ProtocolTCP, 8443, ""))
+	assert.True(t, policy.AllowsIngressPort(ProtocolTCP, 9100, "metrics"))
+	assert.False(t, policy.AllowsIngressPort(ProtocolTCP, 9100, ""))
+	assert.True(t, policy.AllowsIngressPort(ProtocolTCP, 9005, ""))
+	assert.False(t, policy.AllowsIngressPort(ProtocolTCP, 9011, ""))
+	assert.True(t, policy.AllowsIngressPort(ProtocolUDP, 5353, ""))
+
+	// A rule without ports allows every port, and a default deny policy allows none.
+	assert.True(t, (&NetworkPolicy{Ingress: []NetworkPolicyIngressRule{{}}}).AllowsIngressPort(ProtocolTCP, 8443, ""))
+	assert.False(t, (&NetworkPolicy{}).AllowsIngressPort(ProtocolTCP, 8443, ""))
+	// Egress only policies do not restrict ingress.
+	assert.True(t, (&NetworkPolicy{PolicyTypes: []string{PolicyTypeEgress}}).AllowsIngressPort(ProtocolTCP, 8443, ""))
+}
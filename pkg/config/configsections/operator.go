@@ -0,0 +1,93 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections
+
+const (
+	// ClusterRole is the Permission role of a permission granted cluster wide.
+	ClusterRole = "CLUSTER_ROLE"
+	// NamespaceRole is the Permission role of a permission granted in the operator namespace.
+	NamespaceRole = "ROLE"
+)
+
+// InstallMode defines whether an operator supports a way of being installed, as specified in the CSV
+type InstallMode struct {
+	// Type is the install mode, one of OwnNamespace, SingleNamespace, MultiNamespace and AllNamespaces
+	Type string `yaml:"type" json:"type"`
+
+	// Supported is true when the operator can be installed in this mode
+	Supported bool `yaml:"supported" json:"supported"`
+}
+
+// Crd struct defines Custom Resource Definition of the operator
+type Crd struct {
+	// Name is the name of the CRD, as plural.group
+	Name string `yaml:"name" json:"name"`
+
+	// Kind is the kind of the custom resources
+	Kind string `yaml:"kind" json:"kind"`
+
+	// Version is the version of the custom resources
+	Version string `yaml:"version" json:"version"`
+
+	// Namespace is the namespace where above CRD is installed(For all namespace this will be ALL_NAMESPACE)
+	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+
+	// Instances is the instance of CR matching for the above CRD KIND (Auto populated for owned CRDs)
+	Instances []Instance `yaml:"instances,omitempty" json:"instances,omitempty"`
+}
+
+// Deployment defines deployment resources
+type Deployment struct {
+	// Name is the name of the deployment specified in the CSV
+	Name string `yaml:"name" json:"name"`
+
+	// Replicas is no of replicas that are expected for this deployment as specified in the CSV
+	Replicas int `yaml:"replicas" json:"replicas"`
+}
+
+// Permission defines roles and cluster roles resources
+type Permission struct {
+	// Name is the name of the service account the Role or Cluster Role is granted to, as specified in the CSV
+	Name string `yaml:"name" json:"name"`
+
+	// Role is the role type either CLUSTER_ROLE or ROLE
+	Role string `yaml:"role" json:"role"`
+
+	// Rules are the policy rules of the role
+	Rules []PolicyRule `yaml:"rules,omitempty" json:"rules,omitempty"`
+}
+
+// PolicyRule defines the verbs allowed on resources by a Permission
+type PolicyRule struct {
+	// APIGroups are the API groups of the resources
+	APIGroups []string `yaml:"apiGroups,omitempty" json:"apiGroups,omitempty"`
+
+	// Resources are the resources the verbs apply to
+	Resources []string `yaml:"resources,omitempty" json:"resources,omitempty"`
+
+	// Verbs are the allowed verbs, such as get, list or create
+	Verbs []string `yaml:"verbs" json:"verbs"`
+}
+
+// Instance defines crd instances in the cluster
+type Instance struct {
+	// Name is the name of the instance of custom resource (Auto populated)
+	Name string `yaml:"name" json:"name"`
+
+	// Namespace is the namespace of the instance, empty for a cluster scoped resource (Auto populated)
+	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+}
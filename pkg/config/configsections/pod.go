@@ -26,4 +26,13 @@ type Pod struct {
 
 	// Tests this is list of test that need to run against the Pod.
 	Tests []string `yaml:"tests" json:"tests"`
+
+	// Labels are the labels of the Pod (Auto populated).
+	Labels map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
+
+	// Networks are the secondary networks requested by the Pod (Auto populated).
+	Networks []NetworkAttachment `yaml:"networks,omitempty" json:"networks,omitempty"`
+
+	// NetworksStatus are the networks of the Pod as reported by Multus (Auto populated).
+	NetworksStatus []NetworkStatus `yaml:"networksStatus,omitempty" json:"networksStatus,omitempty"`
 }
@@ -0,0 +1,58 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections
+
+// Settings holds the switches that change how the test suites run, as opposed to what they test.  Each setting may
+// also be given through a `TNF_*` environment variable or a command line flag.
+type Settings struct {
+	// DisableAutodiscover turns autodiscovery off, so that the test target and partner come from configuration only.
+	DisableAutodiscover bool `yaml:"disableAutodiscover,omitempty" json:"disableAutodiscover,omitempty"`
+	// NonIntrusiveOnly skips the tests that would impact the CNF or test environment in an intrusive way.
+	NonIntrusiveOnly bool `yaml:"nonIntrusiveOnly,omitempty" json:"nonIntrusiveOnly,omitempty"`
+	// MinikubeOnly skips the tests that require OpenShift.
+	MinikubeOnly bool `yaml:"minikubeOnly,omitempty" json:"minikubeOnly,omitempty"`
+	// DefaultBufferSize is the size in bytes of the buffers used for interactive sessions, when not the default.
+	DefaultBufferSize int `yaml:"defaultBufferSize,omitempty" json:"defaultBufferSize,omitempty"`
+	// DiscoverySnapshot is the path of a discovery snapshot to use in place of autodiscovery.
+	DiscoverySnapshot string `yaml:"discoverySnapshot,omitempty" json:"discoverySnapshot,omitempty"`
+	// DeployPartner deploys the partner workloads for the run when no test orchestrator is found in the cluster.
+	DeployPartner bool `yaml:"deployPartner,omitempty" json:"deployPartner,omitempty"`
+	// PartnerDeployment describes the partner workloads deployed when DeployPartner is set.
+	PartnerDeployment PartnerDeployment `yaml:"partnerDeployment,omitempty" json:"partnerDeployment,omitempty"`
+	// ClusterDomain is the DNS domain of the cluster services, when not cluster.local.
+	ClusterDomain string `yaml:"clusterDomain,omitempty" json:"clusterDomain,omitempty"`
+	// ConnectivityMatrix pings from every container under test to every other, in addition to the test orchestrator.
+	ConnectivityMatrix bool `yaml:"connectivityMatrix,omitempty" json:"connectivityMatrix,omitempty"`
+	// ConnectivityMatrixConcurrency is the number of containers pinging at the same time in the connectivity matrix,
+	// when not the default.
+	ConnectivityMatrixConcurrency int `yaml:"connectivityMatrixConcurrency,omitempty" json:"connectivityMatrixConcurrency,omitempty"`
+}
+
+// PartnerDeployment describes the partner workloads that the test suite deploys for a run.  Empty fields take the
+// default values.
+type PartnerDeployment struct {
+	// Namespace is the namespace the partner workloads are created in.
+	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+	// OrchestratorImage is the image of the test orchestrator container.
+	OrchestratorImage string `yaml:"orchestratorImage,omitempty" json:"orchestratorImage,omitempty"`
+	// FsDiffImage is the image of the fs diff master container.
+	FsDiffImage string `yaml:"fsDiffImage,omitempty" json:"fsDiffImage,omitempty"`
+	// NodeSelector places the partner pods on the nodes with matching labels.
+	NodeSelector map[string]string `yaml:"nodeSelector,omitempty" json:"nodeSelector,omitempty"`
+	// ReadinessTimeoutSeconds is how long to wait for the partner workloads to become ready.
+	ReadinessTimeoutSeconds int `yaml:"readinessTimeoutSeconds,omitempty" json:"readinessTimeoutSeconds,omitempty"`
+}
@@ -16,8 +16,15 @@
 
 /*
 Package config provides test-network-function configuration through a central place. Configuration data
-is automatically included in the claim. Configuration should all be contained in a single yaml file, with each
+is automatically included in the claim. Configuration is written as yaml, with each
 configuration area under its own key.
+Configuration is supplied through the Provider interface. EnvProvider is used for test runs, FileProvider reads a
+configuration file as is and MemoryProvider holds a configuration built in code, so that the three can be swapped in
+tests.
 The env var "TNF_CONFIGURATION_PATH" identifies the config file. If not set, the default of `tnf_config.yml` is used.
+It may list several files, separated as in PATH, which are merged as layers: the first file is the base and the
+others overlay it in order, then the `TNF_*` env vars and finally command line overrides take precedence.
+The setting "discoverySnapshot" (env var "TNF_DISCOVERY_SNAPSHOT") may name a discovery snapshot written by a previous
+run, in which case the test target and partner are taken from the snapshot and autodiscovery is skipped.
 */
 package config
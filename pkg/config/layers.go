@@ -0,0 +1,250 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// pathSeparator separates the keys of a configuration path, as in `settings.minikubeOnly`.
+	pathSeparator = "."
+	// keySeparator separates the keys of a path internally, so that keys which contain a dot, such as node labels,
+	// remain a single key.
+	keySeparator = "\x00"
+	// overrideSeparator separates the path from the value of an override, as in `settings.minikubeOnly=true`.
+	overrideSeparator = "="
+
+	originFileTemplate        = "file %s"
+	originEnvironmentTemplate = "env %s"
+	originOverride            = "flag --set"
+)
+
+// environmentSettings lists the `TNF_*` environment variables, the configuration path that each one sets, and how its
+// value is converted to the type of the setting.
+var environmentSettings = []struct {
+	variable string
+	path     string
+	convert  func(string) (interface{}, error)
+}{
+	{"TNF_DISABLE_CONFIG_AUTODISCOVER", "settings.disableAutodiscover", convertBool},
+	{"TNF_NON_INTRUSIVE_ONLY", "settings.nonIntrusiveOnly", convertBool},
+	{"TNF_MINIKUBE_ONLY", "settings.minikubeOnly", convertBool},
+	{"TNF_DEFAULT_BUFFER_SIZE", "settings.defaultBufferSize", convertInt},
+	{"TNF_DISCOVERY_SNAPSHOT", "settings.discoverySnapshot", convertString},
+	{"TNF_DEPLOY_PARTNER", "settings.deployPartner", convertBool},
+	{"TNF_PARTNER_NAMESPACE", "settings.partnerDeployment.namespace", convertString},
+	{"TNF_CLUSTER_DOMAIN", "settings.clusterDomain", convertString},
+	{"TNF_CONNECTIVITY_MATRIX", "settings.connectivityMatrix", convertString},
+}
+
+// Value is a single value of a LayeredConfig, along with the layer that supplied it.
+type Value struct {
+	// Path locates the value, as dot separated keys.
+	Path string
+	// Value is the value itself.  Lists are treated as single values, and are never merged.
+	Value interface{}
+	// Origin names the layer that supplied the value.
+	Origin string
+}
+
+// LayeredConfig is a configuration merged from several layers.  Each layer is added in increasing order of
+// precedence, replacing any value already set at the same path by an earlier layer.
+type LayeredConfig struct {
+	// values are indexed by their keys joined with keySeparator.
+	values map[string]Value
+}
+
+// NewLayeredConfig creates an empty LayeredConfig.
+func NewLayeredConfig() *LayeredConfig {
+	return &LayeredConfig{values: make(map[string]Value)}
+}
+
+// LoadLayers merges, in increasing order of precedence, the configuration files, the `TNF_*` environment variables
+// as found by `lookupEnv`, and the `overrides`, each given as `path=value`.  When `files` is empty, the files named by
+// the environment are used.
+func LoadLayers(files, overrides []string, lookupEnv func(string) (string, bool)) (*LayeredConfig, error) {
+	if len(files) == 0 {
+		files = getConfigurationFilePathsFromEnvironment()
+	}
+	layers := NewLayeredConfig()
+	for _, filePath := range files {
+		if err := layers.AddFile(filePath); err != nil {
+			return nil, err
+		}
+	}
+	if err := layers.AddEnvironment(lookupEnv); err != nil {
+		return nil, err
+	}
+	if err := layers.AddOverrides(overrides); err != nil {
+		return nil, err
+	}
+	return layers, nil
+}
+
+// AddFile adds the yaml configuration file at `filePath` as a layer.
+func (l *LayeredConfig) AddFile(filePath string) error {
+	contents, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	var tree interface{}
+	err = yaml.Unmarshal(contents, &tree)
+	if err != nil {
+		return fmt.Errorf("error (%s) attempting to unmarshal configuration file %s", err, filePath)
+	}
+	if _, ok := tree.(map[interface{}]interface{}); tree != nil && !ok {
+		return fmt.Errorf("configuration file %s does not contain a yaml mapping", filePath)
+	}
+	l.addTree(nil, tree, fmt.Sprintf(originFileTemplate, filePath))
+	return nil
+}
+
+// AddEnvironment adds the `TNF_*` environment variables that are set and not empty as a layer.  Each value is converted
+// to the type of its setting, booleans being parsed as by strconv.ParseBool, so that `1` and `t` are both true.
+func (l *LayeredConfig) AddEnvironment(lookupEnv func(string) (string, bool)) error {
+	for _, setting := range environmentSettings {
+		raw, ok := lookupEnv(setting.variable)
+		if !ok || raw == "" {
+			continue
+		}
+		value, err := setting.convert(raw)
+		if err != nil {
+			return fmt.Errorf("invalid value %q for %s: %s", raw, setting.variable, err)
+		}
+		l.set(strings.Split(setting.path, pathSeparator), value, fmt.Sprintf(originEnvironmentTemplate, setting.variable))
+	}
+	return nil
+}
+
+// AddOverrides adds `overrides`, each given as `path=value`, as a layer.
+func (l *LayeredConfig) AddOverrides(overrides []string) error {
+	for _, override := range overrides {
+		parts := strings.SplitN(override, overrideSeparator, 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return fmt.Errorf("invalid override %q, expected path=value", override)
+		}
+		l.set(strings.Split(parts[0], pathSeparator), parseValue(parts[1]), originOverride)
+	}
+	return nil
+}
+
+// Values returns every value of the merged configuration, ordered by path.
+func (l *LayeredConfig) Values() []Value {
+	values := make([]Value, 0, len(l.values))
+	for _, value := range l.values {
+		values = append(values, value)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].Path < values[j].Path
+	})
+	return values
+}
+
+// Marshal renders the merged configuration as yaml.
+func (l *LayeredConfig) Marshal() ([]byte, error) {
+	return yaml.Marshal(l.tree())
+}
+
+// Build returns the merged configuration.
+func (l *LayeredConfig) Build() (configsections.TestConfiguration, error) {
+	conf := configsections.TestConfiguration{}
+	contents, err := l.Marshal()
+	if err != nil {
+		return conf, err
+	}
+	err = yaml.Unmarshal(contents, &conf)
+	return conf, err
+}
+
+// addTree sets every leaf of a yaml tree, with `keys` locating `node`.
+func (l *LayeredConfig) addTree(keys []string, node interface{}, origin string) {
+	mapping, ok := node.(map[interface{}]interface{})
+	if !ok {
+		if len(keys) > 0 {
+			l.set(keys, node, origin)
+		}
+		return
+	}
+	for key, child := range mapping {
+		childKeys := append(append([]string{}, keys...), fmt.Sprint(key))
+		l.addTree(childKeys, child, origin)
+	}
+}
+
+// set records `value` at `keys`, replacing both the values below `keys` and any value at a parent of `keys`.
+func (l *LayeredConfig) set(keys []string, value interface{}, origin string) {
+	index := strings.Join(keys, keySeparator)
+	for existing := range l.values {
+		if strings.HasPrefix(existing, index+keySeparator) || strings.HasPrefix(index, existing+keySeparator) {
+			delete(l.values, existing)
+		}
+	}
+	l.values[index] = Value{Path: strings.Join(keys, pathSeparator), Value: value, Origin: origin}
+}
+
+// tree rebuilds the nested yaml tree from the merged values.
+func (l *LayeredConfig) tree() map[string]interface{} {
+	root := make(map[string]interface{})
+	for index, value := range l.values {
+		keys := strings.Split(index, keySeparator)
+		node := root
+		for _, key := range keys[:len(keys)-1] {
+			child, ok := node[key].(map[string]interface{})
+			if !ok {
+				child = make(map[string]interface{})
+				node[key] = child
+			}
+			node = child
+		}
+		node[keys[len(keys)-1]] = value.Value
+	}
+	return root
+}
+
+// convertBool converts the value of a boolean environment variable.
+func convertBool(raw string) (interface{}, error) {
+	return strconv.ParseBool(raw)
+}
+
+// convertInt converts the value of an integer environment variable.
+func convertInt(raw string) (interface{}, error) {
+	return strconv.Atoi(raw)
+}
+
+// convertString keeps the value of a string environment variable as is, so that a value such as `123` or `yes` is not
+// mistaken for a number or a boolean.
+func convertString(raw string) (interface{}, error) {
+	return raw, nil
+}
+
+// parseValue interprets a value given as a string the way yaml would, so that `true` is a boolean and `32768` an
+// integer.  Anything that is not valid yaml is kept as a string.
+func parseValue(raw string) interface{} {
+	var value interface{}
+	if err := yaml.Unmarshal([]byte(raw), &value); err != nil {
+		return raw
+	}
+	return value
+}
@@ -0,0 +1,121 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	overlayFilePath = "testdata/tnf_test_overlay.yml"
+)
+
+func lookupEnvFrom(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+}
+
+func getOrigin(layers *LayeredConfig, path string) string {
+	for _, value := range layers.Values() {
+		if value.Path == path {
+			return value.Origin
+		}
+	}
+	return ""
+}
+
+func TestLoadLayers(t *testing.T) {
+	env := map[string]string{
+		"TNF_MINIKUBE_ONLY":       "true",
+		"TNF_DEFAULT_BUFFER_SIZE": "32768",
+		"TNF_NON_INTRUSIVE_ONLY":  "",
+	}
+	layers, err := LoadLayers([]string{filePath, overlayFilePath}, []string{"settings.defaultBufferSize=65536"}, lookupEnvFrom(env))
+	assert.Nil(t, err)
+	conf, err := layers.Build()
+	assert.Nil(t, err)
+
+	// The base file supplies what the overlay does not replace.
+	assert.Equal(t, "partner", conf.TestOrchestrator.PodName)
+	assert.Equal(t, "file "+filePath, getOrigin(layers, "testPartner.testOrchestrator.podName"))
+	assert.Equal(t, 2, len(conf.ContainersUnderTest))
+	// The overlay replaces single values, and lists as a whole.
+	assert.Equal(t, "lab", conf.TestOrchestrator.Namespace)
+	assert.Equal(t, "file "+overlayFilePath, getOrigin(layers, "testPartner.testOrchestrator.namespace"))
+	assert.Equal(t, 1, len(conf.CertifiedContainerInfo))
+	assert.Equal(t, "nginx-118", conf.CertifiedContainerInfo[0].Name)
+	// An empty environment variable does not replace the files.
+	assert.True(t, conf.Settings.NonIntrusiveOnly)
+	assert.Equal(t, "file "+overlayFilePath, getOrigin(layers, "settings.nonIntrusiveOnly"))
+	// The environment replaces the files, and the overrides replace the environment.
+	assert.True(t, conf.Settings.MinikubeOnly)
+	assert.Equal(t, "env TNF_MINIKUBE_ONLY", getOrigin(layers, "settings.minikubeOnly"))
+	assert.Equal(t, 65536, conf.Settings.DefaultBufferSize)
+	assert.Equal(t, "flag --set", getOrigin(layers, "settings.defaultBufferSize"))
+	// Keys containing a dot remain a single key.
+	assert.Equal(t, "worker-0", conf.Settings.PartnerDeployment.NodeSelector["kubernetes.io/hostname"])
+}
+
+func TestLayeredConfigReplacesSubtrees(t *testing.T) {
+	layers := NewLayeredConfig()
+	assert.Nil(t, layers.AddOverrides([]string{"testPartner.testOrchestrator.podName=partner"}))
+	assert.Nil(t, layers.AddOverrides([]string{"testPartner.testOrchestrator={}"}))
+	assert.Equal(t, 1, len(layers.Values()))
+	assert.Nil(t, layers.AddOverrides([]string{"testPartner.testOrchestrator.namespace=tnf"}))
+	conf, err := layers.Build()
+	assert.Nil(t, err)
+	assert.Equal(t, "", conf.TestOrchestrator.PodName)
+	assert.Equal(t, "tnf", conf.TestOrchestrator.Namespace)
+}
+
+func TestLoadLayersErrors(t *testing.T) {
+	_, err := LoadLayers([]string{"testdata/doesNotExist.yml"}, nil, lookupEnvFrom(nil))
+	assert.NotNil(t, err)
+	_, err = LoadLayers([]string{filePath}, []string{"=true"}, lookupEnvFrom(nil))
+	assert.NotNil(t, err)
+	_, err = LoadLayers([]string{filePath}, []string{"settings.minikubeOnly"}, lookupEnvFrom(nil))
+	assert.NotNil(t, err)
+
+	_, err = LoadLayers([]string{filePath}, nil, lookupEnvFrom(map[string]string{"TNF_MINIKUBE_ONLY": "sometimes"}))
+	assert.NotNil(t, err)
+	_, err = LoadLayers([]string{filePath}, nil, lookupEnvFrom(map[string]string{"TNF_DEFAULT_BUFFER_SIZE": "large"}))
+	assert.NotNil(t, err)
+}
+
+func TestLoadLayersEnvironmentTypes(t *testing.T) {
+	env := map[string]string{
+		"TNF_NON_INTRUSIVE_ONLY":          "1",
+		"TNF_MINIKUBE_ONLY":               "t",
+		"TNF_DISABLE_CONFIG_AUTODISCOVER": "F",
+		"TNF_DEFAULT_BUFFER_SIZE":         "32768",
+		"TNF_CLUSTER_DOMAIN":              "yes",
+	}
+	layers, err := LoadLayers([]string{filePath}, nil, lookupEnvFrom(env))
+	assert.Nil(t, err)
+	conf, err := layers.Build()
+	assert.Nil(t, err)
+	assert.True(t, conf.Settings.NonIntrusiveOnly)
+	assert.True(t, conf.Settings.MinikubeOnly)
+	assert.False(t, conf.Settings.DisableAutodiscover)
+	assert.Equal(t, 32768, conf.Settings.DefaultBufferSize)
+	// A string setting is never interpreted as yaml.
+	assert.Equal(t, "yes", conf.Settings.ClusterDomain)
+}
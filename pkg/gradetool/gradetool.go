@@ -21,8 +21,10 @@ import (
 	"fmt"
 	"io/ioutil"
 	"path"
+	"sort"
 
 	"github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/baseimage"
 	"github.com/test-network-function/test-network-function/pkg/jsonschema"
 	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
 	"github.com/xeipuuv/gojsonschema"
@@ -30,6 +32,9 @@ import (
 
 const (
 	outputFilePermissions = 420
+	// baseImagesKey is the key of the classes of the base images of the containers under test in the raw results of the
+	// claim.
+	baseImagesKey = "baseImages"
 )
 
 var (
@@ -40,7 +45,10 @@ var (
 type Grade struct {
 	GradeName            string
 	RequiredPassingTests []identifier.Identifier
-	NextGrade            *Grade
+	// RequiredBaseImageClasses are the classes of base images, such as `ubi-minimal`, which every container under test
+	// must be built from.  When empty, any base image is accepted.
+	RequiredBaseImageClasses []string
+	NextGrade                *Grade
 }
 
 // Policy is the object in the policy file
@@ -54,6 +62,8 @@ type GradeResult struct {
 	Propose bool
 	Pass    []identifier.Identifier
 	Fail    []identifier.Identifier
+	// BaseImageFail lists the containers whose base image is not of a required class.
+	BaseImageFail []string `json:",omitempty"`
 }
 
 // GenerateGrade outputs a grade file based on input test results and input grading policy
@@ -80,8 +90,13 @@ func GenerateGrade(resultsPath, policyPath, outputPath string) error {
 		return err
 	}
 
+	baseImages, err := getBaseImages(claimObj.Claim.RawResults)
+	if err != nil {
+		return err
+	}
+
 	// start grading process
-	gradingOutput, err := doGrading(policyObj, claimObj.Claim.Results)
+	gradingOutput, err := doGrading(policyObj, claimObj.Claim.Results, baseImages)
 	if err != nil {
 		return err
 	}
@@ -98,14 +113,14 @@ func GenerateGrade(resultsPath, policyPath, outputPath string) error {
 // NewGradeResult creates a new object without nil properties
 func NewGradeResult(gradeName string) GradeResult {
 	emptySlice := []identifier.Identifier{}
-	return GradeResult{gradeName, false, emptySlice, emptySlice}
+	return GradeResult{gradeName, false, emptySlice, emptySlice, nil}
 }
 
 func generateTestResultsKey(id identifier.Identifier) string {
 	return fmt.Sprintf("{\"url\":\"%s\",\"version\":\"%s\"}", id.URL, id.SemanticVersion)
 }
 
-func doGrading(policy Policy, results map[string]interface{}) (interface{}, error) {
+func doGrading(policy Policy, results map[string]interface{}, baseImages map[string]baseimage.Classification) (interface{}, error) {
 	gradingOutput := []GradeResult{}
 
 	grade := &policy.Grades
@@ -130,7 +145,8 @@ func doGrading(policy Policy, results map[string]interface{}) (interface{}, erro
 				gradeResult.Fail = append(gradeResult.Fail, id)
 			}
 		}
-		if previousGradePassed && len(gradeResult.Fail) == 0 {
+		gradeResult.BaseImageFail = gradeBaseImages(grade.RequiredBaseImageClasses, baseImages)
+		if previousGradePassed && len(gradeResult.Fail) == 0 && len(gradeResult.BaseImageFail) == 0 {
 			gradeResult.Propose = true
 		}
 		gradingOutput = append(gradingOutput, gradeResult)
@@ -140,6 +156,52 @@ func doGrading(policy Policy, results map[string]interface{}) (interface{}, erro
 	return gradingOutput, nil
 }
 
+// gradeBaseImages returns the containers of `baseImages` whose base image is not of one of the `requiredClasses`, or a
+// single entry if the claim has no base image to check.
+func gradeBaseImages(requiredClasses []string, baseImages map[string]baseimage.Classification) []string {
+	if len(requiredClasses) == 0 {
+		return nil
+	}
+	if len(baseImages) == 0 {
+		return []string{"the claim has no base image classes"}
+	}
+	var fail []string
+	for container, classification := range baseImages {
+		if !containsString(requiredClasses, classification.Class) {
+			fail = append(fail, fmt.Sprintf("%s: %s", container, classification.String()))
+		}
+	}
+	sort.Strings(fail)
+	return fail
+}
+
+// getBaseImages returns the classes of the base images of the containers under test in the raw results of a claim.
+func getBaseImages(rawResults map[string]interface{}) (map[string]baseimage.Classification, error) {
+	rawBaseImages, ok := rawResults[baseImagesKey]
+	if !ok {
+		return nil, nil
+	}
+	baseImages := map[string]baseimage.Classification{}
+	// The raw results are generic, so the base images are converted by way of JSON.
+	jsonBytes, err := json.Marshal(rawBaseImages)
+	if err == nil {
+		err = json.Unmarshal(jsonBytes, &baseImages)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("the base images of the claim are not of the expected type: %s", err)
+	}
+	return baseImages, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
 func processTestResults(results interface{}) (bool, error) {
 	pass := false
 	var resultsTyped []interface{}
@@ -187,6 +249,11 @@ func validatePolicy(policyObj *Policy) error {
 			return fmt.Errorf("duplicate grade name %s in policy", grade.GradeName)
 		}
 		gradeNames[grade.GradeName] = true
+		for _, class := range grade.RequiredBaseImageClasses {
+			if !baseimage.IsClass(class) {
+				return fmt.Errorf("unknown base image class %s in grade %s", class, grade.GradeName)
+			}
+		}
 
 		grade = grade.NextGrade
 	}
@@ -32,6 +32,11 @@ const (
 	goodPolicy   = testDataPath + "policy-good.json"
 	badPolicy    = testDataPath + "policy-duplicate-grade.json"
 	outPath      = testDataPath + "out.json"
+
+	baseImagesClaim    = testDataPath + "claim-base-images.json"
+	baseImagesPolicy   = testDataPath + "policy-base-images.json"
+	unknownClassPolicy = testDataPath + "policy-unknown-class.json"
+	baseImagesOutPath  = testDataPath + "out-base-images.json"
 )
 
 var (
@@ -56,6 +61,26 @@ func TestGenerateGrade_ErrorInput(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestGenerateGrade_BaseImages(t *testing.T) {
+	err := GenerateGrade(baseImagesClaim, baseImagesPolicy, testOutPath)
+	assert.Nil(t, err)
+	assertFilesMatch(t, baseImagesOutPath, testOutPath)
+
+	// A claim without base images fails the grades which require a class.
+	err = GenerateGrade(goodClaim, baseImagesPolicy, testOutPath)
+	assert.Nil(t, err)
+	var grades []GradeResult
+	assert.Nil(t, unmarshalFromFile(testOutPath, &grades))
+	if assert.Len(t, grades, 3) {
+		assert.True(t, grades[0].Propose)
+		assert.False(t, grades[1].Propose)
+		assert.Equal(t, []string{"the claim has no base image classes"}, grades[1].BaseImageFail)
+	}
+
+	err = GenerateGrade(baseImagesClaim, unknownClassPolicy, testOutPath)
+	assert.NotNil(t, err)
+}
+
 func assertFilesMatch(t *testing.T, pathA, pathB string) {
 	command := exec.Command("cmp", "-s", pathA, pathB)
 	err := command.Run()
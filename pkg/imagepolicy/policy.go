@@ -0,0 +1,240 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package imagepolicy
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/test-network-function/test-network-function/pkg/podpolicy"
+)
+
+// IDs of the checks flagging how images are referenced.
+const (
+	ReferenceCheckID  = "IMAGE_REFERENCE"
+	LatestTagCheckID  = "LATEST_TAG"
+	DigestCheckID     = "DIGEST_PINNING"
+	RegistryCheckID   = "ALLOWED_REGISTRY"
+	PullPolicyCheckID = "PULL_POLICY"
+	DriftCheckID      = "IMAGE_DRIFT"
+)
+
+const (
+	// PullAlways is the imagePullPolicy which pulls the image each time a container starts.
+	PullAlways = "Always"
+
+	registryWildcardPrefix = "*."
+)
+
+// Policy states how the images of the containers under test must be referenced.
+type Policy struct {
+	// RequireDigest requires each image to be pinned by digest.
+	RequireDigest bool
+	// AllowedRegistries are the registries, such as `quay.io`, or registry paths, such as `quay.io/org`, which images may
+	// be pulled from.  A registry given as `*.example.com` allows each subdomain of example.com, on any port unless the
+	// pattern gives one, as in `*.example.com:5000`.  When empty, every registry is allowed.
+	AllowedRegistries []string
+}
+
+// Container is a container or an init container, along with the image it runs.
+type Container struct {
+	// Pod is the namespace and name of the pod of the container, such as `tnf/test-0`.
+	Pod string
+	// Name is the name of the container.
+	Name string
+	// Image is the image reference of the container spec.
+	Image string
+	// ImagePullPolicy is the imagePullPolicy of the container spec.
+	ImagePullPolicy string
+	// ImageID is the image the container runs, as reported in the pod status, or empty if the container has not
+	// started.
+	ImageID string
+}
+
+// Containers returns the containers and init containers of `pod`, along with the images they run.
+func Containers(pod *podpolicy.Pod) []Container {
+	var containers []Container
+	for _, specs := range [][]podpolicy.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+		for i := range specs {
+			container := Container{
+				Pod:             pod.Metadata.Namespace + pathSeparator + pod.Metadata.Name,
+				Name:            specs[i].Name,
+				Image:           specs[i].Image,
+				ImagePullPolicy: specs[i].ImagePullPolicy,
+			}
+			if status := pod.GetContainerStatus(specs[i].Name); status != nil {
+				container.ImageID = status.ImageID
+			}
+			containers = append(containers, container)
+		}
+	}
+	return containers
+}
+
+// String names the container.
+func (c *Container) String() string {
+	return fmt.Sprintf("pod %s container %s", c.Pod, c.Name)
+}
+
+// Finding is an image reference which does not follow the policy.
+type Finding struct {
+	// Container is the container whose image is flagged.
+	Container *Container
+	// CheckID names the check which flagged the image.
+	CheckID string
+	// Reason states why the image is flagged.
+	Reason string
+}
+
+// String describes the finding.
+func (f *Finding) String() string {
+	return fmt.Sprintf("%s %s: %s", f.Container, f.CheckID, f.Reason)
+}
+
+// Evaluate returns the findings for each of `containers`, in order.  Tags which resolve to different images in
+// different containers are flagged for each of those containers, so all the containers under test should be evaluated
+// together.
+func (p *Policy) Evaluate(containers []Container) []Finding {
+	var findings []Finding
+	references := make([]*Reference, len(containers))
+	// tagDigests are the digests which each reference not pinned by digest resolves to.
+	tagDigests := make(map[string][]string)
+	for i := range containers {
+		reference, err := ParseReference(containers[i].Image)
+		if err != nil {
+			findings = append(findings, Finding{Container: &containers[i], CheckID: ReferenceCheckID, Reason: err.Error()})
+			continue
+		}
+		references[i] = reference
+		if digest := ImageIDDigest(containers[i].ImageID); digest != "" && !reference.Pinned() &&
+			!containsString(tagDigests[reference.String()], digest) {
+			tagDigests[reference.String()] = append(tagDigests[reference.String()], digest)
+		}
+	}
+	for i := range containers {
+		if references[i] == nil {
+			continue
+		}
+		for _, check := range []struct {
+			id     string
+			reason string
+		}{
+			{LatestTagCheckID, p.flagLatestTag(references[i])},
+			{DigestCheckID, p.flagDigest(references[i])},
+			{RegistryCheckID, p.flagRegistry(references[i])},
+			{PullPolicyCheckID, flagPullPolicy(references[i], containers[i].ImagePullPolicy)},
+			{DriftCheckID, flagDrift(references[i], containers[i].ImageID, tagDigests)},
+		} {
+			if check.reason != "" {
+				findings = append(findings, Finding{Container: &containers[i], CheckID: check.id, Reason: check.reason})
+			}
+		}
+	}
+	return findings
+}
+
+// flagLatestTag returns why `reference` uses the latest tag, or an empty string.
+func (p *Policy) flagLatestTag(reference *Reference) string {
+	switch {
+	case reference.Pinned():
+		return ""
+	case reference.Tag == "":
+		return fmt.Sprintf("image %s names no tag, and so uses the %s tag", reference, LatestTag)
+	case reference.Tag == LatestTag:
+		return fmt.Sprintf("image %s uses the %s tag", reference, LatestTag)
+	}
+	return ""
+}
+
+// flagDigest returns why `reference` is not pinned by digest as the policy requires, or an empty string.
+func (p *Policy) flagDigest(reference *Reference) string {
+	if p.RequireDigest && !reference.Pinned() {
+		return fmt.Sprintf("image %s is not pinned by digest", reference)
+	}
+	return ""
+}
+
+// flagRegistry returns why the registry of `reference` is not allowed, or an empty string.
+func (p *Policy) flagRegistry(reference *Reference) string {
+	if len(p.AllowedRegistries) == 0 {
+		return ""
+	}
+	for _, allowed := range p.AllowedRegistries {
+		allowed = strings.TrimSuffix(allowed, pathSeparator)
+		if strings.HasPrefix(allowed, registryWildcardPrefix) {
+			registry := reference.Registry
+			if _, _, err := net.SplitHostPort(allowed); err != nil {
+				registry = registryHost(registry)
+			}
+			if strings.HasSuffix(registry, allowed[1:]) {
+				return ""
+			}
+		} else if reference.Registry == allowed || strings.HasPrefix(reference.Name(), allowed+pathSeparator) {
+			return ""
+		}
+	}
+	return fmt.Sprintf("image %s is not from an allowed registry (%s)", reference,
+		strings.Join(p.AllowedRegistries, ", "))
+}
+
+// registryHost returns `registry` without its port, if it has one.
+func registryHost(registry string) string {
+	if host, _, err := net.SplitHostPort(registry); err == nil {
+		return host
+	}
+	return registry
+}
+
+// flagPullPolicy returns why `pullPolicy` contradicts the tag of `reference`, or an empty string.  An unset pull policy
+// defaults to one which suits the tag.
+func flagPullPolicy(reference *Reference, pullPolicy string) string {
+	if reference.Floating() && pullPolicy != "" && pullPolicy != PullAlways {
+		return fmt.Sprintf("image %s is expected to change, but imagePullPolicy %s does not pull it again once cached "+
+			"on a node", reference, pullPolicy)
+	}
+	return ""
+}
+
+// flagDrift returns why the image `imageID` run for `reference` may not be the one intended, or an empty string: the
+// tag of the reference resolves to different digests in different containers.  References pinned by digest are left
+// out, as the runtime may report the digest of the platform manifest of a pinned manifest list, which differs from the
+// pinned digest while naming the same image.
+func flagDrift(reference *Reference, imageID string, tagDigests map[string][]string) string {
+	digest := ImageIDDigest(imageID)
+	if digest == "" || reference.Pinned() {
+		return ""
+	}
+	if digests := tagDigests[reference.String()]; len(digests) > 1 {
+		sorted := append([]string{}, digests...)
+		sort.Strings(sorted)
+		return fmt.Sprintf("image %s runs as %s, while its tag resolves to %s", reference, digest,
+			strings.Join(sorted, ", "))
+	}
+	return ""
+}
+
+// containsString returns true if `values` contains `value`.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package imagepolicy_test
+
+import (
+	"io/ioutil"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/imagepolicy"
+	"github.com/test-network-function/test-network-function/pkg/podpolicy"
+)
+
+func loadContainers(t *testing.T, name string) []imagepolicy.Container {
+	contents, err := ioutil.ReadFile(path.Join("testdata", name+".json"))
+	assert.Nil(t, err)
+	pod, err := podpolicy.ParsePod(contents)
+	assert.Nil(t, err)
+	return imagepolicy.Containers(pod)
+}
+
+func findingStrings(findings []imagepolicy.Finding) []string {
+	var result []string
+	for i := range findings {
+		result = append(result, findings[i].String())
+	}
+	return result
+}
+
+func TestContainers(t *testing.T) {
+	containers := loadContainers(t, "tagged_pod")
+	if assert.Len(t, containers, 5) {
+		// Init containers come first.
+		assert.Equal(t, imagepolicy.Container{Pod: "tnf/tagged", Name: "setup", Image: "busybox",
+			ImagePullPolicy: "IfNotPresent", ImageID: "docker.io/library/busybox@" + digestA}, containers[0])
+		assert.Equal(t, "pod tnf/tagged container app", containers[1].String())
+		// Containers which have not started have no image ID.
+		assert.Equal(t, "", containers[4].ImageID)
+	}
+}
+
+func TestEvaluateDefaultPolicy(t *testing.T) {
+	policy := &imagepolicy.Policy{}
+	assert.Equal(t, []string{
+		"pod tnf/tagged container setup LATEST_TAG: image docker.io/library/busybox names no tag, and so uses the latest tag",
+		"pod tnf/tagged container setup PULL_POLICY: image docker.io/library/busybox is expected to change, but " +
+			"imagePullPolicy IfNotPresent does not pull it again once cached on a node",
+		"pod tnf/tagged container app IMAGE_DRIFT: image quay.io/testnetworkfunction/app:1.0 runs as " + digestB +
+			", while its tag resolves to " + digestB + ", " + digestD,
+		"pod tnf/tagged container worker IMAGE_DRIFT: image quay.io/testnetworkfunction/app:1.0 runs as " + digestD +
+			", while its tag resolves to " + digestB + ", " + digestD,
+		"pod tnf/tagged container proxy LATEST_TAG: image registry.example.com:5000/proxy:latest uses the latest tag",
+	}, findingStrings(policy.Evaluate(loadContainers(t, "tagged_pod"))))
+
+	// The pinned app runs the digest of its platform manifest, which is not drift.
+	assert.Nil(t, findingStrings(policy.Evaluate(loadContainers(t, "pinned_pod"))))
+}
+
+func TestEvaluateDriftConfigID(t *testing.T) {
+	policy := &imagepolicy.Policy{}
+	assert.Nil(t, findingStrings(policy.Evaluate([]imagepolicy.Container{
+		{Pod: "tnf/app", Name: "app", Image: "quay.io/org/app:1.0", ImageID: "quay.io/org/app@" + digestA},
+		{Pod: "tnf/app", Name: "worker", Image: "quay.io/org/app:1.0", ImageID: "docker://" + digestB},
+		{Pod: "tnf/app", Name: "proxy", Image: "quay.io/org/app:1.0", ImageID: digestC},
+	})))
+}
+
+func TestEvaluateRequireDigest(t *testing.T) {
+	policy := &imagepolicy.Policy{RequireDigest: true}
+	var flagged []string
+	for _, finding := range policy.Evaluate(loadContainers(t, "tagged_pod")) {
+		if finding.CheckID == imagepolicy.DigestCheckID {
+			flagged = append(flagged, finding.Container.Name)
+		}
+	}
+	assert.Equal(t, []string{"setup", "app", "worker", "proxy", "pending"}, flagged)
+}
+
+func TestEvaluateAllowedRegistries(t *testing.T) {
+	testCases := []struct {
+		allowed []string
+		flagged []string
+	}{
+		{nil, nil},
+		{[]string{"quay.io"}, []string{"setup", "proxy"}},
+		{[]string{"quay.io/testnetworkfunction/", "docker.io/library"}, []string{"proxy"}},
+		{[]string{"quay.io/other", "*.example.com:5000"}, []string{"setup", "app", "worker", "pending"}},
+		{[]string{"quay.io/other", "*.example.com"}, []string{"setup", "app", "worker", "pending"}},
+		{[]string{"quay.io/other", "*.example.com:443"}, []string{"setup", "app", "worker", "proxy", "pending"}},
+	}
+	for _, testCase := range testCases {
+		policy := &imagepolicy.Policy{AllowedRegistries: testCase.allowed}
+		var flagged []string
+		for _, finding := range policy.Evaluate(loadContainers(t, "tagged_pod")) {
+			if finding.CheckID == imagepolicy.RegistryCheckID {
+				flagged = append(flagged, finding.Container.Name)
+			}
+		}
+		assert.Equal(t, testCase.flagged, flagged, testCase.allowed)
+	}
+}
+
+func TestEvaluateInvalidReference(t *testing.T) {
+	policy := &imagepolicy.Policy{RequireDigest: true}
+	findings := policy.Evaluate([]imagepolicy.Container{{Pod: "tnf/invalid", Name: "app", Image: "quay.io/org/App"}})
+	if assert.Len(t, findings, 1) {
+		assert.Equal(t, imagepolicy.ReferenceCheckID, findings[0].CheckID)
+		assert.Equal(t, `invalid repository in image reference "quay.io/org/App"`, findings[0].Reason)
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package imagepolicy
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// DefaultRegistry is the registry of the image references which do not name one.
+	DefaultRegistry = "docker.io"
+	// LatestTag is the tag of the image references which name neither a tag nor a digest.
+	LatestTag = "latest"
+
+	defaultRegistryNamespace = "library"
+	localhostRegistry        = "localhost"
+	digestSeparator          = "@"
+	tagSeparator             = ":"
+	pathSeparator            = "/"
+	imageIDSchemeSeparator   = "://"
+)
+
+var (
+	// digestRegex matches a digest, such as `sha256:` followed by the hex encoded hash.
+	digestRegex = regexp.MustCompile(`^[a-z0-9]+(?:[.+_-][a-z0-9]+)*:[0-9a-fA-F]{32,}$`)
+	// tagRegex matches a tag.
+	tagRegex = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]{0,127}$`)
+	// repositoryRegex matches a repository, as path components of lowercase letters, digits and separators.
+	repositoryRegex = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*$`)
+)
+
+// Reference is a parsed image reference, such as `quay.io/org/app:1.0@sha256:...`.
+type Reference struct {
+	// Registry is the host, and optional port, of the registry, defaulting to DefaultRegistry.
+	Registry string
+	// Repository is the path of the image in the registry.
+	Repository string
+	// Tag is empty when the reference names no tag.
+	Tag string
+	// Digest is empty when the reference is not pinned by digest.
+	Digest string
+}
+
+// ParseReference parses the image reference `image`.  References to the default registry are normalized, so that
+// `nginx` is in the `library/nginx` repository of DefaultRegistry.
+func ParseReference(image string) (*Reference, error) {
+	reference := &Reference{}
+	name := image
+	if i := strings.Index(name, digestSeparator); i >= 0 {
+		name, reference.Digest = name[:i], name[i+1:]
+		if !digestRegex.MatchString(reference.Digest) {
+			return nil, fmt.Errorf("invalid digest in image reference %q", image)
+		}
+	}
+	if i := strings.LastIndex(name, tagSeparator); i > strings.LastIndex(name, pathSeparator) {
+		name, reference.Tag = name[:i], name[i+1:]
+		if !tagRegex.MatchString(reference.Tag) {
+			return nil, fmt.Errorf("invalid tag in image reference %q", image)
+		}
+	}
+	components := strings.SplitN(name, pathSeparator, 2)
+	if len(components) == 2 && (strings.ContainsAny(components[0], ".:") || components[0] == localhostRegistry) {
+		reference.Registry, reference.Repository = components[0], components[1]
+	} else {
+		reference.Registry, reference.Repository = DefaultRegistry, name
+		if len(components) == 1 {
+			reference.Repository = defaultRegistryNamespace + pathSeparator + name
+		}
+	}
+	if !repositoryRegex.MatchString(reference.Repository) {
+		return nil, fmt.Errorf("invalid repository in image reference %q", image)
+	}
+	return reference, nil
+}
+
+// Name returns the registry and the repository of the reference, without its tag or digest.
+func (r *Reference) Name() string {
+	return r.Registry + pathSeparator + r.Repository
+}
+
+// String returns the normalized reference.
+func (r *Reference) String() string {
+	result := r.Name()
+	if r.Tag != "" {
+		result += tagSeparator + r.Tag
+	}
+	if r.Digest != "" {
+		result += digestSeparator + r.Digest
+	}
+	return result
+}
+
+// Pinned returns true if the reference names a digest, and so always resolves to the same image.
+func (r *Reference) Pinned() bool {
+	return r.Digest != ""
+}
+
+// Floating returns true if the reference names neither a digest nor a tag other than LatestTag, and so is expected to
+// resolve to a new image with each release.
+func (r *Reference) Floating() bool {
+	return !r.Pinned() && (r.Tag == "" || r.Tag == LatestTag)
+}
+
+// ImageIDDigest returns the repository digest of the image ID reported in the status of a container, such as
+// `docker-pullable://quay.io/org/app@sha256:...`, or an empty string if it names none.  Image IDs such as `sha256:...`
+// or `docker://sha256:...` name the config of the image rather than a manifest, and so yield an empty string.
+func ImageIDDigest(imageID string) string {
+	if i := strings.Index(imageID, imageIDSchemeSeparator); i >= 0 {
+		imageID = imageID[i+len(imageIDSchemeSeparator):]
+	}
+	i := strings.LastIndex(imageID, digestSeparator)
+	if i <= 0 {
+		return ""
+	}
+	if digest := imageID[i+1:]; digestRegex.MatchString(digest) {
+		return digest
+	}
+	return ""
+}
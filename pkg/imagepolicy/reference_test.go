@@ -0,0 +1,94 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package imagepolicy_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/imagepolicy"
+)
+
+var (
+	digestA = "sha256:" + strings.Repeat("a", 64)
+	digestB = "sha256:" + strings.Repeat("b", 64)
+	digestC = "sha256:" + strings.Repeat("c", 64)
+	digestD = "sha256:" + strings.Repeat("d", 64)
+)
+
+func TestParseReference(t *testing.T) {
+	testCases := []struct {
+		image     string
+		reference imagepolicy.Reference
+	}{
+		{"nginx", imagepolicy.Reference{Registry: "docker.io", Repository: "library/nginx"}},
+		{"nginx:1.21", imagepolicy.Reference{Registry: "docker.io", Repository: "library/nginx", Tag: "1.21"}},
+		{"org/app:latest", imagepolicy.Reference{Registry: "docker.io", Repository: "org/app", Tag: "latest"}},
+		{"quay.io/org/team/app:1.0@" + digestA,
+			imagepolicy.Reference{Registry: "quay.io", Repository: "org/team/app", Tag: "1.0", Digest: digestA}},
+		{"localhost/app", imagepolicy.Reference{Registry: "localhost", Repository: "app"}},
+		{"registry.example.com:5000/app", imagepolicy.Reference{Registry: "registry.example.com:5000", Repository: "app"}},
+		{"registry.example.com:5000/app:2", imagepolicy.Reference{Registry: "registry.example.com:5000", Repository: "app",
+			Tag: "2"}},
+	}
+	for _, testCase := range testCases {
+		reference, err := imagepolicy.ParseReference(testCase.image)
+		if assert.Nil(t, err, testCase.image) {
+			assert.Equal(t, testCase.reference, *reference, testCase.image)
+		}
+	}
+
+	for _, image := range []string{"", "quay.io/org/App", "app@sha256:abc", "app:", "app:-tag", "quay.io/"} {
+		_, err := imagepolicy.ParseReference(image)
+		assert.NotNil(t, err, image)
+	}
+}
+
+func TestReference(t *testing.T) {
+	testCases := []struct {
+		image    string
+		name     string
+		pinned   bool
+		floating bool
+	}{
+		{"nginx", "docker.io/library/nginx", false, true},
+		{"quay.io/org/app:latest", "quay.io/org/app", false, true},
+		{"quay.io/org/app:1.0", "quay.io/org/app", false, false},
+		{"quay.io/org/app:latest@" + digestA, "quay.io/org/app", true, false},
+	}
+	for _, testCase := range testCases {
+		reference, err := imagepolicy.ParseReference(testCase.image)
+		assert.Nil(t, err)
+		assert.Equal(t, testCase.name, reference.Name())
+		assert.Equal(t, testCase.pinned, reference.Pinned(), testCase.image)
+		assert.Equal(t, testCase.floating, reference.Floating(), testCase.image)
+	}
+
+	reference, _ := imagepolicy.ParseReference("app:1@" + digestA)
+	assert.Equal(t, "docker.io/library/app:1@"+digestA, reference.String())
+}
+
+func TestImageIDDigest(t *testing.T) {
+	assert.Equal(t, digestA, imagepolicy.ImageIDDigest("quay.io/org/app@"+digestA))
+	assert.Equal(t, digestA, imagepolicy.ImageIDDigest("docker-pullable://quay.io/org/app@"+digestA))
+	// Config IDs name no manifest.
+	assert.Equal(t, "", imagepolicy.ImageIDDigest(digestA))
+	assert.Equal(t, "", imagepolicy.ImageIDDigest("docker://"+digestA))
+	assert.Equal(t, "", imagepolicy.ImageIDDigest(""))
+	assert.Equal(t, "", imagepolicy.ImageIDDigest("docker://quay.io/org/app:1.0"))
+}
@@ -0,0 +1,148 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package podpolicy
+
+import (
+	"fmt"
+)
+
+// Severity ranks how much the violation of a rule weakens the isolation of a pod from its host.
+type Severity string
+
+const (
+	// SeverityHigh rules guard against access to the host, or to the rest of the cluster through it.
+	SeverityHigh Severity = "high"
+	// SeverityMedium rules guard against privileges which only become harmful along with another weakness.
+	SeverityMedium Severity = "medium"
+	// SeverityLow rules enforce practices which reduce the impact of a compromised container.
+	SeverityLow Severity = "low"
+)
+
+const (
+	// SubjectPod is the kind of subject of the rules evaluated against the pod as a whole.
+	SubjectPod = "pod"
+	// SubjectContainer is the kind of subject of the rules evaluated against each container.
+	SubjectContainer = "container"
+	// SubjectInitContainer is the kind of subject of the rules evaluated against each init container.
+	SubjectInitContainer = "init container"
+	// SubjectVolume is the kind of subject of the rules evaluated against each volume.
+	SubjectVolume = "volume"
+)
+
+// Rule is a policy which the spec of a pod must abide by.  Exactly one of its checks is set, which selects whether
+// the rule is evaluated against the pod, against each of its containers and init containers, or against each of its
+// volumes.  A check returns why the rule is violated, or an empty string.
+type Rule struct {
+	// ID names the rule, as in the configured tests.
+	ID string
+	// Severity ranks the rule.
+	Severity Severity
+	// Description states what the rule requires.
+	Description string
+	// Remediation states how to abide by the rule.
+	Remediation string
+
+	checkPod       func(pod *Pod) string
+	checkContainer func(pod *Pod, container *Container) string
+	checkVolume    func(volume *Volume) string
+}
+
+// Subject is the part of a pod which a rule is evaluated against.
+type Subject struct {
+	// Kind is one of SubjectPod, SubjectContainer, SubjectInitContainer and SubjectVolume.
+	Kind string
+	// Name is the name of the container or volume, and empty for the pod itself.
+	Name string
+}
+
+// String returns the kind of the subject, followed by its name if it has one.
+func (s Subject) String() string {
+	if s.Name == "" {
+		return s.Kind
+	}
+	return s.Kind + " " + s.Name
+}
+
+// Finding is the outcome of evaluating a rule against a subject.
+type Finding struct {
+	Rule    *Rule
+	Subject Subject
+	Passed  bool
+	// Reason states why the rule is violated, and is empty if it is not.
+	Reason string
+}
+
+// Describe returns the severity, the reason and the remediation of a violation, or an empty string if the rule is
+// not violated.
+func (f *Finding) Describe() string {
+	if f.Passed {
+		return ""
+	}
+	return fmt.Sprintf("[%s] %s: %s", f.Rule.Severity, f.Reason, f.Rule.Remediation)
+}
+
+// Evaluate evaluates each of `rules` against `pod`, in order, and against each of its containers, init containers and
+// volumes in the order of the spec.
+func Evaluate(pod *Pod, rules []*Rule) []Finding {
+	var findings []Finding
+	record := func(rule *Rule, subject Subject, reason string) {
+		findings = append(findings, Finding{Rule: rule, Subject: subject, Passed: reason == "", Reason: reason})
+	}
+	for _, rule := range rules {
+		switch {
+		case rule.checkPod != nil:
+			record(rule, Subject{Kind: SubjectPod}, rule.checkPod(pod))
+		case rule.checkContainer != nil:
+			for i := range pod.Spec.InitContainers {
+				container := &pod.Spec.InitContainers[i]
+				record(rule, Subject{Kind: SubjectInitContainer, Name: container.Name}, rule.checkContainer(pod, container))
+			}
+			for i := range pod.Spec.Containers {
+				container := &pod.Spec.Containers[i]
+				record(rule, Subject{Kind: SubjectContainer, Name: container.Name}, rule.checkContainer(pod, container))
+			}
+		case rule.checkVolume != nil:
+			for i := range pod.Spec.Volumes {
+				volume := &pod.Spec.Volumes[i]
+				record(rule, Subject{Kind: SubjectVolume, Name: volume.Name}, rule.checkVolume(volume))
+			}
+		}
+	}
+	return findings
+}
+
+// SelectRules returns the rules of `rules` whose ID is among `ids`, in the order of `rules`, along with the IDs which
+// match none of them.
+func SelectRules(rules []*Rule, ids []string) (selected []*Rule, unknown []string) {
+	wanted := make(map[string]bool)
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	for _, rule := range rules {
+		if wanted[rule.ID] {
+			selected = append(selected, rule)
+			delete(wanted, rule.ID)
+		}
+	}
+	for _, id := range ids {
+		if wanted[id] {
+			unknown = append(unknown, id)
+			delete(wanted, id)
+		}
+	}
+	return selected, unknown
+}
@@ -0,0 +1,106 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package podpolicy_test
+
+import (
+	"io/ioutil"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/podpolicy"
+)
+
+func loadPod(t *testing.T, name string) *podpolicy.Pod {
+	contents, err := ioutil.ReadFile(path.Join("testdata", name+".json"))
+	assert.Nil(t, err)
+	pod, err := podpolicy.ParsePod(contents)
+	assert.Nil(t, err)
+	return pod
+}
+
+func TestParsePod(t *testing.T) {
+	pod := loadPod(t, "privileged_pod")
+	assert.Equal(t, "privileged", pod.Metadata.Name)
+	assert.Equal(t, "tnf", pod.Metadata.Namespace)
+	assert.Len(t, pod.Spec.Containers, 2)
+	assert.Len(t, pod.Spec.InitContainers, 1)
+	assert.Len(t, pod.Spec.Volumes, 2)
+
+	_, err := podpolicy.ParsePod([]byte("{"))
+	assert.NotNil(t, err)
+}
+
+func TestEvaluateSubjects(t *testing.T) {
+	pod := loadPod(t, "privileged_pod")
+	rules, _ := podpolicy.SelectRules(podpolicy.HostResourceRules, []string{podpolicy.HostNetworkRuleID,
+		podpolicy.RootRuleID, podpolicy.HostPathRuleID})
+
+	var subjects []string
+	for _, finding := range podpolicy.Evaluate(pod, rules) {
+		subjects = append(subjects, finding.Rule.ID+" "+finding.Subject.String())
+	}
+	// Init containers are evaluated first, as they run first.
+	assert.Equal(t, []string{
+		"HOST_NETWORK_CHECK pod",
+		"HOST_PATH_CHECK volume host-root",
+		"HOST_PATH_CHECK volume scratch",
+		"ROOT_CHECK init container setup",
+		"ROOT_CHECK container app",
+		"ROOT_CHECK container sidecar",
+	}, subjects)
+}
+
+func TestFindingDescribe(t *testing.T) {
+	pod := loadPod(t, "privileged_pod")
+	rules, _ := podpolicy.SelectRules(podpolicy.HostResourceRules, []string{podpolicy.HostIPCRuleID})
+	findings := podpolicy.Evaluate(pod, rules)
+	assert.Len(t, findings, 1)
+	assert.Equal(t, "[high] the pod uses the IPC namespace of the host: remove hostIPC from the pod spec, or set it to false",
+		findings[0].Describe())
+
+	findings = podpolicy.Evaluate(loadPod(t, "compliant_pod"), rules)
+	assert.True(t, findings[0].Passed)
+	assert.Equal(t, "", findings[0].Describe())
+}
+
+func TestSelectRules(t *testing.T) {
+	selected, unknown := podpolicy.SelectRules(podpolicy.HostResourceRules, []string{podpolicy.RootRuleID, "NO_SUCH_CHECK",
+		podpolicy.HostNetworkRuleID, podpolicy.RootRuleID})
+	assert.Len(t, selected, 2)
+	assert.Equal(t, podpolicy.HostNetworkRuleID, selected[0].ID)
+	assert.Equal(t, podpolicy.RootRuleID, selected[1].ID)
+	assert.Equal(t, []string{"NO_SUCH_CHECK"}, unknown)
+
+	selected, unknown = podpolicy.SelectRules(podpolicy.HostResourceRules, nil)
+	assert.Empty(t, selected)
+	assert.Empty(t, unknown)
+}
+
+func TestAutomountsServiceAccountToken(t *testing.T) {
+	enabled, disabled := true, false
+	pod := loadPod(t, "compliant_pod")
+	assert.True(t, pod.AutomountsServiceAccountToken(nil))
+	assert.True(t, pod.AutomountsServiceAccountToken(&enabled))
+	assert.False(t, pod.AutomountsServiceAccountToken(&disabled))
+
+	// The setting of the pod overrides that of its service account.
+	pod.Spec.AutomountServiceAccountToken = &disabled
+	assert.False(t, pod.AutomountsServiceAccountToken(&enabled))
+	pod.Spec.AutomountServiceAccountToken = &enabled
+	assert.True(t, pod.AutomountsServiceAccountToken(&disabled))
+}
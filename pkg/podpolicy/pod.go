@@ -0,0 +1,249 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package podpolicy
+
+import (
+	"encoding/json"
+)
+
+// Pod is the part of a Kubernetes Pod which the rules inspect.
+type Pod struct {
+	Metadata struct {
+		Name        string            `json:"name"`
+		Namespace   string            `json:"namespace"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"metadata"`
+	Spec   PodSpec   `json:"spec"`
+	Status PodStatus `json:"status"`
+}
+
+// PodSpec is the part of the spec of a Pod which the rules inspect.
+type PodSpec struct {
+	ServiceAccountName string `json:"serviceAccountName"`
+	// AutomountServiceAccountToken overrides the setting of the service account when set.
+	AutomountServiceAccountToken *bool               `json:"automountServiceAccountToken"`
+	HostNetwork                  bool                `json:"hostNetwork"`
+	HostPID                      bool                `json:"hostPID"`
+	HostIPC                      bool                `json:"hostIPC"`
+	SecurityContext              *PodSecurityContext `json:"securityContext"`
+	Containers                   []Container         `json:"containers"`
+	InitContainers               []Container         `json:"initContainers"`
+	Volumes                      []Volume            `json:"volumes"`
+}
+
+// PodSecurityContext holds the security settings of a Pod, which apply to each container that does not override them.
+type PodSecurityContext struct {
+	RunAsUser      *int64          `json:"runAsUser"`
+	RunAsNonRoot   *bool           `json:"runAsNonRoot"`
+	SeccompProfile *SeccompProfile `json:"seccompProfile"`
+}
+
+// PodStatus is the part of the status of a Pod which the rules inspect.
+type PodStatus struct {
+	ContainerStatuses     []ContainerStatus `json:"containerStatuses"`
+	InitContainerStatuses []ContainerStatus `json:"initContainerStatuses"`
+}
+
+// ContainerStatus is the status of a container or an init container of a Pod.
+type ContainerStatus struct {
+	Name string `json:"name"`
+	// ImageID is the image the container runs, as resolved by the container runtime, usually pinned by digest.
+	ImageID string `json:"imageID"`
+}
+
+// Container is a container or an init container of a Pod.
+type Container struct {
+	Name            string           `json:"name"`
+	Image           string           `json:"image"`
+	ImagePullPolicy string           `json:"imagePullPolicy"`
+	Command         []string         `json:"command"`
+	Args            []string         `json:"args"`
+	Env             []EnvVar         `json:"env"`
+	EnvFrom         []EnvFromSource  `json:"envFrom"`
+	Ports           []ContainerPort  `json:"ports"`
+	SecurityContext *SecurityContext `json:"securityContext"`
+}
+
+// EnvVar is an environment variable of a container, set either to a literal value or from another object.
+type EnvVar struct {
+	Name      string `json:"name"`
+	Value     string `json:"value"`
+	ValueFrom *struct {
+		ConfigMapKeyRef *KeyRef `json:"configMapKeyRef"`
+		SecretKeyRef    *KeyRef `json:"secretKeyRef"`
+	} `json:"valueFrom"`
+}
+
+// KeyRef references a key of a ConfigMap or a Secret.
+type KeyRef struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+}
+
+// EnvFromSource sets the environment variables of a container from every key of a ConfigMap or a Secret.
+type EnvFromSource struct {
+	Prefix       string  `json:"prefix"`
+	ConfigMapRef *ObjRef `json:"configMapRef"`
+	SecretRef    *ObjRef `json:"secretRef"`
+}
+
+// ObjRef references a ConfigMap or a Secret of the namespace of the pod.
+type ObjRef struct {
+	Name string `json:"name"`
+}
+
+// ContainerPort is a port declared by a container.
+type ContainerPort struct {
+	ContainerPort int    `json:"containerPort"`
+	HostPort      int    `json:"hostPort"`
+	Protocol      string `json:"protocol"`
+}
+
+// SecurityContext holds the security settings of a container.
+type SecurityContext struct {
+	Privileged               *bool           `json:"privileged"`
+	RunAsUser                *int64          `json:"runAsUser"`
+	RunAsNonRoot             *bool           `json:"runAsNonRoot"`
+	AllowPrivilegeEscalation *bool           `json:"allowPrivilegeEscalation"`
+	ReadOnlyRootFilesystem   *bool           `json:"readOnlyRootFilesystem"`
+	Capabilities             *Capabilities   `json:"capabilities"`
+	SeccompProfile           *SeccompProfile `json:"seccompProfile"`
+}
+
+// Capabilities are the Linux capabilities added to and dropped from a container.
+type Capabilities struct {
+	Add  []string `json:"add"`
+	Drop []string `json:"drop"`
+}
+
+// SeccompProfile is the seccomp profile of a container, or of all the containers of a Pod.
+type SeccompProfile struct {
+	// Type is one of Unconfined, RuntimeDefault and Localhost.
+	Type             string `json:"type"`
+	LocalhostProfile string `json:"localhostProfile"`
+}
+
+// Volume is a volume of a Pod.
+type Volume struct {
+	Name     string `json:"name"`
+	HostPath *struct {
+		Path string `json:"path"`
+	} `json:"hostPath"`
+	ConfigMap *ObjRef `json:"configMap"`
+	Projected *struct {
+		Sources []struct {
+			ConfigMap *ObjRef `json:"configMap"`
+		} `json:"sources"`
+	} `json:"projected"`
+}
+
+// ParsePod parses a Pod from its JSON representation.
+func ParsePod(data []byte) (*Pod, error) {
+	pod := &Pod{}
+	if err := json.Unmarshal(data, pod); err != nil {
+		return nil, err
+	}
+	return pod, nil
+}
+
+// runAsUser returns the user the container runs as, as set by the container or else by the pod, or nil if neither
+// sets it, in which case the user of the image is used.
+func (p *Pod) runAsUser(container *Container) *int64 {
+	if container.SecurityContext != nil && container.SecurityContext.RunAsUser != nil {
+		return container.SecurityContext.RunAsUser
+	}
+	if p.Spec.SecurityContext != nil {
+		return p.Spec.SecurityContext.RunAsUser
+	}
+	return nil
+}
+
+// runAsNonRoot returns true if the container, or else the pod, requires the container not to run as root.
+func (p *Pod) runAsNonRoot(container *Container) bool {
+	if container.SecurityContext != nil && container.SecurityContext.RunAsNonRoot != nil {
+		return *container.SecurityContext.RunAsNonRoot
+	}
+	return p.Spec.SecurityContext != nil && p.Spec.SecurityContext.RunAsNonRoot != nil && *p.Spec.SecurityContext.RunAsNonRoot
+}
+
+// seccompProfile returns the seccomp profile of the container, as set by the container or else by the pod, or nil if
+// neither sets it.
+func (p *Pod) seccompProfile(container *Container) *SeccompProfile {
+	if container.SecurityContext != nil && container.SecurityContext.SeccompProfile != nil {
+		return container.SecurityContext.SeccompProfile
+	}
+	if p.Spec.SecurityContext != nil {
+		return p.Spec.SecurityContext.SeccompProfile
+	}
+	return nil
+}
+
+// AutomountsServiceAccountToken returns true unless the pod, or else its service account, whose setting is
+// `serviceAccountAutomount`, sets automountServiceAccountToken to false.
+func (p *Pod) AutomountsServiceAccountToken(serviceAccountAutomount *bool) bool {
+	if p.Spec.AutomountServiceAccountToken != nil {
+		return *p.Spec.AutomountServiceAccountToken
+	}
+	return serviceAccountAutomount == nil || *serviceAccountAutomount
+}
+
+// ConfigMapNames returns the names of the ConfigMaps which the pod references, through environment variables of its
+// containers and init containers or through its volumes, without duplicates and in the order of the spec.
+func (p *Pod) ConfigMapNames() []string {
+	var names []string
+	seen := make(map[string]bool)
+	add := func(ref *ObjRef) {
+		if ref != nil && !seen[ref.Name] {
+			seen[ref.Name] = true
+			names = append(names, ref.Name)
+		}
+	}
+	for _, containers := range [][]Container{p.Spec.InitContainers, p.Spec.Containers} {
+		for i := range containers {
+			for _, env := range containers[i].Env {
+				if env.ValueFrom != nil && env.ValueFrom.ConfigMapKeyRef != nil {
+					add(&ObjRef{Name: env.ValueFrom.ConfigMapKeyRef.Name})
+				}
+			}
+			for _, envFrom := range containers[i].EnvFrom {
+				add(envFrom.ConfigMapRef)
+			}
+		}
+	}
+	for i := range p.Spec.Volumes {
+		add(p.Spec.Volumes[i].ConfigMap)
+		if p.Spec.Volumes[i].Projected != nil {
+			for _, source := range p.Spec.Volumes[i].Projected.Sources {
+				add(source.ConfigMap)
+			}
+		}
+	}
+	return names
+}
+
+// GetContainerStatus returns the status of the container or init container `name`, or nil if the pod has not reported
+// it yet.
+func (p *Pod) GetContainerStatus(name string) *ContainerStatus {
+	for _, statuses := range [][]ContainerStatus{p.Status.InitContainerStatuses, p.Status.ContainerStatuses} {
+		for i := range statuses {
+			if statuses[i].Name == name {
+				return &statuses[i]
+			}
+		}
+	}
+	return nil
+}
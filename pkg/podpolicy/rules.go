@@ -0,0 +1,175 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package podpolicy
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Rule IDs of the host resource rules, as selected by the hostResourceRules setting.
+const (
+	HostNetworkRuleID         = "HOST_NETWORK_CHECK"
+	HostPortRuleID            = "HOST_PORT_CHECK"
+	HostPathRuleID            = "HOST_PATH_CHECK"
+	HostIPCRuleID             = "HOST_IPC_CHECK"
+	HostPIDRuleID             = "HOST_PID_CHECK"
+	CapabilityRuleID          = "CAPABILITY_CHECK"
+	RootRuleID                = "ROOT_CHECK"
+	PrivilegeEscalationRuleID = "PRIVILEGE_ESCALATION"
+)
+
+var (
+	// deniedCapabilities may not be added to a container.  Adding ALL adds them too.
+	deniedCapabilities = []string{"NET_ADMIN", "SYS_ADMIN", "ALL"}
+)
+
+// HostResourceRules keep a pod from accessing the resources of its host: its network, ports, file system, IPC and
+// PID namespaces, and the privileges which lead to them.
+var HostResourceRules = []*Rule{
+	{
+		ID:          HostNetworkRuleID,
+		Severity:    SeverityHigh,
+		Description: "The pod does not use the network namespace of the host.",
+		Remediation: "remove hostNetwork from the pod spec, or set it to false",
+		checkPod: func(pod *Pod) string {
+			if pod.Spec.HostNetwork {
+				return "the pod uses the network namespace of the host"
+			}
+			return ""
+		},
+	},
+	{
+		ID:          HostPortRuleID,
+		Severity:    SeverityMedium,
+		Description: "No container binds a port of the host.",
+		Remediation: "remove hostPort from the container ports, and expose them through a Service instead",
+		checkContainer: func(_ *Pod, container *Container) string {
+			var hostPorts []string
+			for _, port := range container.Ports {
+				if port.HostPort != 0 {
+					hostPorts = append(hostPorts, fmt.Sprint(port.HostPort))
+				}
+			}
+			if len(hostPorts) > 0 {
+				return fmt.Sprintf("the container binds host ports %s", strings.Join(hostPorts, ", "))
+			}
+			return ""
+		},
+	},
+	{
+		ID:          HostPathRuleID,
+		Severity:    SeverityHigh,
+		Description: "No volume mounts a path of the host.",
+		Remediation: "replace hostPath volumes with persistent volumes, config maps, secrets or emptyDir volumes",
+		checkVolume: func(volume *Volume) string {
+			if volume.HostPath != nil {
+				return fmt.Sprintf("the volume mounts host path %s", volume.HostPath.Path)
+			}
+			return ""
+		},
+	},
+	{
+		ID:          HostIPCRuleID,
+		Severity:    SeverityHigh,
+		Description: "The pod does not use the IPC namespace of the host.",
+		Remediation: "remove hostIPC from the pod spec, or set it to false",
+		checkPod: func(pod *Pod) string {
+			if pod.Spec.HostIPC {
+				return "the pod uses the IPC namespace of the host"
+			}
+			return ""
+		},
+	},
+	{
+		ID:          HostPIDRuleID,
+		Severity:    SeverityHigh,
+		Description: "The pod does not use the PID namespace of the host.",
+		Remediation: "remove hostPID from the pod spec, or set it to false",
+		checkPod: func(pod *Pod) string {
+			if pod.Spec.HostPID {
+				return "the pod uses the PID namespace of the host"
+			}
+			return ""
+		},
+	},
+	{
+		ID:          CapabilityRuleID,
+		Severity:    SeverityHigh,
+		Description: "No container is granted the NET_ADMIN or SYS_ADMIN capabilities.",
+		Remediation: "remove NET_ADMIN, SYS_ADMIN and ALL from securityContext.capabilities.add",
+		checkContainer: func(_ *Pod, container *Container) string {
+			if container.SecurityContext == nil || container.SecurityContext.Capabilities == nil {
+				return ""
+			}
+			var denied []string
+			for _, capability := range container.SecurityContext.Capabilities.Add {
+				if name := normalizeCapability(capability); contains(deniedCapabilities, name) {
+					denied = append(denied, name)
+				}
+			}
+			if len(denied) > 0 {
+				return fmt.Sprintf("the container adds capabilities %s", strings.Join(denied, ", "))
+			}
+			return ""
+		},
+	},
+	{
+		ID:          RootRuleID,
+		Severity:    SeverityMedium,
+		Description: "No container runs as root.",
+		Remediation: "set securityContext.runAsUser to a non-zero user, or set securityContext.runAsNonRoot",
+		checkContainer: func(pod *Pod, container *Container) string {
+			user := pod.runAsUser(container)
+			switch {
+			case user != nil && *user == 0:
+				return "the container runs as root"
+			case user == nil && !pod.runAsNonRoot(container):
+				return "the container may run as root, as neither runAsUser nor runAsNonRoot is set"
+			}
+			return ""
+		},
+	},
+	{
+		ID:          PrivilegeEscalationRuleID,
+		Severity:    SeverityHigh,
+		Description: "No container allows privilege escalation.",
+		Remediation: "remove securityContext.allowPrivilegeEscalation, or set it to false",
+		checkContainer: func(_ *Pod, container *Container) string {
+			if container.SecurityContext != nil && container.SecurityContext.AllowPrivilegeEscalation != nil &&
+				*container.SecurityContext.AllowPrivilegeEscalation {
+				return "the container allows privilege escalation"
+			}
+			return ""
+		},
+	},
+}
+
+// normalizeCapability returns the name of a capability in upper case and without its optional CAP_ prefix.
+func normalizeCapability(capability string) string {
+	return strings.TrimPrefix(strings.ToUpper(capability), "CAP_")
+}
+
+// contains returns true if `value` is one of `values`.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
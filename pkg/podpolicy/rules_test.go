@@ -0,0 +1,73 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package podpolicy_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/podpolicy"
+)
+
+// violations returns the reasons of the violations found by the host resource rules, keyed by rule ID and subject.
+func violations(pod *podpolicy.Pod) map[string]string {
+	found := make(map[string]string)
+	for _, finding := range podpolicy.Evaluate(pod, podpolicy.HostResourceRules) {
+		if !finding.Passed {
+			found[finding.Rule.ID+" "+finding.Subject.String()] = finding.Reason
+		}
+	}
+	return found
+}
+
+func TestHostResourceRulesCompliant(t *testing.T) {
+	assert.Empty(t, violations(loadPod(t, "compliant_pod")))
+}
+
+func TestHostResourceRulesPrivileged(t *testing.T) {
+	assert.Equal(t, map[string]string{
+		"HOST_NETWORK_CHECK pod":                "the pod uses the network namespace of the host",
+		"HOST_PORT_CHECK container app":         "the container binds host ports 8080, 53",
+		"HOST_PATH_CHECK volume host-root":      "the volume mounts host path /",
+		"HOST_IPC_CHECK pod":                    "the pod uses the IPC namespace of the host",
+		"HOST_PID_CHECK pod":                    "the pod uses the PID namespace of the host",
+		"CAPABILITY_CHECK init container setup": "the container adds capabilities SYS_ADMIN",
+		"CAPABILITY_CHECK container app":        "the container adds capabilities NET_ADMIN",
+		"ROOT_CHECK init container setup":       "the container runs as root",
+		"ROOT_CHECK container sidecar":          "the container runs as root",
+		"PRIVILEGE_ESCALATION container app":    "the container allows privilege escalation",
+	}, violations(loadPod(t, "privileged_pod")))
+}
+
+func TestRootRuleUnsetUser(t *testing.T) {
+	pod := loadPod(t, "compliant_pod")
+	pod.Spec.SecurityContext = nil
+	assert.Equal(t, map[string]string{
+		"ROOT_CHECK container app": "the container may run as root, as neither runAsUser nor runAsNonRoot is set",
+	}, violations(pod))
+}
+
+func TestHostResourceRulesHaveRemediations(t *testing.T) {
+	ids := make(map[string]bool)
+	for _, rule := range podpolicy.HostResourceRules {
+		assert.NotEmpty(t, rule.Description, rule.ID)
+		assert.NotEmpty(t, rule.Remediation, rule.ID)
+		assert.Contains(t, []podpolicy.Severity{podpolicy.SeverityHigh, podpolicy.SeverityMedium, podpolicy.SeverityLow}, rule.Severity)
+		assert.False(t, ids[rule.ID], "duplicate rule %s", rule.ID)
+		ids[rule.ID] = true
+	}
+}
@@ -0,0 +1,337 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package podpolicy
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	// SCCAnnotation is the annotation with which OpenShift records the SecurityContextConstraints that admitted a pod.
+	SCCAnnotation = "openshift.io/scc"
+
+	// RunAsUser strategies of SecurityContextConstraints, from the most to the least constraining.
+	RunAsUserMustRunAs        = "MustRunAs"
+	RunAsUserMustRunAsRange   = "MustRunAsRange"
+	RunAsUserMustRunAsNonRoot = "MustRunAsNonRoot"
+	RunAsUserRunAsAny         = "RunAsAny"
+
+	// sccWildcard allows any capability or volume type.
+	sccWildcard = "*"
+	// capabilityAll stands for every capability in the capabilities to drop.
+	capabilityAll = "ALL"
+	// volumeHostPath is the volume type of hostPath volumes.
+	volumeHostPath = "hostPath"
+)
+
+var (
+	// DefaultSCCLadder lists the SecurityContextConstraints shipped with OpenShift, from the least to the most
+	// permissive.  The -v2 SCCs, which OpenShift 4.11 introduced, forbid privilege escalation and drop every capability
+	// but NET_BIND_SERVICE, and rank below their original counterparts.
+	DefaultSCCLadder = []string{"restricted-v2", "restricted", "nonroot-v2", "nonroot", "hostnetwork-v2", "hostnetwork",
+		"anyuid", "privileged"}
+
+	// runAsUserRanks orders the RunAsUser strategies from the most to the least constraining.
+	runAsUserRanks = map[string]int{
+		RunAsUserMustRunAs:        0,
+		RunAsUserMustRunAsRange:   1,
+		RunAsUserMustRunAsNonRoot: 2,
+		RunAsUserRunAsAny:         3,
+	}
+)
+
+// SecurityContextConstraints is the part of an OpenShift SecurityContextConstraints object which decides whether a
+// pod spec is admitted.
+type SecurityContextConstraints struct {
+	Metadata struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+	AllowPrivilegedContainer bool `json:"allowPrivilegedContainer"`
+	AllowHostNetwork         bool `json:"allowHostNetwork"`
+	AllowHostPorts           bool `json:"allowHostPorts"`
+	AllowHostPID             bool `json:"allowHostPID"`
+	AllowHostIPC             bool `json:"allowHostIPC"`
+	AllowHostDirVolumePlugin bool `json:"allowHostDirVolumePlugin"`
+	// AllowPrivilegeEscalation defaults to true when unset.
+	AllowPrivilegeEscalation *bool    `json:"allowPrivilegeEscalation"`
+	AllowedCapabilities      []string `json:"allowedCapabilities"`
+	DefaultAddCapabilities   []string `json:"defaultAddCapabilities"`
+	RequiredDropCapabilities []string `json:"requiredDropCapabilities"`
+	Volumes                  []string `json:"volumes"`
+	RunAsUser                struct {
+		Type        string `json:"type"`
+		UID         *int64 `json:"uid"`
+		UIDRangeMin *int64 `json:"uidRangeMin"`
+		UIDRangeMax *int64 `json:"uidRangeMax"`
+	} `json:"runAsUser"`
+}
+
+// ParseSecurityContextConstraints parses SecurityContextConstraints from their JSON representation.
+func ParseSecurityContextConstraints(data []byte) (*SecurityContextConstraints, error) {
+	scc := &SecurityContextConstraints{}
+	if err := json.Unmarshal(data, scc); err != nil {
+		return nil, err
+	}
+	return scc, nil
+}
+
+// Name returns the name of the SecurityContextConstraints.
+func (s *SecurityContextConstraints) Name() string {
+	return s.Metadata.Name
+}
+
+// Rejections returns why the SecurityContextConstraints do not admit `pod`, or nothing if they do.  The UID range of
+// the namespace is not known, so that a user set by a container is only admitted by a MustRunAsRange strategy when it
+// is within the range of the SecurityContextConstraints themselves.
+func (s *SecurityContextConstraints) Rejections(pod *Pod) []string {
+	var reasons []string
+	if pod.Spec.HostNetwork && !s.AllowHostNetwork {
+		reasons = append(reasons, "the pod uses the network namespace of the host")
+	}
+	if pod.Spec.HostPID && !s.AllowHostPID {
+		reasons = append(reasons, "the pod uses the PID namespace of the host")
+	}
+	if pod.Spec.HostIPC && !s.AllowHostIPC {
+		reasons = append(reasons, "the pod uses the IPC namespace of the host")
+	}
+	for i := range pod.Spec.Volumes {
+		volume := &pod.Spec.Volumes[i]
+		if volume.HostPath != nil && (!s.AllowHostDirVolumePlugin || !s.allowsVolume(volumeHostPath)) {
+			reasons = append(reasons, fmt.Sprintf("volume %s mounts the host path %s", volume.Name, volume.HostPath.Path))
+		}
+	}
+	for i := range pod.Spec.InitContainers {
+		container := &pod.Spec.InitContainers[i]
+		reasons = append(reasons, s.containerRejections(pod, container, Subject{Kind: SubjectInitContainer, Name: container.Name})...)
+	}
+	for i := range pod.Spec.Containers {
+		container := &pod.Spec.Containers[i]
+		reasons = append(reasons, s.containerRejections(pod, container, Subject{Kind: SubjectContainer, Name: container.Name})...)
+	}
+	return reasons
+}
+
+// containerRejections returns why the SecurityContextConstraints do not admit `container`, or nothing if they do.
+func (s *SecurityContextConstraints) containerRejections(pod *Pod, container *Container, subject Subject) []string {
+	var reasons []string
+	securityContext := container.SecurityContext
+	if securityContext == nil {
+		securityContext = &SecurityContext{}
+	}
+	if isTrue(securityContext.Privileged) && !s.AllowPrivilegedContainer {
+		reasons = append(reasons, fmt.Sprintf("%s is privileged", subject))
+	}
+	if isTrue(securityContext.AllowPrivilegeEscalation) && !s.allowsPrivilegeEscalation() {
+		reasons = append(reasons, fmt.Sprintf("%s allows privilege escalation", subject))
+	}
+	for _, port := range container.Ports {
+		if port.HostPort != 0 && !s.AllowHostPorts {
+			reasons = append(reasons, fmt.Sprintf("%s binds host port %d", subject, port.HostPort))
+		}
+	}
+	if securityContext.Capabilities != nil {
+		for _, capability := range securityContext.Capabilities.Add {
+			if !s.allowsCapability(capability) {
+				reasons = append(reasons, fmt.Sprintf("%s adds capability %s", subject, normalizeCapability(capability)))
+			}
+		}
+	}
+	if uid := pod.runAsUser(container); uid != nil && !s.allowsUser(*uid) {
+		reasons = append(reasons, fmt.Sprintf("%s runs as user %d, which the %s strategy does not allow", subject, *uid,
+			s.RunAsUser.Type))
+	}
+	return reasons
+}
+
+// allowsPrivilegeEscalation returns true unless the SecurityContextConstraints forbid privilege escalation.
+func (s *SecurityContextConstraints) allowsPrivilegeEscalation() bool {
+	return s.AllowPrivilegeEscalation == nil || *s.AllowPrivilegeEscalation
+}
+
+// allowsCapability returns true if a container may add `capability`.
+func (s *SecurityContextConstraints) allowsCapability(capability string) bool {
+	capability = normalizeCapability(capability)
+	for _, allowed := range append(append([]string{}, s.AllowedCapabilities...), s.DefaultAddCapabilities...) {
+		if allowed == sccWildcard || normalizeCapability(allowed) == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// requiresDropping returns true if containers must drop `capability`.
+func (s *SecurityContextConstraints) requiresDropping(capability string) bool {
+	capability = normalizeCapability(capability)
+	for _, required := range s.RequiredDropCapabilities {
+		if normalizeCapability(required) == capabilityAll || normalizeCapability(required) == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsVolume returns true if pods may use volumes of type `volumeType`.
+func (s *SecurityContextConstraints) allowsVolume(volumeType string) bool {
+	return contains(s.Volumes, sccWildcard) || contains(s.Volumes, volumeType)
+}
+
+// allowsUser returns true if a container may set its user to `uid`.
+func (s *SecurityContextConstraints) allowsUser(uid int64) bool {
+	switch s.RunAsUser.Type {
+	case RunAsUserRunAsAny:
+		return true
+	case RunAsUserMustRunAsNonRoot:
+		return uid != 0
+	case RunAsUserMustRunAs:
+		return s.RunAsUser.UID != nil && *s.RunAsUser.UID == uid
+	default:
+		return s.RunAsUser.UIDRangeMin != nil && s.RunAsUser.UIDRangeMax != nil &&
+			*s.RunAsUser.UIDRangeMin <= uid && uid <= *s.RunAsUser.UIDRangeMax
+	}
+}
+
+// runAsUserRank ranks the RunAsUser strategy of the SecurityContextConstraints.  Unknown strategies rank as RunAsAny.
+func (s *SecurityContextConstraints) runAsUserRank() int {
+	if rank, ok := runAsUserRanks[s.RunAsUser.Type]; ok {
+		return rank
+	}
+	return runAsUserRanks[RunAsUserRunAsAny]
+}
+
+// Covers returns true if the SecurityContextConstraints are at least as permissive as `other`, that is if they
+// grant every privilege that `other` grants and require dropping no capability that `other` lets containers keep.  A
+// wildcard capability or volume type is only covered by a wildcard.
+func (s *SecurityContextConstraints) Covers(other *SecurityContextConstraints) bool {
+	grants := []struct{ own, other bool }{
+		{s.AllowPrivilegedContainer, other.AllowPrivilegedContainer},
+		{s.AllowHostNetwork, other.AllowHostNetwork},
+		{s.AllowHostPorts, other.AllowHostPorts},
+		{s.AllowHostPID, other.AllowHostPID},
+		{s.AllowHostIPC, other.AllowHostIPC},
+		{s.AllowHostDirVolumePlugin, other.AllowHostDirVolumePlugin},
+		{s.allowsPrivilegeEscalation(), other.allowsPrivilegeEscalation()},
+	}
+	for _, grant := range grants {
+		if grant.other && !grant.own {
+			return false
+		}
+	}
+	for _, capability := range append(append([]string{}, other.AllowedCapabilities...), other.DefaultAddCapabilities...) {
+		if !s.allowsCapability(capability) {
+			return false
+		}
+	}
+	for _, capability := range s.RequiredDropCapabilities {
+		if !other.requiresDropping(capability) {
+			return false
+		}
+	}
+	for _, volumeType := range other.Volumes {
+		if !s.allowsVolume(volumeType) {
+			return false
+		}
+	}
+	return s.runAsUserRank() >= other.runAsUserRank()
+}
+
+// SCCRejection records why SecurityContextConstraints do not admit a pod.
+type SCCRejection struct {
+	SCC     string
+	Reasons []string
+}
+
+// SCCAssessment compares the SecurityContextConstraints that admitted a pod with the least permissive
+// SecurityContextConstraints of a ladder which admit its spec.
+type SCCAssessment struct {
+	// Assigned names the SecurityContextConstraints that admitted the pod.
+	Assigned string
+	// Level names the least permissive SecurityContextConstraints of the ladder which are at least as permissive as
+	// Assigned, and is empty if none are.
+	Level string
+	// Required names the least permissive SecurityContextConstraints of the ladder which admit the pod spec, and is
+	// empty if none do.
+	Required string
+	// Rejections records why each of the SecurityContextConstraints of the ladder below Required do not admit the pod.
+	Rejections []SCCRejection
+
+	levelRank    int
+	requiredRank int
+}
+
+// AssessSCC classifies the SecurityContextConstraints `assigned` that admitted `pod` against `ladder`, ordered from
+// the least to the most permissive, and finds the least permissive SecurityContextConstraints of the ladder which
+// admit the pod spec.  SecurityContextConstraints of the ladder are classified by name, and others as the first of the
+// ladder which covers them.
+func AssessSCC(pod *Pod, assigned *SecurityContextConstraints, ladder []*SecurityContextConstraints) *SCCAssessment {
+	assessment := &SCCAssessment{Assigned: assigned.Name(), levelRank: len(ladder), requiredRank: len(ladder)}
+	for rank, scc := range ladder {
+		if scc.Name() == assigned.Name() {
+			assessment.levelRank = rank
+			break
+		}
+	}
+	if assessment.levelRank == len(ladder) {
+		for rank, scc := range ladder {
+			if scc.Covers(assigned) {
+				assessment.levelRank = rank
+				break
+			}
+		}
+	}
+	if assessment.levelRank < len(ladder) {
+		assessment.Level = ladder[assessment.levelRank].Name()
+	}
+	for rank, scc := range ladder {
+		reasons := scc.Rejections(pod)
+		if len(reasons) == 0 {
+			assessment.Required = scc.Name()
+			assessment.requiredRank = rank
+			break
+		}
+		assessment.Rejections = append(assessment.Rejections, SCCRejection{SCC: scc.Name(), Reasons: reasons})
+	}
+	return assessment
+}
+
+// Passed returns true unless the pod was admitted by SecurityContextConstraints more permissive than its spec needs.
+func (a *SCCAssessment) Passed() bool {
+	return a.levelRank <= a.requiredRank
+}
+
+// Describe states which SecurityContextConstraints the pod runs under, which it needs, and why the less permissive
+// ones of the ladder do not admit it.
+func (a *SCCAssessment) Describe() string {
+	level := a.Level
+	if level == "" {
+		level = "beyond the ladder"
+	}
+	required := a.Required
+	if required == "" {
+		required = "no SCC of the ladder"
+	}
+	description := fmt.Sprintf("the pod runs under SCC %s (classified as %s), and %s admits its spec", a.Assigned, level, required)
+	if !a.Passed() {
+		description = fmt.Sprintf("the pod runs under SCC %s (classified as %s), which is more permissive than needed as %s admits its spec",
+			a.Assigned, level, required)
+	}
+	for _, rejection := range a.Rejections {
+		description += fmt.Sprintf("; %s rejects it as %s", rejection.SCC, strings.Join(rejection.Reasons, ", "))
+	}
+	return description
+}
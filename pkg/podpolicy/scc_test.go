@@ -0,0 +1,199 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package podpolicy_test
+
+import (
+	"io/ioutil"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/podpolicy"
+)
+
+func loadSCC(t *testing.T, name string) *podpolicy.SecurityContextConstraints {
+	contents, err := ioutil.ReadFile(path.Join("testdata", "scc", name+".json"))
+	assert.Nil(t, err)
+	scc, err := podpolicy.ParseSecurityContextConstraints(contents)
+	assert.Nil(t, err)
+	return scc
+}
+
+func loadSCCLadder(t *testing.T) []*podpolicy.SecurityContextConstraints {
+	var ladder []*podpolicy.SecurityContextConstraints
+	for _, name := range podpolicy.DefaultSCCLadder {
+		ladder = append(ladder, loadSCC(t, name))
+	}
+	return ladder
+}
+
+// loadRestrictedPod loads the hardened pod, without the capabilities it adds back which the restricted SCC does not
+// allow.
+func loadRestrictedPod(t *testing.T) *podpolicy.Pod {
+	pod := loadPod(t, "hardened_pod")
+	for i := range pod.Spec.Containers {
+		pod.Spec.Containers[i].SecurityContext.Capabilities.Add = nil
+	}
+	return pod
+}
+
+func TestParseSecurityContextConstraints(t *testing.T) {
+	scc := loadSCC(t, "privileged")
+	assert.Equal(t, "privileged", scc.Name())
+	assert.True(t, scc.AllowPrivilegedContainer)
+	assert.Equal(t, []string{"*"}, scc.AllowedCapabilities)
+	assert.Equal(t, podpolicy.RunAsUserRunAsAny, scc.RunAsUser.Type)
+
+	_, err := podpolicy.ParseSecurityContextConstraints([]byte(`{"volumes": 1}`))
+	assert.NotNil(t, err)
+}
+
+func TestRejections(t *testing.T) {
+	assert.Empty(t, loadSCC(t, "restricted").Rejections(loadRestrictedPod(t)))
+	assert.Equal(t, []string{
+		"init container setup runs as user 1000, which the MustRunAsRange strategy does not allow",
+		"container app adds capability NET_BIND_SERVICE",
+	}, loadSCC(t, "restricted").Rejections(loadPod(t, "compliant_pod")))
+	assert.Equal(t, []string{
+		"container app adds capability NET_BIND_SERVICE",
+	}, loadSCC(t, "nonroot").Rejections(loadPod(t, "compliant_pod")))
+	assert.Equal(t, []string{
+		"the pod uses the network namespace of the host",
+		"the pod uses the PID namespace of the host",
+		"the pod uses the IPC namespace of the host",
+		"volume host-root mounts the host path /",
+		"init container setup adds capability SYS_ADMIN",
+		"container app binds host port 8080",
+		"container app binds host port 53",
+		"container app adds capability NET_ADMIN",
+		"container app adds capability NET_RAW",
+	}, loadSCC(t, "anyuid").Rejections(loadPod(t, "privileged_pod")))
+	assert.Empty(t, loadSCC(t, "privileged").Rejections(loadPod(t, "privileged_pod")))
+}
+
+func TestRejectionsRunAsUser(t *testing.T) {
+	pod := loadRestrictedPod(t)
+	root := int64(0)
+	pod.Spec.SecurityContext.RunAsUser = &root
+	assert.Len(t, loadSCC(t, "nonroot").Rejections(pod), 2)
+	assert.Empty(t, loadSCC(t, "anyuid").Rejections(pod))
+
+	scc := loadSCC(t, "restricted")
+	min, max := int64(1000), int64(2000)
+	scc.RunAsUser.UIDRangeMin, scc.RunAsUser.UIDRangeMax = &min, &max
+	user := int64(1500)
+	pod.Spec.SecurityContext.RunAsUser = &user
+	assert.Empty(t, scc.Rejections(pod))
+
+	scc.RunAsUser.Type = podpolicy.RunAsUserMustRunAs
+	scc.RunAsUser.UID = &min
+	assert.Len(t, scc.Rejections(pod), 2)
+}
+
+func TestCovers(t *testing.T) {
+	ladder := loadSCCLadder(t)
+	for i, scc := range ladder {
+		assert.True(t, scc.Covers(scc), scc.Name())
+		assert.True(t, ladder[len(ladder)-1].Covers(scc), scc.Name())
+		if i > 0 {
+			assert.False(t, ladder[0].Covers(scc), scc.Name())
+		}
+	}
+	assert.False(t, loadSCC(t, "anyuid").Covers(loadSCC(t, "hostnetwork")))
+	assert.False(t, loadSCC(t, "hostnetwork").Covers(loadSCC(t, "anyuid")))
+	assert.False(t, loadSCC(t, "hostnetwork").Covers(loadSCC(t, "cnf-custom")))
+	assert.True(t, loadSCC(t, "privileged").Covers(loadSCC(t, "cnf-custom")))
+}
+
+func TestCoversRequiredDropCapabilities(t *testing.T) {
+	restricted, restrictedV2 := loadSCC(t, "restricted"), loadSCC(t, "restricted-v2")
+	assert.False(t, restricted.Covers(restrictedV2))
+	assert.False(t, restrictedV2.Covers(restricted))
+
+	// SCCs which let containers keep capabilities that restricted-v2 drops are not covered by it.
+	loosened := loadSCC(t, "restricted-v2")
+	loosened.RequiredDropCapabilities = []string{"KILL"}
+	assert.False(t, restrictedV2.Covers(loosened))
+	assert.True(t, loosened.Covers(restrictedV2))
+}
+
+func TestAssessSCC(t *testing.T) {
+	ladder := loadSCCLadder(t)
+
+	assessment := podpolicy.AssessSCC(loadRestrictedPod(t), loadSCC(t, "restricted-v2"), ladder)
+	assert.True(t, assessment.Passed())
+	assert.Equal(t, "restricted-v2", assessment.Level)
+	assert.Equal(t, "restricted-v2", assessment.Required)
+	assert.Empty(t, assessment.Rejections)
+	assert.Equal(t, "the pod runs under SCC restricted-v2 (classified as restricted-v2), and restricted-v2 admits its spec",
+		assessment.Describe())
+
+	assessment = podpolicy.AssessSCC(loadRestrictedPod(t), loadSCC(t, "restricted"), ladder)
+	assert.False(t, assessment.Passed())
+	assert.Equal(t, "restricted-v2", assessment.Required)
+
+	assessment = podpolicy.AssessSCC(loadRestrictedPod(t), loadSCC(t, "anyuid"), ladder)
+	assert.False(t, assessment.Passed())
+	assert.Equal(t, "the pod runs under SCC anyuid (classified as anyuid), which is more permissive than needed as "+
+		"restricted-v2 admits its spec", assessment.Describe())
+
+	assessment = podpolicy.AssessSCC(loadPod(t, "privileged_pod"), loadSCC(t, "privileged"), ladder)
+	assert.True(t, assessment.Passed())
+	assert.Equal(t, "privileged", assessment.Required)
+	assert.Len(t, assessment.Rejections, len(ladder)-1)
+}
+
+func TestAssessSCCRestrictedV2(t *testing.T) {
+	// restricted-v2 admits pods which add NET_BIND_SERVICE back, which only privileged of the original SCCs allows.
+	pod := loadPod(t, "hardened_pod")
+	restrictedV2 := loadSCC(t, "restricted-v2")
+	assert.Empty(t, restrictedV2.Rejections(pod))
+
+	assessment := podpolicy.AssessSCC(pod, restrictedV2, loadSCCLadder(t))
+	assert.True(t, assessment.Passed())
+	assert.Equal(t, "restricted-v2", assessment.Level)
+	assert.Equal(t, "restricted-v2", assessment.Required)
+
+	// A copy of restricted-v2 under another name is classified as restricted-v2 rather than as privileged.
+	custom := loadSCC(t, "restricted-v2")
+	custom.Metadata.Name = "cnf-restricted"
+	assessment = podpolicy.AssessSCC(pod, custom, loadSCCLadder(t))
+	assert.Equal(t, "restricted-v2", assessment.Level)
+	assert.True(t, assessment.Passed())
+}
+
+func TestAssessSCCCustom(t *testing.T) {
+	ladder := loadSCCLadder(t)
+
+	// A custom SCC is classified as the least permissive SCC of the ladder which covers it.
+	assessment := podpolicy.AssessSCC(loadPod(t, "compliant_pod"), loadSCC(t, "cnf-custom"), ladder)
+	assert.Equal(t, "cnf-custom", assessment.Assigned)
+	assert.Equal(t, "privileged", assessment.Level)
+	assert.Equal(t, "nonroot-v2", assessment.Required)
+	assert.False(t, assessment.Passed())
+
+	// A custom SCC which is not covered by the ladder is beyond it, and only passes when no SCC of the ladder admits
+	// the pod.
+	withoutPrivileged := ladder[:len(ladder)-1]
+	assessment = podpolicy.AssessSCC(loadRestrictedPod(t), loadSCC(t, "cnf-custom"), withoutPrivileged)
+	assert.Equal(t, "", assessment.Level)
+	assert.False(t, assessment.Passed())
+	assessment = podpolicy.AssessSCC(loadPod(t, "privileged_pod"), loadSCC(t, "cnf-custom"), withoutPrivileged)
+	assert.Equal(t, "", assessment.Required)
+	assert.True(t, assessment.Passed())
+	assert.Contains(t, assessment.Describe(), "classified as beyond the ladder), and no SCC of the ladder admits its spec")
+}
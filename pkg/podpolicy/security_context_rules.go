@@ -0,0 +1,146 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package podpolicy
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Rule IDs of the security context rules.
+const (
+	ReadOnlyRootFilesystemRuleID = "READ_ONLY_ROOT_FILESYSTEM"
+	NoPrivilegeEscalationRuleID  = "NO_PRIVILEGE_ESCALATION"
+	RunAsNonRootRuleID           = "RUN_AS_NON_ROOT"
+	SeccompProfileRuleID         = "SECCOMP_PROFILE"
+	DropAllCapabilitiesRuleID    = "DROP_ALL_CAPABILITIES"
+
+	// SeccompProfileRuntimeDefault and SeccompProfileLocalhost are the seccomp profile types which confine a container.
+	SeccompProfileRuntimeDefault = "RuntimeDefault"
+	SeccompProfileLocalhost      = "Localhost"
+
+	// allCapabilities stands for every capability in the capabilities added to or dropped from a container.
+	allCapabilities = "ALL"
+)
+
+var (
+	// DefaultAllowedCapabilities may always be added to a container once ALL are dropped, as the restricted Pod Security
+	// Standard allows.
+	DefaultAllowedCapabilities = []string{"NET_BIND_SERVICE"}
+)
+
+// SecurityContextRules harden the security context of each container and init container: a read-only root file
+// system, no privilege escalation, a non-root user, a confining seccomp profile, and every capability dropped except
+// those of DefaultAllowedCapabilities and `allowedCapabilities`, which may be added back.
+func SecurityContextRules(allowedCapabilities []string) []*Rule {
+	allowed := make([]string, 0, len(DefaultAllowedCapabilities)+len(allowedCapabilities))
+	for _, capability := range append(append([]string{}, DefaultAllowedCapabilities...), allowedCapabilities...) {
+		allowed = append(allowed, normalizeCapability(capability))
+	}
+	return []*Rule{
+		{
+			ID:          ReadOnlyRootFilesystemRuleID,
+			Severity:    SeverityLow,
+			Description: "Each container has a read-only root file system.",
+			Remediation: "set securityContext.readOnlyRootFilesystem to true, and mount volumes where the container writes",
+			checkContainer: func(_ *Pod, container *Container) string {
+				if container.SecurityContext == nil || !isTrue(container.SecurityContext.ReadOnlyRootFilesystem) {
+					return "the root file system of the container is writable"
+				}
+				return ""
+			},
+		},
+		{
+			ID:          NoPrivilegeEscalationRuleID,
+			Severity:    SeverityMedium,
+			Description: "Each container forbids privilege escalation.",
+			Remediation: "set securityContext.allowPrivilegeEscalation to false",
+			checkContainer: func(_ *Pod, container *Container) string {
+				if container.SecurityContext == nil || container.SecurityContext.AllowPrivilegeEscalation == nil ||
+					*container.SecurityContext.AllowPrivilegeEscalation {
+					return "the container does not set allowPrivilegeEscalation to false"
+				}
+				return ""
+			},
+		},
+		{
+			ID:          RunAsNonRootRuleID,
+			Severity:    SeverityMedium,
+			Description: "Each container is required to run as a non-root user.",
+			Remediation: "set securityContext.runAsNonRoot to true in the container or the pod",
+			checkContainer: func(pod *Pod, container *Container) string {
+				if !pod.runAsNonRoot(container) {
+					return "neither the container nor the pod sets runAsNonRoot to true"
+				}
+				return ""
+			},
+		},
+		{
+			ID:          SeccompProfileRuleID,
+			Severity:    SeverityMedium,
+			Description: "Each container is confined by the RuntimeDefault or a Localhost seccomp profile.",
+			Remediation: "set securityContext.seccompProfile.type to RuntimeDefault or Localhost in the container or the pod",
+			checkContainer: func(pod *Pod, container *Container) string {
+				profile := pod.seccompProfile(container)
+				switch {
+				case profile == nil || profile.Type == "":
+					return "no seccomp profile is set"
+				case profile.Type != SeccompProfileRuntimeDefault && profile.Type != SeccompProfileLocalhost:
+					return fmt.Sprintf("the seccomp profile is %s", profile.Type)
+				}
+				return ""
+			},
+		},
+		{
+			ID:          DropAllCapabilitiesRuleID,
+			Severity:    SeverityMedium,
+			Description: "Each container drops all capabilities, and only adds back allowed ones.",
+			Remediation: "add ALL to securityContext.capabilities.drop, and only add back the capabilities the container needs, " +
+				"which must be allowed by allowedCapabilities in the configuration",
+			checkContainer: func(_ *Pod, container *Container) string {
+				var capabilities Capabilities
+				if container.SecurityContext != nil && container.SecurityContext.Capabilities != nil {
+					capabilities = *container.SecurityContext.Capabilities
+				}
+				var reasons []string
+				dropsAll := false
+				for _, capability := range capabilities.Drop {
+					dropsAll = dropsAll || normalizeCapability(capability) == allCapabilities
+				}
+				if !dropsAll {
+					reasons = append(reasons, "the container does not drop ALL capabilities")
+				}
+				var disallowed []string
+				for _, capability := range capabilities.Add {
+					if name := normalizeCapability(capability); !contains(allowed, name) {
+						disallowed = append(disallowed, name)
+					}
+				}
+				if len(disallowed) > 0 {
+					reasons = append(reasons, fmt.Sprintf("the container adds capabilities %s, which are not allowed",
+						strings.Join(disallowed, ", ")))
+				}
+				return strings.Join(reasons, "; ")
+			},
+		},
+	}
+}
+
+// isTrue returns true if `value` is set and true.
+func isTrue(value *bool) bool {
+	return value != nil && *value
+}
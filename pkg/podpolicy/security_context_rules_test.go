@@ -0,0 +1,82 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package podpolicy_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/podpolicy"
+)
+
+// securityContextViolations returns the reasons of the violations found by the security context rules, keyed by rule
+// ID and subject.
+func securityContextViolations(pod *podpolicy.Pod, allowedCapabilities []string) map[string]string {
+	found := make(map[string]string)
+	for _, finding := range podpolicy.Evaluate(pod, podpolicy.SecurityContextRules(allowedCapabilities)) {
+		if !finding.Passed {
+			found[finding.Rule.ID+" "+finding.Subject.String()] = finding.Reason
+		}
+	}
+	return found
+}
+
+func TestSecurityContextRulesHardened(t *testing.T) {
+	assert.Empty(t, securityContextViolations(loadPod(t, "hardened_pod"), nil))
+}
+
+func TestSecurityContextRulesCompliantWithHostResources(t *testing.T) {
+	// Abiding by the host resource rules does not make a container hardened.
+	assert.Equal(t, map[string]string{
+		"READ_ONLY_ROOT_FILESYSTEM init container setup": "the root file system of the container is writable",
+		"READ_ONLY_ROOT_FILESYSTEM container app":        "the root file system of the container is writable",
+		"NO_PRIVILEGE_ESCALATION init container setup":   "the container does not set allowPrivilegeEscalation to false",
+		"SECCOMP_PROFILE init container setup":           "no seccomp profile is set",
+		"SECCOMP_PROFILE container app":                  "no seccomp profile is set",
+		"DROP_ALL_CAPABILITIES init container setup":     "the container does not drop ALL capabilities",
+	}, securityContextViolations(loadPod(t, "compliant_pod"), nil))
+}
+
+func TestSecurityContextRulesPrivileged(t *testing.T) {
+	violations := securityContextViolations(loadPod(t, "privileged_pod"), nil)
+	assert.Equal(t, "neither the container nor the pod sets runAsNonRoot to true", violations["RUN_AS_NON_ROOT container app"])
+	assert.Equal(t, "the container does not set allowPrivilegeEscalation to false", violations["NO_PRIVILEGE_ESCALATION container app"])
+	assert.Equal(t, "the container does not drop ALL capabilities; the container adds capabilities NET_ADMIN, NET_RAW, which are not allowed",
+		violations["DROP_ALL_CAPABILITIES container app"])
+
+	// Allowed capabilities may be added back, with or without their CAP_ prefix.
+	violations = securityContextViolations(loadPod(t, "privileged_pod"), []string{"CAP_NET_RAW"})
+	assert.Equal(t, "the container does not drop ALL capabilities; the container adds capabilities NET_ADMIN, which are not allowed",
+		violations["DROP_ALL_CAPABILITIES container app"])
+}
+
+func TestSecurityContextRulesSeccompProfile(t *testing.T) {
+	pod := loadPod(t, "hardened_pod")
+	pod.Spec.Containers[0].SecurityContext.SeccompProfile = &podpolicy.SeccompProfile{Type: "Unconfined"}
+	pod.Spec.SecurityContext.SeccompProfile = nil
+	assert.Equal(t, map[string]string{
+		"SECCOMP_PROFILE init container setup": "no seccomp profile is set",
+		"SECCOMP_PROFILE container app":        "the seccomp profile is Unconfined",
+	}, securityContextViolations(pod, nil))
+}
+
+func TestSecurityContextRulesHaveRemediations(t *testing.T) {
+	for _, rule := range podpolicy.SecurityContextRules(nil) {
+		assert.NotEmpty(t, rule.Description, rule.ID)
+		assert.NotEmpty(t, rule.Remediation, rule.ID)
+	}
+}
@@ -0,0 +1,319 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package rbacpolicy
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IDs of the checks flagging dangerous rules.
+const (
+	WildcardCheckID      = "WILDCARD"
+	SecretsCheckID       = "SECRETS_ACCESS"
+	EscalationCheckID    = "ESCALATE_BIND_IMPERSONATE"
+	NodeAccessCheckID    = "NODE_ACCESS"
+	PodExecAccessCheckID = "POD_EXEC_ACCESS"
+)
+
+const (
+	wildcard     = "*"
+	coreAPIGroup = ""
+
+	secretsResource      = "secrets"
+	nodesResource        = "nodes"
+	podExecResource      = "pods/exec"
+	subresourceSeparator = "/"
+
+	clusterWideScope       = "cluster-wide"
+	namespaceScopeTemplate = "in namespace %s"
+)
+
+var (
+	// escalationVerbs let a subject grant itself, or act with, permissions it does not have.
+	escalationVerbs = []string{"escalate", "bind", "impersonate"}
+)
+
+// Check flags the rules which grant a dangerous permission.
+type Check struct {
+	// ID names the check.
+	ID string
+	// Description states what the check flags.
+	Description string
+	// flag returns why `rule` grants the dangerous permission, or an empty string.
+	flag func(rule *PolicyRule) string
+}
+
+// Checks flag wildcards in verbs, resources or apiGroups, access to secrets, the escalate, bind and impersonate verbs,
+// and access to nodes or to pods/exec.
+var Checks = []*Check{
+	{
+		ID:          WildcardCheckID,
+		Description: "The rule does not use wildcards in its verbs, resources or apiGroups.",
+		flag: func(rule *PolicyRule) string {
+			var fields []string
+			for _, field := range []struct {
+				name   string
+				values []string
+			}{{"verbs", rule.Verbs}, {"resources", rule.Resources}, {"apiGroups", rule.APIGroups}} {
+				if contains(field.values, wildcard) {
+					fields = append(fields, field.name)
+				}
+			}
+			if len(fields) > 0 {
+				return fmt.Sprintf("wildcard %s", strings.Join(fields, ", "))
+			}
+			return ""
+		},
+	},
+	{
+		ID:          SecretsCheckID,
+		Description: "The rule does not grant access to secrets.",
+		flag: func(rule *PolicyRule) string {
+			return flagResource(rule, secretsResource)
+		},
+	},
+	{
+		ID:          EscalationCheckID,
+		Description: "The rule does not grant the escalate, bind or impersonate verbs.",
+		flag: func(rule *PolicyRule) string {
+			var verbs []string
+			for _, verb := range escalationVerbs {
+				if contains(rule.Verbs, verb) || contains(rule.Verbs, wildcard) {
+					verbs = append(verbs, verb)
+				}
+			}
+			if len(verbs) > 0 {
+				return fmt.Sprintf("grants the %s verbs", strings.Join(verbs, ", "))
+			}
+			return ""
+		},
+	},
+	{
+		ID:          NodeAccessCheckID,
+		Description: "The rule does not grant access to nodes.",
+		flag: func(rule *PolicyRule) string {
+			return flagResource(rule, nodesResource)
+		},
+	},
+	{
+		ID:          PodExecAccessCheckID,
+		Description: "The rule does not grant access to pods/exec.",
+		flag: func(rule *PolicyRule) string {
+			return flagResource(rule, podExecResource)
+		},
+	},
+}
+
+// flagResource returns why `rule` grants access to `resource` of the core API group, or its subresources, or an
+// empty string if it does not.
+func flagResource(rule *PolicyRule, resource string) string {
+	if len(rule.Verbs) == 0 || !(contains(rule.APIGroups, coreAPIGroup) || contains(rule.APIGroups, wildcard)) {
+		return ""
+	}
+	var matched []string
+	for _, ruleResource := range rule.Resources {
+		if matchesResource(ruleResource, resource) {
+			matched = append(matched, ruleResource)
+		}
+	}
+	if len(matched) == 0 {
+		return ""
+	}
+	reason := fmt.Sprintf("grants %s on %s", strings.Join(rule.Verbs, ", "), strings.Join(matched, ", "))
+	if len(rule.ResourceNames) > 0 {
+		reason += fmt.Sprintf(" named %s", strings.Join(rule.ResourceNames, ", "))
+	}
+	return reason
+}
+
+// matchesResource returns true if the resource of a rule, which may be a wildcard or name a subresource, covers
+// `resource` or one of its subresources.
+func matchesResource(ruleResource, resource string) bool {
+	switch {
+	case ruleResource == wildcard || ruleResource == resource:
+		return true
+	case strings.HasPrefix(ruleResource, resource+subresourceSeparator):
+		// A subresource of `resource`, such as nodes/proxy.
+		return true
+	case strings.HasSuffix(ruleResource, subresourceSeparator+wildcard):
+		// Every subresource of a resource, such as pods/*.
+		return strings.HasPrefix(resource, strings.TrimSuffix(ruleResource, wildcard))
+	}
+	return false
+}
+
+// Grant is a rule which a binding effectively grants to a service account.
+type Grant struct {
+	// Binding is the RoleBinding or ClusterRoleBinding.
+	Binding string
+	// Subject is the subject of the binding through which the service account is bound.
+	Subject string
+	// Role is the Role or ClusterRole which the binding grants.
+	Role string
+	// Namespace is the namespace the rule applies in, and is empty if it applies cluster-wide.
+	Namespace string
+	Rule      PolicyRule
+	// ClusterDefault is true if the binding is a default binding of the cluster, which binds a group that every
+	// service account belongs to.
+	ClusterDefault bool
+}
+
+// Scope returns where the rule applies.
+func (g *Grant) Scope() string {
+	if g.Namespace == "" {
+		return clusterWideScope
+	}
+	return fmt.Sprintf(namespaceScopeTemplate, g.Namespace)
+}
+
+// String describes the rule and how it is granted.
+func (g *Grant) String() string {
+	rule := fmt.Sprintf("verbs [%s]", strings.Join(g.Rule.Verbs, " "))
+	if len(g.Rule.NonResourceURLs) > 0 {
+		rule += fmt.Sprintf(" nonResourceURLs [%s]", strings.Join(g.Rule.NonResourceURLs, " "))
+	} else {
+		rule += fmt.Sprintf(" apiGroups [%s] resources [%s]", strings.Join(quoteCoreGroup(g.Rule.APIGroups), " "),
+			strings.Join(g.Rule.Resources, " "))
+	}
+	if len(g.Rule.ResourceNames) > 0 {
+		rule += fmt.Sprintf(" resourceNames [%s]", strings.Join(g.Rule.ResourceNames, " "))
+	}
+	return fmt.Sprintf("%s via %s to %s, %s: %s", g.Role, g.Binding, g.Subject, g.Scope(), rule)
+}
+
+// quoteCoreGroup returns `groups` with the core API group, which is empty, quoted so that it shows.
+func quoteCoreGroup(groups []string) []string {
+	quoted := make([]string, len(groups))
+	for i, group := range groups {
+		quoted[i] = group
+		if group == coreAPIGroup {
+			quoted[i] = `""`
+		}
+	}
+	return quoted
+}
+
+// Finding is a grant flagged by a check.
+type Finding struct {
+	Check  *Check
+	Grant  *Grant
+	Reason string
+}
+
+// String describes the finding.
+func (f *Finding) String() string {
+	return fmt.Sprintf("[%s] %s %s: %s", f.Check.ID, f.Grant.Role, f.Grant.Scope(), f.Reason)
+}
+
+// Report is the effective permissions of a service account.
+type Report struct {
+	ServiceAccount ServiceAccount
+	// Grants are the rules of every role bound to the service account, in the order of the bindings and roles.
+	Grants []*Grant
+	// Findings are the grants flagged by the checks, in the order of the grants.  Grants of the default bindings of the
+	// cluster are left out.
+	Findings []*Finding
+	// DefaultFindings are the grants of the default bindings of the cluster flagged by the checks.  These bindings are
+	// the same for every service account of the cluster, so that their findings are only reported.
+	DefaultFindings []*Finding
+	// MissingRoles are the roles bound to the service account which could not be found.
+	MissingRoles []RoleKey
+}
+
+// Analyze resolves the roles which the bindings among `objects` grant to `sa`, and flags their rules with `checks`.
+// `objects` holds the bindings along with the roles they grant.
+func Analyze(sa ServiceAccount, objects []Object, checks []*Check) *Report {
+	report := &Report{ServiceAccount: sa}
+	roles := make(map[RoleKey]*Object)
+	for i := range objects {
+		if !objects[i].isBinding() {
+			roles[objects[i].key()] = &objects[i]
+		}
+	}
+	missing := make(map[RoleKey]bool)
+	for i := range objects {
+		binding := &objects[i]
+		if !binding.isBinding() {
+			continue
+		}
+		subject := binding.boundSubject(sa)
+		if subject == nil {
+			continue
+		}
+		key := binding.roleKey()
+		role, ok := roles[key]
+		if !ok {
+			if !missing[key] {
+				missing[key] = true
+				report.MissingRoles = append(report.MissingRoles, key)
+			}
+			continue
+		}
+		namespace := ""
+		if binding.Kind == KindRoleBinding {
+			namespace = binding.Metadata.Namespace
+		}
+		clusterDefault := binding.isClusterDefault() && subject.Kind == subjectGroup
+		for _, rule := range role.Rules {
+			grant := &Grant{Binding: binding.String(), Subject: subject.String(), Role: role.String(), Namespace: namespace,
+				Rule: rule, ClusterDefault: clusterDefault}
+			report.Grants = append(report.Grants, grant)
+			report.flag(grant, checks)
+		}
+	}
+	return report
+}
+
+// flag adds the findings of `checks` on `grant` to the report.
+func (r *Report) flag(grant *Grant, checks []*Check) {
+	for _, check := range checks {
+		reason := check.flag(&grant.Rule)
+		if reason == "" {
+			continue
+		}
+		finding := &Finding{Check: check, Grant: grant, Reason: reason}
+		if grant.ClusterDefault {
+			r.DefaultFindings = append(r.DefaultFindings, finding)
+		} else {
+			r.Findings = append(r.Findings, finding)
+		}
+	}
+}
+
+// Lines renders the report, a line per grant followed by a line per finding.
+func (r *Report) Lines() []string {
+	summary := fmt.Sprintf("service account %s: %d rules granted, %d flagged", r.ServiceAccount, len(r.Grants),
+		len(r.Findings))
+	if len(r.DefaultFindings) > 0 {
+		summary += fmt.Sprintf(", %d flagged in the default bindings of the cluster", len(r.DefaultFindings))
+	}
+	lines := []string{summary}
+	for _, grant := range r.Grants {
+		lines = append(lines, "  "+grant.String())
+	}
+	for _, key := range r.MissingRoles {
+		lines = append(lines, fmt.Sprintf("  %s is bound but could not be found", key))
+	}
+	for _, finding := range r.Findings {
+		lines = append(lines, "  "+finding.String())
+	}
+	for _, finding := range r.DefaultFindings {
+		lines = append(lines, fmt.Sprintf("  %s (default binding of the cluster, not failing)", finding))
+	}
+	return lines
+}
@@ -0,0 +1,136 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package rbacpolicy_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/rbacpolicy"
+)
+
+// findings returns the check ID and the reason of each finding of `report`.
+func findings(report *rbacpolicy.Report) [][2]string {
+	var found [][2]string
+	for _, finding := range report.Findings {
+		found = append(found, [2]string{finding.Check.ID, finding.Reason})
+	}
+	return found
+}
+
+func TestAnalyze(t *testing.T) {
+	report := rbacpolicy.Analyze(testServiceAccount, loadObjects(t), rbacpolicy.Checks)
+	assert.Equal(t, testServiceAccount, report.ServiceAccount)
+	assert.Len(t, report.Grants, 9)
+	assert.Equal(t, "Role tnf/app-reader via RoleBinding tnf/app-reader to ServiceAccount tnf/test-sa, in namespace tnf: "+
+		`verbs [get list watch] apiGroups [""] resources [configmaps pods]`, report.Grants[0].String())
+	assert.Equal(t, "ClusterRole system:basic-user via ClusterRoleBinding system:basic-user to Group system:authenticated, "+
+		"cluster-wide: verbs [get] nonResourceURLs [/version]", report.Grants[7].String())
+	assert.Equal(t, []rbacpolicy.RoleKey{{Kind: rbacpolicy.KindRole, Namespace: "other", Name: "missing"}}, report.MissingRoles)
+	assert.Equal(t, [][2]string{
+		{rbacpolicy.SecretsCheckID, "grants get on secrets named app-tls"},
+		{rbacpolicy.NodeAccessCheckID, "grants get, list on nodes, nodes/proxy"},
+		{rbacpolicy.EscalationCheckID, "grants the escalate, bind verbs"},
+		{rbacpolicy.PodExecAccessCheckID, "grants create on pods/*"},
+		{rbacpolicy.WildcardCheckID, "wildcard resources"},
+	}, findings(report))
+	assert.Equal(t, "[SECRETS_ACCESS] ClusterRole secret-reader in namespace tnf: grants get on secrets named app-tls",
+		report.Findings[0].String())
+
+	lines := report.Lines()
+	assert.Equal(t, "service account tnf/test-sa: 9 rules granted, 5 flagged, 1 flagged in the default bindings of the cluster",
+		lines[0])
+	assert.Len(t, lines, 17)
+	assert.Equal(t, "  Role other/missing is bound but could not be found", lines[10])
+	assert.Equal(t, "  [ESCALATE_BIND_IMPERSONATE] ClusterRole system:scope-impersonation cluster-wide: grants the impersonate "+
+		"verbs (default binding of the cluster, not failing)", lines[16])
+}
+
+func TestAnalyzeClusterAdmin(t *testing.T) {
+	report := rbacpolicy.Analyze(rbacpolicy.ServiceAccount{Namespace: "other", Name: "test-sa"}, loadObjects(t),
+		rbacpolicy.Checks)
+	assert.Empty(t, report.MissingRoles)
+	clusterAdmin := [][2]string{
+		{rbacpolicy.WildcardCheckID, "wildcard verbs, resources, apiGroups"},
+		{rbacpolicy.SecretsCheckID, "grants * on *"},
+		{rbacpolicy.EscalationCheckID, "grants the escalate, bind, impersonate verbs"},
+		{rbacpolicy.NodeAccessCheckID, "grants * on *"},
+		{rbacpolicy.PodExecAccessCheckID, "grants * on *"},
+		{rbacpolicy.WildcardCheckID, "wildcard verbs"},
+		{rbacpolicy.EscalationCheckID, "grants the escalate, bind, impersonate verbs"},
+	}
+	// The service account is granted cluster-admin both in its namespace and cluster-wide.
+	assert.Equal(t, append(append([][2]string{}, clusterAdmin...), clusterAdmin...), findings(report))
+	assert.Equal(t, "in namespace other", report.Findings[0].Grant.Scope())
+	assert.Equal(t, "cluster-wide", report.Findings[7].Grant.Scope())
+}
+
+func TestAnalyzeSelectedChecks(t *testing.T) {
+	report := rbacpolicy.Analyze(testServiceAccount, loadObjects(t), rbacpolicy.Checks[:1])
+	assert.Len(t, report.Grants, 9)
+	assert.Equal(t, [][2]string{{rbacpolicy.WildcardCheckID, "wildcard resources"}}, findings(report))
+}
+
+func TestAnalyzeUnbound(t *testing.T) {
+	report := rbacpolicy.Analyze(rbacpolicy.ServiceAccount{Namespace: "tnf", Name: "default"}, loadObjects(t),
+		rbacpolicy.Checks)
+	// Every service account is bound to the roles of its groups.
+	assert.Len(t, report.Grants, 4)
+	assert.Equal(t, [][2]string{{rbacpolicy.WildcardCheckID, "wildcard resources"}}, findings(report))
+}
+
+func TestAnalyzeClusterDefaults(t *testing.T) {
+	// The default bindings of the cluster which bind the groups of every service account are only reported.
+	report := rbacpolicy.Analyze(rbacpolicy.ServiceAccount{Namespace: "other", Name: "default"}, loadObjects(t),
+		rbacpolicy.Checks)
+	assert.Empty(t, report.Findings)
+	assert.Len(t, report.DefaultFindings, 1)
+	assert.True(t, report.DefaultFindings[0].Grant.ClusterDefault)
+	assert.Equal(t, "ClusterRoleBinding system:scope-impersonation", report.DefaultFindings[0].Grant.Binding)
+
+	// A binding of the service account itself is flagged, even when it is annotated as a default binding.
+	report = rbacpolicy.Analyze(rbacpolicy.ServiceAccount{Namespace: "tnf", Name: "impersonator"}, loadObjects(t),
+		rbacpolicy.Checks)
+	assert.Equal(t, [][2]string{
+		{rbacpolicy.WildcardCheckID, "wildcard resources"},
+		{rbacpolicy.EscalationCheckID, "grants the impersonate verbs"},
+	}, findings(report))
+	assert.Equal(t, "ClusterRoleBinding cnf-impersonation", report.Findings[1].Grant.Binding)
+}
+
+func TestChecksResourceMatching(t *testing.T) {
+	objects := []rbacpolicy.Object{
+		{Kind: rbacpolicy.KindClusterRoleBinding, Subjects: []rbacpolicy.Subject{{Kind: "ServiceAccount", Name: "test-sa", Namespace: "tnf"}},
+			RoleRef: rbacpolicy.RoleRef{Kind: rbacpolicy.KindClusterRole, Name: "matching"}},
+		{Kind: rbacpolicy.KindClusterRole, Rules: []rbacpolicy.PolicyRule{
+			// Pods, but not their exec subresource.
+			{APIGroups: []string{""}, Resources: []string{"pods", "pods/log"}, Verbs: []string{"get"}},
+			// Secrets of another API group.
+			{APIGroups: []string{"example.com"}, Resources: []string{"secrets", "nodes"}, Verbs: []string{"get"}},
+			// No verbs grant nothing.
+			{APIGroups: []string{""}, Resources: []string{"secrets"}},
+			// Pods exec through a wildcard API group.
+			{APIGroups: []string{"*"}, Resources: []string{"pods/exec"}, Verbs: []string{"create"}},
+		}},
+	}
+	objects[1].Metadata.Name = "matching"
+	report := rbacpolicy.Analyze(testServiceAccount, objects, rbacpolicy.Checks)
+	assert.Equal(t, [][2]string{
+		{rbacpolicy.WildcardCheckID, "wildcard apiGroups"},
+		{rbacpolicy.PodExecAccessCheckID, "grants create on pods/exec"},
+	}, findings(report))
+}
@@ -0,0 +1,250 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package rbacpolicy
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Kinds of the RBAC objects.
+const (
+	KindRole               = "Role"
+	KindClusterRole        = "ClusterRole"
+	KindRoleBinding        = "RoleBinding"
+	KindClusterRoleBinding = "ClusterRoleBinding"
+)
+
+const (
+	// Kinds of the subjects of a binding.
+	subjectServiceAccount = "ServiceAccount"
+	subjectUser           = "User"
+	subjectGroup          = "Group"
+
+	// serviceAccountUserPrefix prefixes the user name of a service account, followed by its namespace and name.
+	serviceAccountUserPrefix = "system:serviceaccount:"
+	// serviceAccountsGroup is the group of every service account.  It is followed by the namespace in the group of
+	// the service accounts of a namespace.
+	serviceAccountsGroup = "system:serviceaccounts"
+	// authenticatedGroup is the group of every authenticated user, service accounts included.
+	authenticatedGroup = "system:authenticated"
+
+	// autoupdateAnnotation marks the default roles and bindings which the cluster creates, and reconciles on start.
+	autoupdateAnnotation = "rbac.authorization.kubernetes.io/autoupdate"
+)
+
+// Object is the part of a Role, ClusterRole, RoleBinding or ClusterRoleBinding which the analysis inspects.
+type Object struct {
+	Kind     string `json:"kind"`
+	Metadata struct {
+		Name        string            `json:"name"`
+		Namespace   string            `json:"namespace"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"metadata"`
+	// Subjects and RoleRef are only set for bindings.
+	Subjects []Subject `json:"subjects"`
+	RoleRef  RoleRef   `json:"roleRef"`
+	// Rules are only set for roles.
+	Rules []PolicyRule `json:"rules"`
+}
+
+// Subject is a user, group or service account which a binding grants a role to.
+type Subject struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// RoleRef references the role which a binding grants.
+type RoleRef struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+// PolicyRule is a set of verbs allowed on a set of resources.
+type PolicyRule struct {
+	Verbs           []string `json:"verbs"`
+	APIGroups       []string `json:"apiGroups"`
+	Resources       []string `json:"resources"`
+	ResourceNames   []string `json:"resourceNames"`
+	NonResourceURLs []string `json:"nonResourceURLs"`
+}
+
+// ParseObjects parses the items of a list of RBAC objects from its JSON representation.
+func ParseObjects(data []byte) ([]Object, error) {
+	list := struct {
+		Items []Object `json:"items"`
+	}{}
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// ServiceAccount identifies a service account.
+type ServiceAccount struct {
+	Namespace string
+	Name      string
+}
+
+// String returns the namespace and the name of the service account.
+func (s ServiceAccount) String() string {
+	return s.Namespace + "/" + s.Name
+}
+
+// userName returns the name the service account authenticates as.
+func (s ServiceAccount) userName() string {
+	return serviceAccountUserPrefix + s.Namespace + ":" + s.Name
+}
+
+// groups returns the groups the service account belongs to.
+func (s ServiceAccount) groups() []string {
+	return []string{serviceAccountsGroup, serviceAccountsGroup + ":" + s.Namespace, authenticatedGroup}
+}
+
+// SubjectNames returns the names of the subjects which can bind a role to the service account: its own name, its
+// user name and its groups.  A binding which binds the service account has a subject with one of these names.
+func (s ServiceAccount) SubjectNames() []string {
+	return append([]string{s.Name, s.userName()}, s.groups()...)
+}
+
+// String returns the kind of the subject and its name, with its namespace for a service account.
+func (s *Subject) String() string {
+	if s.Kind == subjectServiceAccount {
+		return fmt.Sprintf("%s %s/%s", s.Kind, s.Namespace, s.Name)
+	}
+	return fmt.Sprintf("%s %s", s.Kind, s.Name)
+}
+
+// String returns the kind of the object and its name, preceded by its namespace if it has one.
+func (o *Object) String() string {
+	if o.Metadata.Namespace == "" {
+		return fmt.Sprintf("%s %s", o.Kind, o.Metadata.Name)
+	}
+	return fmt.Sprintf("%s %s/%s", o.Kind, o.Metadata.Namespace, o.Metadata.Name)
+}
+
+// isBinding returns true if the object is a RoleBinding or a ClusterRoleBinding.
+func (o *Object) isBinding() bool {
+	return o.Kind == KindRoleBinding || o.Kind == KindClusterRoleBinding
+}
+
+// isClusterDefault returns true if the object is one of the default roles or bindings of the cluster.
+func (o *Object) isClusterDefault() bool {
+	return o.Metadata.Annotations[autoupdateAnnotation] == "true"
+}
+
+// boundSubject returns the subject through which the binding binds `sa`, or nil if it does not.  The service account
+// subjects of a RoleBinding default to the namespace of the binding.
+func (o *Object) boundSubject(sa ServiceAccount) *Subject {
+	for i := range o.Subjects {
+		subject := o.Subjects[i]
+		switch subject.Kind {
+		case subjectServiceAccount:
+			if subject.Namespace == "" && o.Kind == KindRoleBinding {
+				subject.Namespace = o.Metadata.Namespace
+			}
+			if subject.Name == sa.Name && subject.Namespace == sa.Namespace {
+				return &subject
+			}
+		case subjectUser:
+			if subject.Name == sa.userName() {
+				return &subject
+			}
+		case subjectGroup:
+			if contains(sa.groups(), subject.Name) {
+				return &subject
+			}
+		}
+	}
+	return nil
+}
+
+// RoleKey identifies a Role or a ClusterRole.
+type RoleKey struct {
+	Kind string
+	// Namespace is empty for a ClusterRole.
+	Namespace string
+	Name      string
+}
+
+// String returns the kind of the role and its name, preceded by its namespace for a Role.
+func (k RoleKey) String() string {
+	if k.Namespace == "" {
+		return fmt.Sprintf("%s %s", k.Kind, k.Name)
+	}
+	return fmt.Sprintf("%s %s/%s", k.Kind, k.Namespace, k.Name)
+}
+
+// roleKey returns the key of the role which the binding grants.  A RoleBinding may grant a ClusterRole, which is not
+// namespaced, or a Role of its own namespace.
+func (o *Object) roleKey() RoleKey {
+	key := RoleKey{Kind: o.RoleRef.Kind, Name: o.RoleRef.Name}
+	if key.Kind == KindRole {
+		key.Namespace = o.Metadata.Namespace
+	}
+	return key
+}
+
+// key returns the key of a role.
+func (o *Object) key() RoleKey {
+	return RoleKey{Kind: o.Kind, Namespace: o.Metadata.Namespace, Name: o.Metadata.Name}
+}
+
+// RoleKeys returns the keys of the roles which `bindings` grant to `sa`, without duplicates and in the order of the
+// bindings.
+func RoleKeys(sa ServiceAccount, bindings []Object) []RoleKey {
+	var keys []RoleKey
+	seen := make(map[RoleKey]bool)
+	for i := range bindings {
+		binding := &bindings[i]
+		if !binding.isBinding() || binding.boundSubject(sa) == nil {
+			continue
+		}
+		if key := binding.roleKey(); !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// DirectBindings returns the bindings among `bindings` which bind `sa` itself, through its name or its user name, rather
+// than through the groups it belongs to.
+func DirectBindings(sa ServiceAccount, bindings []Object) []string {
+	var direct []string
+	for i := range bindings {
+		binding := &bindings[i]
+		if !binding.isBinding() {
+			continue
+		}
+		if subject := binding.boundSubject(sa); subject != nil && subject.Kind != subjectGroup {
+			direct = append(direct, binding.String())
+		}
+	}
+	return direct
+}
+
+// contains returns true if `value` is one of `values`.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package rbacpolicy_test
+
+import (
+	"io/ioutil"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/rbacpolicy"
+)
+
+var testServiceAccount = rbacpolicy.ServiceAccount{Namespace: "tnf", Name: "test-sa"}
+
+func loadObjects(t *testing.T) []rbacpolicy.Object {
+	contents, err := ioutil.ReadFile(path.Join("testdata", "objects.json"))
+	assert.Nil(t, err)
+	objects, err := rbacpolicy.ParseObjects(contents)
+	assert.Nil(t, err)
+	return objects
+}
+
+func TestParseObjects(t *testing.T) {
+	objects := loadObjects(t)
+	assert.Len(t, objects, 17)
+	assert.Equal(t, rbacpolicy.KindRoleBinding, objects[0].Kind)
+	assert.Equal(t, "RoleBinding tnf/app-reader", objects[0].String())
+	assert.Equal(t, "ClusterRole secret-reader", objects[9].String())
+	assert.Equal(t, []string{"app-tls"}, objects[9].Rules[0].ResourceNames)
+	assert.Equal(t, "true", objects[14].Metadata.Annotations["rbac.authorization.kubernetes.io/autoupdate"])
+
+	_, err := rbacpolicy.ParseObjects([]byte(`{"items": {}}`))
+	assert.NotNil(t, err)
+}
+
+func TestSubjectNames(t *testing.T) {
+	assert.Equal(t, "tnf/test-sa", testServiceAccount.String())
+	assert.Equal(t, []string{"test-sa", "system:serviceaccount:tnf:test-sa", "system:serviceaccounts",
+		"system:serviceaccounts:tnf", "system:authenticated"}, testServiceAccount.SubjectNames())
+}
+
+func TestRoleKeys(t *testing.T) {
+	assert.Equal(t, []rbacpolicy.RoleKey{
+		{Kind: rbacpolicy.KindRole, Namespace: "tnf", Name: "app-reader"},
+		{Kind: rbacpolicy.KindClusterRole, Name: "secret-reader"},
+		{Kind: rbacpolicy.KindRole, Namespace: "other", Name: "missing"},
+		{Kind: rbacpolicy.KindClusterRole, Name: "cnf-operator"},
+		{Kind: rbacpolicy.KindClusterRole, Name: "apps-reader"},
+		{Kind: rbacpolicy.KindClusterRole, Name: "system:basic-user"},
+		{Kind: rbacpolicy.KindClusterRole, Name: "system:scope-impersonation"},
+	}, rbacpolicy.RoleKeys(testServiceAccount, loadObjects(t)))
+	assert.Equal(t, []rbacpolicy.RoleKey{
+		{Kind: rbacpolicy.KindClusterRole, Name: "cluster-admin"},
+		{Kind: rbacpolicy.KindClusterRole, Name: "system:basic-user"},
+		{Kind: rbacpolicy.KindClusterRole, Name: "system:scope-impersonation"},
+	}, rbacpolicy.RoleKeys(rbacpolicy.ServiceAccount{Namespace: "other", Name: "test-sa"}, loadObjects(t)))
+	assert.Equal(t, "Role other/missing", rbacpolicy.RoleKey{Kind: rbacpolicy.KindRole, Namespace: "other", Name: "missing"}.String())
+}
+
+func TestDirectBindings(t *testing.T) {
+	assert.Equal(t, []string{
+		"RoleBinding tnf/app-reader",
+		"RoleBinding tnf/secret-reader",
+		"RoleBinding other/remote",
+		"ClusterRoleBinding cnf-operator",
+	}, rbacpolicy.DirectBindings(testServiceAccount, loadObjects(t)))
+	// The default service account is only bound through the groups it belongs to.
+	assert.Empty(t, rbacpolicy.DirectBindings(rbacpolicy.ServiceAccount{Namespace: "tnf", Name: "default"}, loadObjects(t)))
+}
@@ -0,0 +1,123 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package sbom
+
+import (
+	"time"
+)
+
+const (
+	cycloneDXFormat      = "CycloneDX"
+	cycloneDXSpecVersion = "1.4"
+	cycloneDXVersion     = 1
+
+	containerComponentType = "container"
+	libraryComponentType   = "library"
+	toolVendor             = "test-network-function"
+	toolName               = "test-network-function"
+
+	osIDProperty        = "tnf:os:id"
+	osVersionIDProperty = "tnf:os:versionID"
+	managerProperty     = "tnf:package:manager"
+)
+
+// CycloneDX is a software bill of materials in the CycloneDX JSON format.
+type CycloneDX struct {
+	BOMFormat   string      `json:"bomFormat"`
+	SpecVersion string      `json:"specVersion"`
+	Version     int         `json:"version"`
+	Metadata    Metadata    `json:"metadata"`
+	Components  []Component `json:"components"`
+}
+
+// Metadata describes the image the bill of materials is for.
+type Metadata struct {
+	Timestamp string    `json:"timestamp"`
+	Tools     []Tool    `json:"tools"`
+	Component Component `json:"component"`
+}
+
+// Tool names the tool which produced the bill of materials.
+type Tool struct {
+	Vendor string `json:"vendor"`
+	Name   string `json:"name"`
+}
+
+// Component is the image, or one of the packages installed in it.
+type Component struct {
+	Type       string     `json:"type"`
+	BOMRef     string     `json:"bom-ref,omitempty"`
+	Name       string     `json:"name"`
+	Version    string     `json:"version,omitempty"`
+	PURL       string     `json:"purl,omitempty"`
+	Licenses   []License  `json:"licenses,omitempty"`
+	Properties []Property `json:"properties,omitempty"`
+}
+
+// License is the license declared by a package.  Package licenses are seldom valid SPDX expressions, so they are
+// recorded by name.
+type License struct {
+	License struct {
+		Name string `json:"name"`
+	} `json:"license"`
+}
+
+// Property is a name and value pair which CycloneDX has no field for.
+type Property struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// NewCycloneDX renders the bill of materials of the packages of `inventory`, installed in the image `image`, which
+// resolved to `imageID`, stamped with the current time.
+func NewCycloneDX(image, imageID string, inventory *Inventory) *CycloneDX {
+	bom := &CycloneDX{
+		BOMFormat:   cycloneDXFormat,
+		SpecVersion: cycloneDXSpecVersion,
+		Version:     cycloneDXVersion,
+		Metadata: Metadata{
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Tools:     []Tool{{Vendor: toolVendor, Name: toolName}},
+			Component: Component{Type: containerComponentType, Name: image, Version: imageID},
+		},
+		Components: []Component{},
+	}
+	for _, property := range []Property{{osIDProperty, inventory.OSID}, {osVersionIDProperty, inventory.OSVersionID}} {
+		if property.Value != "" {
+			bom.Metadata.Component.Properties = append(bom.Metadata.Component.Properties, property)
+		}
+	}
+	for i := range inventory.Packages {
+		pkg := &inventory.Packages[i]
+		purl := pkg.PURL(inventory.OSID, inventory.OSVersionID)
+		component := Component{
+			Type:       libraryComponentType,
+			BOMRef:     purl,
+			Name:       pkg.Name,
+			Version:    pkg.Version,
+			PURL:       purl,
+			Properties: []Property{{managerProperty, pkg.Manager}},
+		}
+		if pkg.License != "" {
+			license := License{}
+			license.License.Name = pkg.License
+			component.Licenses = []License{license}
+		}
+		bom.Components = append(bom.Components, component)
+	}
+	return bom
+}
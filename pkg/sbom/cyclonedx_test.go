@@ -0,0 +1,57 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package sbom_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/sbom"
+)
+
+func TestNewCycloneDX(t *testing.T) {
+	inventory := sbom.ParseInventory(inventoryLines(
+		[]string{"os", "alpine", "3.14.2"},
+		[]string{"apk", "musl", "1.2.2-r3", "x86_64", "MIT"},
+		[]string{"apk", "busybox", "1.33.1-r3", "x86_64", ""},
+	))
+	bom := sbom.NewCycloneDX("quay.io/org/app:1.0", "sha256:abc", inventory)
+	assert.Equal(t, "CycloneDX", bom.BOMFormat)
+	assert.Equal(t, "1.4", bom.SpecVersion)
+	assert.Equal(t, 1, bom.Version)
+	assert.NotEmpty(t, bom.Metadata.Timestamp)
+	assert.Equal(t, sbom.Component{Type: "container", Name: "quay.io/org/app:1.0", Version: "sha256:abc",
+		Properties: []sbom.Property{{Name: "tnf:os:id", Value: "alpine"}, {Name: "tnf:os:versionID", Value: "3.14.2"}}},
+		bom.Metadata.Component)
+	if assert.Len(t, bom.Components, 2) {
+		busybox := bom.Components[0]
+		assert.Equal(t, "library", busybox.Type)
+		assert.Equal(t, "busybox", busybox.Name)
+		assert.Equal(t, "1.33.1-r3", busybox.Version)
+		assert.Equal(t, "pkg:apk/alpine/busybox@1.33.1-r3?arch=x86_64&distro=alpine-3.14.2", busybox.PURL)
+		assert.Equal(t, busybox.PURL, busybox.BOMRef)
+		assert.Empty(t, busybox.Licenses)
+		assert.Equal(t, []sbom.Property{{Name: "tnf:package:manager", Value: "apk"}}, busybox.Properties)
+		if assert.Len(t, bom.Components[1].Licenses, 1) {
+			assert.Equal(t, "MIT", bom.Components[1].Licenses[0].License.Name)
+		}
+	}
+
+	empty := sbom.NewCycloneDX("scratch", "", &sbom.Inventory{})
+	assert.NotNil(t, empty.Components)
+	assert.Empty(t, empty.Metadata.Component.Properties)
+}
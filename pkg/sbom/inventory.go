@@ -0,0 +1,165 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package sbom
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Package managers whose databases are listed.
+const (
+	ManagerRPM = "rpm"
+	ManagerDeb = "deb"
+	ManagerAPK = "apk"
+)
+
+const (
+	// osRecord is the record type of the line naming the distribution, as `os<TAB>ID<TAB>VERSION_ID`.
+	osRecord = "os"
+	// moduleRecord is the record type of the lines naming an enabled module stream, as `module<TAB>name<TAB>stream`.
+	moduleRecord = "module"
+	// recordSeparator separates the fields of an inventory line.
+	recordSeparator = "\t"
+	// packageFields is the number of fields of a package line, as `manager<TAB>name<TAB>version<TAB>arch<TAB>license`.
+	packageFields = 5
+	osFields      = 3
+	moduleFields  = 3
+	// moduleSeparator separates the name of a module from its stream.
+	moduleSeparator = ":"
+	// noneValue is output by rpm for tags which are not set.
+	noneValue = "(none)"
+	// epochSeparator separates the epoch from the version of a package.
+	epochSeparator = ":"
+)
+
+// Package is a package installed in a container.
+type Package struct {
+	// Manager is the package manager which installed the package, one of ManagerRPM, ManagerDeb and ManagerAPK.
+	Manager string `json:"manager"`
+	Name    string `json:"name"`
+	// Version includes the release of the package, and its epoch when set, such as `1:1.1.1k-5.el8_5` for rpm.
+	Version string `json:"version"`
+	Arch    string `json:"arch,omitempty"`
+	// License is the license declared by the package, which is not set by dpkg.
+	License string `json:"license,omitempty"`
+}
+
+// Inventory is the list of the packages installed in a container, along with its distribution.
+type Inventory struct {
+	// OSID is the ID of the distribution, as in /etc/os-release, such as `rhel`, `debian` or `alpine`.
+	OSID string `json:"osID,omitempty"`
+	// OSVersionID is the VERSION_ID of the distribution, as in /etc/os-release.
+	OSVersionID string `json:"osVersionID,omitempty"`
+	// Modules are the module streams enabled with dnf, as `name:stream` such as `nodejs:14`, sorted.
+	Modules []string `json:"modules,omitempty"`
+	// Packages are sorted by name.
+	Packages []Package `json:"packages"`
+}
+
+// ParseInventory parses the tab separated inventory `output`.  The line naming the distribution is
+// `os<TAB>ID<TAB>VERSION_ID`, each enabled module stream is listed as `module<TAB>name<TAB>stream`, and each package is
+// listed as `manager<TAB>name<TAB>version<TAB>arch<TAB>license`.  Any other line, such as the echo of the command, is
+// ignored.
+func ParseInventory(output string) *Inventory {
+	inventory := &Inventory{}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(strings.TrimRight(line, "\r"), recordSeparator)
+		switch {
+		case len(fields) == osFields && fields[0] == osRecord:
+			inventory.OSID, inventory.OSVersionID = fields[1], fields[2]
+		case len(fields) == moduleFields && fields[0] == moduleRecord && fields[1] != "" && fields[2] != "":
+			inventory.Modules = append(inventory.Modules, fields[1]+moduleSeparator+fields[2])
+		case len(fields) == packageFields && isManager(fields[0]) && fields[1] != "":
+			pkg := Package{Manager: fields[0], Name: fields[1], Version: fields[2], Arch: fields[3], License: fields[4]}
+			if pkg.Arch == noneValue {
+				pkg.Arch = ""
+			}
+			if pkg.License == noneValue {
+				pkg.License = ""
+			}
+			inventory.Packages = append(inventory.Packages, pkg)
+		}
+	}
+	sort.Strings(inventory.Modules)
+	sort.SliceStable(inventory.Packages, func(i, j int) bool {
+		return inventory.Packages[i].Name < inventory.Packages[j].Name
+	})
+	return inventory
+}
+
+// Managers returns the package managers which installed the packages, in order of their first package.
+func (i *Inventory) Managers() []string {
+	var managers []string
+	for _, pkg := range i.Packages {
+		if !containsString(managers, pkg.Manager) {
+			managers = append(managers, pkg.Manager)
+		}
+	}
+	return managers
+}
+
+// PURL returns the package URL of the package, such as `pkg:rpm/rhel/bash@4.4.20-1.el8_4?arch=x86_64&distro=rhel-8.4`,
+// namespaced by the distribution `osID` and qualified by its `osVersionID`, either of which may be empty.  The epoch of
+// an rpm is given as the `epoch` qualifier, as package URLs of rpms expect.
+func (p *Package) PURL(osID, osVersionID string) string {
+	purl := fmt.Sprintf("pkg:%s/", p.Manager)
+	if osID != "" {
+		purl += url.QueryEscape(osID) + "/"
+	}
+	purl += url.QueryEscape(p.Name)
+	version, epoch := p.Version, ""
+	if p.Manager == ManagerRPM {
+		if i := strings.Index(version, epochSeparator); i >= 0 {
+			epoch, version = version[:i], version[i+1:]
+		}
+	}
+	if version != "" {
+		purl += "@" + url.QueryEscape(version)
+	}
+	var qualifiers []string
+	if p.Arch != "" {
+		qualifiers = append(qualifiers, "arch="+url.QueryEscape(p.Arch))
+	}
+	if epoch != "" {
+		qualifiers = append(qualifiers, "epoch="+url.QueryEscape(epoch))
+	}
+	if osID != "" && osVersionID != "" {
+		qualifiers = append(qualifiers, "distro="+url.QueryEscape(osID+"-"+osVersionID))
+	}
+	if len(qualifiers) > 0 {
+		purl += "?" + strings.Join(qualifiers, "&")
+	}
+	return purl
+}
+
+// isManager returns true if `manager` is a supported package manager.
+func isManager(manager string) bool {
+	return manager == ManagerRPM || manager == ManagerDeb || manager == ManagerAPK
+}
+
+// containsString returns true if `values` contains `value`.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
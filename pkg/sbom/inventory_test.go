@@ -0,0 +1,79 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package sbom_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/sbom"
+)
+
+// inventoryLines joins tab separated records into inventory output.
+func inventoryLines(records ...[]string) string {
+	var lines []string
+	for _, record := range records {
+		lines = append(lines, strings.Join(record, "\t"))
+	}
+	return strings.Join(lines, "\r\n")
+}
+
+func TestParseInventory(t *testing.T) {
+	output := "sh -c 'rpm -qa --queryformat ...'\r\n" + inventoryLines(
+		[]string{"os", "rhel", "8.4"},
+		[]string{"module", "nodejs", "14"},
+		[]string{"module", "container-tools", "rhel8"},
+		[]string{"module", "perl", ""},
+		[]string{"rpm", "openssl-libs", "1.1.1g-15.el8_3", "x86_64", "OpenSSL and ASL 2.0"},
+		[]string{"rpm", "bash", "4.4.20-1.el8_4", "x86_64", "GPLv3+"},
+		[]string{"rpm", "gpg-pubkey", "fd431d51-4ae0493b", "(none)", "pubkey"},
+		[]string{"rpm", "", "1.0", "x86_64", ""},
+		[]string{"pip", "requests", "2.25.1", "", ""},
+	) + "\r\nPACKAGES_EXIT_STATUS=0\r\n"
+	inventory := sbom.ParseInventory(output)
+	assert.Equal(t, "rhel", inventory.OSID)
+	assert.Equal(t, "8.4", inventory.OSVersionID)
+	assert.Equal(t, []string{"container-tools:rhel8", "nodejs:14"}, inventory.Modules)
+	assert.Equal(t, []sbom.Package{
+		{Manager: "rpm", Name: "bash", Version: "4.4.20-1.el8_4", Arch: "x86_64", License: "GPLv3+"},
+		{Manager: "rpm", Name: "gpg-pubkey", Version: "fd431d51-4ae0493b", License: "pubkey"},
+		{Manager: "rpm", Name: "openssl-libs", Version: "1.1.1g-15.el8_3", Arch: "x86_64", License: "OpenSSL and ASL 2.0"},
+	}, inventory.Packages)
+	assert.Equal(t, []string{"rpm"}, inventory.Managers())
+
+	inventory = sbom.ParseInventory("no package manager\r\n")
+	assert.Empty(t, inventory.Packages)
+	assert.Empty(t, inventory.Managers())
+	assert.Equal(t, "", inventory.OSID)
+}
+
+func TestPackagePURL(t *testing.T) {
+	rpm := sbom.Package{Manager: "rpm", Name: "bash", Version: "4.4.20-1.el8_4", Arch: "x86_64"}
+	assert.Equal(t, "pkg:rpm/rhel/bash@4.4.20-1.el8_4?arch=x86_64&distro=rhel-8.4", rpm.PURL("rhel", "8.4"))
+	assert.Equal(t, "pkg:rpm/bash@4.4.20-1.el8_4?arch=x86_64", rpm.PURL("", ""))
+
+	epochRPM := sbom.Package{Manager: "rpm", Name: "openssl-libs", Version: "1:1.1.1k-5.el8_5", Arch: "x86_64"}
+	assert.Equal(t, "pkg:rpm/rhel/openssl-libs@1.1.1k-5.el8_5?arch=x86_64&epoch=1&distro=rhel-8.5",
+		epochRPM.PURL("rhel", "8.5"))
+
+	deb := sbom.Package{Manager: "deb", Name: "libc6", Version: "2.31-13+deb11u2", Arch: "amd64"}
+	assert.Equal(t, "pkg:deb/debian/libc6@2.31-13%2Bdeb11u2?arch=amd64&distro=debian-11", deb.PURL("debian", "11"))
+
+	epoch := sbom.Package{Manager: "deb", Name: "tzdata", Version: "1:2021a"}
+	assert.Equal(t, "pkg:deb/debian/tzdata@1%3A2021a", epoch.PURL("debian", ""))
+}
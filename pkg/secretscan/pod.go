@@ -0,0 +1,99 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package secretscan
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/test-network-function/test-network-function/pkg/podpolicy"
+)
+
+var (
+	// caBundleConfigMaps are published by the cluster in every namespace, and mounted in each pod by its projected
+	// kube-api-access volume.  They only hold the certificates of the cluster CAs.
+	caBundleConfigMaps = []string{"kube-root-ca.crt", "openshift-service-ca.crt"}
+)
+
+// ConfigMap is the part of a Kubernetes ConfigMap which is scanned.
+type ConfigMap struct {
+	Metadata struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+	Data map[string]string `json:"data"`
+}
+
+// ParseConfigMaps parses the ConfigMaps of the JSON list `data`, as output by `oc get configmaps -o json`.
+func ParseConfigMaps(data []byte) ([]ConfigMap, error) {
+	list := struct {
+		Items []ConfigMap `json:"items"`
+	}{}
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// Scan returns the likely credentials in the data of the ConfigMap.
+func (c *ConfigMap) Scan() []Finding {
+	return ScanData(fmt.Sprintf("configmap %s", c.Metadata.Name), c.Data)
+}
+
+// ConfigMapNames returns the names of the ConfigMaps which `pod` references, without the CA bundles which the cluster
+// publishes in every namespace.
+func ConfigMapNames(pod *podpolicy.Pod) []string {
+	var names []string
+	for _, name := range pod.ConfigMapNames() {
+		if !isCABundleConfigMap(name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// isCABundleConfigMap returns true if `name` is one of the CA bundles which the cluster publishes in every namespace.
+func isCABundleConfigMap(name string) bool {
+	for _, caBundle := range caBundleConfigMaps {
+		if name == caBundle {
+			return true
+		}
+	}
+	return false
+}
+
+// ScanPod returns the likely credentials in the literal environment variables, commands and arguments of the
+// containers and init containers of `pod`.  Variables set from Secrets are never flagged, and variables set from
+// ConfigMaps are flagged when scanning the ConfigMaps themselves.
+func ScanPod(pod *podpolicy.Pod) []Finding {
+	var findings []Finding
+	scanContainers := func(kind string, containers []podpolicy.Container) {
+		for i := range containers {
+			container := &containers[i]
+			location := fmt.Sprintf("%s %s", kind, container.Name)
+			for _, env := range container.Env {
+				if env.ValueFrom == nil {
+					findings = append(findings, ScanValue(fmt.Sprintf("%s env %s", location, env.Name), env.Name, env.Value)...)
+				}
+			}
+			findings = append(findings, ScanArgs(location+" command", container.Command)...)
+			findings = append(findings, ScanArgs(location+" args", container.Args)...)
+		}
+	}
+	scanContainers("init container", pod.Spec.InitContainers)
+	scanContainers("container", pod.Spec.Containers)
+	return findings
+}
@@ -0,0 +1,95 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package secretscan_test
+
+import (
+	"io/ioutil"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/podpolicy"
+	"github.com/test-network-function/test-network-function/pkg/secretscan"
+)
+
+func loadPod(t *testing.T, name string) *podpolicy.Pod {
+	contents, err := ioutil.ReadFile(path.Join("testdata", name+".json"))
+	assert.Nil(t, err)
+	pod, err := podpolicy.ParsePod(contents)
+	assert.Nil(t, err)
+	return pod
+}
+
+func findingStrings(findings []secretscan.Finding) []string {
+	var result []string
+	for i := range findings {
+		result = append(result, findings[i].String())
+	}
+	return result
+}
+
+func TestScanPod(t *testing.T) {
+	pod := loadPod(t, "credentials_pod")
+	// Init containers are scanned first, and variables set from Secrets are never flagged.
+	assert.Equal(t, []string{
+		"init container migrate args: URL credentials S3cr****",
+		"container app env DB_PASSWORD: sensitive name hunt****",
+		"container app env AWS_ACCESS_KEY_ID: AWS access key ID AKIA****",
+		"container app env SESSION_SEED: high entropy string q8Zr****",
+		"container app args: sensitive name k3y-****",
+		"container notifier command: Slack token xoxb****",
+	}, findingStrings(secretscan.ScanPod(pod)))
+
+	assert.Empty(t, secretscan.ScanPod(loadPod(t, "clean_pod")))
+}
+
+func TestScanConfigMaps(t *testing.T) {
+	contents, err := ioutil.ReadFile(path.Join("testdata", "configmaps.json"))
+	assert.Nil(t, err)
+	configMaps, err := secretscan.ParseConfigMaps(contents)
+	assert.Nil(t, err)
+	if assert.Len(t, configMaps, 2) {
+		assert.Equal(t, []string{
+			"configmap app-config key application.properties: sensitive name ****",
+			"configmap app-config key config.yaml: Google API key AIza****",
+			"configmap app-config key tls.key: private key ----****",
+		}, findingStrings(configMaps[0].Scan()))
+		assert.Empty(t, configMaps[1].Scan())
+	}
+
+	_, err = secretscan.ParseConfigMaps([]byte("{"))
+	assert.NotNil(t, err)
+}
+
+func TestScanCABundles(t *testing.T) {
+	contents, err := ioutil.ReadFile(path.Join("testdata", "ca_bundles.json"))
+	assert.Nil(t, err)
+	configMaps, err := secretscan.ParseConfigMaps(contents)
+	assert.Nil(t, err)
+	if assert.Len(t, configMaps, 2) {
+		assert.Empty(t, configMaps[0].Scan())
+		assert.Empty(t, configMaps[1].Scan())
+	}
+}
+
+func TestConfigMapNames(t *testing.T) {
+	assert.Equal(t, []string{"app-config"}, loadPod(t, "credentials_pod").ConfigMapNames())
+	assert.Equal(t, []string{"app-config", "kube-root-ca.crt", "openshift-service-ca.crt"},
+		loadPod(t, "clean_pod").ConfigMapNames())
+	// The CA bundles of the cluster, mounted by the kube-api-access volume, are not scanned.
+	assert.Equal(t, []string{"app-config"}, secretscan.ConfigMapNames(loadPod(t, "clean_pod")))
+}
@@ -0,0 +1,211 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package secretscan
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Detectors which are not patterns of well-known keys and tokens.
+const (
+	// DetectorSensitiveName flags a literal value assigned to a name which suggests a credential.
+	DetectorSensitiveName = "sensitive name"
+	// DetectorHighEntropy flags a string which looks randomly generated.
+	DetectorHighEntropy = "high entropy string"
+)
+
+const (
+	// Remediation states how to avoid exposing credentials.
+	Remediation = "store the value in a Secret, and reference it with valueFrom.secretKeyRef, envFrom.secretRef or a " +
+		"secret volume instead"
+
+	// minEntropyLength is the length from which a string may be flagged for its entropy.
+	minEntropyLength = 20
+	// minEntropy is the Shannon entropy, in bits per character, from which a string is flagged.
+	minEntropy = 4.0
+	// minRedactedPrefixLength is the length from which the first characters of a value are kept when redacting it.
+	minRedactedPrefixLength = 12
+	redactedPrefixLength    = 4
+	redactionMask           = "****"
+)
+
+// pattern matches a well-known key or token.
+type pattern struct {
+	name  string
+	regex *regexp.Regexp
+}
+
+var (
+	// patterns match well-known keys and tokens, which are flagged wherever they are found.
+	patterns = []pattern{
+		{"private key", regexp.MustCompile(`-----BEGIN (?:[A-Z]+ )*PRIVATE KEY-----(?s:.*?)(?:-----END (?:[A-Z]+ )*PRIVATE KEY-----|$)`)},
+		{"AWS access key ID", regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+		{"GitHub token", regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`)},
+		{"Slack token", regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9-]{10,}`)},
+		{"Google API key", regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}`)},
+		{"JSON web token", regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{8,}\.eyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}`)},
+		{"URL credentials", regexp.MustCompile(`[a-z][a-z0-9+.-]*://[^/\s:@]+:([^/\s@]+)@`)},
+	}
+
+	// certificate matches a PEM certificate, which is public and would otherwise be flagged for its entropy.
+	certificate = regexp.MustCompile(`-----BEGIN (?:[A-Z0-9]+ )*CERTIFICATE(?: [A-Z]+)*-----(?s:.*?)-----END (?:[A-Z0-9]+ )*CERTIFICATE(?: [A-Z]+)*-----`)
+
+	// sensitiveName matches the names which suggest a credential.
+	sensitiveName = regexp.MustCompile(`(?i)passw(?:or)?d|\bpwd\b|secret|token|api[_.-]?key|access[_.-]?key|` +
+		`private[_.-]?key|credential`)
+	// assignment matches a name assigned a value in free text, as in shell commands, properties, YAML or JSON.
+	assignment = regexp.MustCompile(`([A-Za-z0-9_.-]+)"?\s*[:=]\s*"?([^\s"',;]+)`)
+	// nonSecretValue matches the values which are not credentials although assigned to a sensitive name: numbers,
+	// booleans, paths and references to other variables.
+	nonSecretValue = regexp.MustCompile(`(?i)^(?:\d+[smhd]?|true|false|yes|no|null|none|/.*|\$\(.*\)|\$\{.*\}|\$[A-Z_]+)$`)
+	// tokenSeparators split free text into the strings checked for their entropy.
+	tokenSeparators = regexp.MustCompile(`[\s"'=:,;]+`)
+	// encodedToken matches the strings in base64 or hex, which excludes host names, image references and paths.
+	encodedToken = regexp.MustCompile(`^[A-Za-z0-9+_-][A-Za-z0-9+/_-]*={0,2}$`)
+	// hasDigit and hasLetter keep the entropy check to strings which mix digits and letters.
+	hasDigit  = regexp.MustCompile(`[0-9]`)
+	hasLetter = regexp.MustCompile(`[A-Za-z]`)
+)
+
+// Finding is a likely credential.
+type Finding struct {
+	// Location names where the value was found, such as `container app env DB_PASSWORD`.
+	Location string
+	// Detector names the pattern which matched, or is one of DetectorSensitiveName and DetectorHighEntropy.
+	Detector string
+	// Redacted is the value, with all but its first characters masked.
+	Redacted string
+}
+
+// String describes the finding, with its value redacted.
+func (f *Finding) String() string {
+	return fmt.Sprintf("%s: %s %s", f.Location, f.Detector, f.Redacted)
+}
+
+// Redact masks `value`, keeping its first characters when it is long enough for them not to give it away.
+func Redact(value string) string {
+	if len(value) < minRedactedPrefixLength {
+		return redactionMask
+	}
+	return value[:redactedPrefixLength] + redactionMask
+}
+
+// ScanValue returns the likely credentials in `value`, found at `location`.  `name` is the name the value is
+// assigned to, such as the name of an environment variable, and may be empty.  Each part of the value is flagged at
+// most once: by a pattern, else for the name it is assigned to, else for its entropy.  PEM certificates are left out.
+func ScanValue(location, name, value string) []Finding {
+	scan := &valueScan{location: location}
+	value = certificate.ReplaceAllString(value, "\n")
+	for _, p := range patterns {
+		for _, match := range p.regex.FindAllStringSubmatch(value, -1) {
+			// Patterns with a group flag the group, such as the password of a URL, rather than the whole match.
+			scan.add(p.name, match[len(match)-1])
+		}
+	}
+	if name != "" && sensitiveName.MatchString(name) && isSecretValue(value) {
+		scan.add(DetectorSensitiveName, value)
+	}
+	for _, match := range assignment.FindAllStringSubmatch(value, -1) {
+		if sensitiveName.MatchString(match[1]) && isSecretValue(match[2]) {
+			scan.add(DetectorSensitiveName, match[2])
+		}
+	}
+	for _, token := range tokenSeparators.Split(value, -1) {
+		if len(token) >= minEntropyLength && encodedToken.MatchString(token) && hasDigit.MatchString(token) &&
+			hasLetter.MatchString(token) && entropy(token) >= minEntropy {
+			scan.add(DetectorHighEntropy, token)
+		}
+	}
+	return scan.findings
+}
+
+// valueScan collects the findings in a value, flagging each part of it at most once.
+type valueScan struct {
+	location string
+	flagged  []string
+	findings []Finding
+}
+
+// add records a finding for `secret`, unless it overlaps an already flagged string.
+func (s *valueScan) add(detector, secret string) {
+	for _, flagged := range s.flagged {
+		if strings.Contains(flagged, secret) || strings.Contains(secret, flagged) {
+			return
+		}
+	}
+	s.flagged = append(s.flagged, secret)
+	s.findings = append(s.findings, Finding{Location: s.location, Detector: detector, Redacted: Redact(secret)})
+}
+
+// ScanArgs returns the likely credentials in the command line `args`, found at `location`.  The value of an option
+// whose name suggests a credential, as in `--password=value` or `--password value`, is flagged.
+func ScanArgs(location string, args []string) []Finding {
+	var findings []Finding
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") || !sensitiveName.MatchString(arg) || strings.Contains(arg, "=") {
+			findings = append(findings, ScanValue(location, "", arg)...)
+			continue
+		}
+		// An option whose value is the next argument.
+		if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+			findings = append(findings, ScanValue(location, arg, args[i+1])...)
+			i++
+		}
+	}
+	return findings
+}
+
+// ScanData returns the likely credentials in the values of `data`, such as the data of a ConfigMap, found at
+// `location`, in the order of the keys.
+func ScanData(location string, data map[string]string) []Finding {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var findings []Finding
+	for _, key := range keys {
+		findings = append(findings, ScanValue(fmt.Sprintf("%s key %s", location, key), key, data[key])...)
+	}
+	return findings
+}
+
+// isSecretValue returns true if `value`, assigned to a sensitive name, may be a credential.
+func isSecretValue(value string) bool {
+	value = strings.TrimSpace(value)
+	return value != "" && !nonSecretValue.MatchString(value)
+}
+
+// entropy returns the Shannon entropy of `value`, in bits per character.
+func entropy(value string) float64 {
+	counts := make(map[rune]int)
+	for _, r := range value {
+		counts[r]++
+	}
+	length := float64(len([]rune(value)))
+	result := 0.0
+	for _, count := range counts {
+		p := float64(count) / length
+		result -= p * math.Log2(p)
+	}
+	return result
+}
@@ -47,6 +47,9 @@ const (
 	// JqBinaryName is the name of the Unix `jq` command.
 	JqBinaryName = "jq"
 
+	// NcBinaryName is the name of the Unix `nc` command.
+	NcBinaryName = "nc"
+
 	// OcBinaryName is the name of the OpenShift CLI client command.
 	OcBinaryName = "oc"
 
@@ -59,15 +62,27 @@ const (
 	// PingBinaryName is the name of the Unix `ping` command.
 	PingBinaryName = "ping"
 
+	// TimeoutBinaryName is the name of the Unix `timeout` command.
+	TimeoutBinaryName = "timeout"
+
 	// XargsBinaryName is the name of the Unix `xargs` command.
 	XargsBinaryName = "xargs"
 
 	// CgroupProcfsPath is the path to the psuedofile in procfs that contains the list of cgroups
 	CgroupProcfsPath = "/proc/self/cgroup"
 
+	// DigBinaryName is the name of the DNS lookup utility `dig`.
+	DigBinaryName = "dig"
+
 	// EchoBinaryName is the name of the Unix `echo` command
 	EchoBinaryName = "echo"
 
 	// WcBinaryName is the name of the Unix `wc` command
 	WcBinaryName = "wc"
+
+	// Iperf3BinaryName is the name of the network throughput measurement tool `iperf3`.
+	Iperf3BinaryName = "iperf3"
+
+	// ReadlinkBinaryName is the name of the Unix `readlink` command.
+	ReadlinkBinaryName = "readlink"
 )
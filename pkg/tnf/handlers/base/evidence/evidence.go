@@ -0,0 +1,135 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package evidence
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/baseimage"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	// ExitStatusRegex matches the exit status of the evidence listing, which follows the evidence.
+	ExitStatusRegex = `(?m)^BASEIMAGE_EXIT_STATUS=(\d+)\r?$`
+)
+
+var (
+	// Command lists the lines of /etc/os-release, the files of /root/buildinfo, the labels of
+	// /root/buildinfo/labels.json on a single line and the package managers in the PATH, each as a tab separated line.
+	Command = strings.Join([]string{
+		`(cat /etc/os-release || cat /usr/lib/os-release) 2>/dev/null |`,
+		`while IFS= read -r l; do printf 'os-release\t%s\n' "$l"; done;`,
+		`ls /root/buildinfo 2>/dev/null | while IFS= read -r f; do printf 'buildinfo\t%s\n' "$f"; done;`,
+		`if [ -f /root/buildinfo/labels.json ]; then`,
+		`printf 'labels\t%s\n' "$(tr -d '\n' < /root/buildinfo/labels.json)"; fi;`,
+		`for m in ` + strings.Join(baseimage.Managers, " ") + `; do`,
+		`if command -v $m >/dev/null 2>&1; then printf 'manager\t%s\n' $m; fi; done;`,
+		`echo BASEIMAGE_EXIT_STATUS=$?`,
+	}, " ")
+)
+
+// Evidence collects what a container reveals about its base image.
+type Evidence struct {
+	result   int
+	timeout  time.Duration
+	args     []string
+	evidence *baseimage.Evidence
+	err      string
+}
+
+// NewEvidence creates a new `Evidence` test which collects the evidence of the base image of the container it runs in.
+func NewEvidence(timeout time.Duration) *Evidence {
+	return &Evidence{
+		result:  tnf.ERROR,
+		timeout: timeout,
+		args:    strings.Split(Command, " "),
+	}
+}
+
+// Args returns the command line args for the test.
+func (e *Evidence) Args() []string {
+	return e.args
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (e *Evidence) GetIdentifier() identifier.Identifier {
+	return identifier.BaseImageEvidenceIdentifier
+}
+
+// Timeout returns the timeout in seconds for the test.
+func (e *Evidence) Timeout() time.Duration {
+	return e.timeout
+}
+
+// Result returns the test result.
+func (e *Evidence) Result() int {
+	return e.result
+}
+
+// ReelFirst returns a step which expects the exit status of the evidence listing within the test timeout.
+func (e *Evidence) ReelFirst() *reel.Step {
+	return &reel.Step{
+		Expect:  []string{ExitStatusRegex},
+		Timeout: e.timeout,
+	}
+}
+
+// ReelMatch parses the evidence, which precedes the exit status.  The result is success if it was listed, even if the
+// container has no /etc/os-release, and failure otherwise.
+// Returns no step; the test is complete.
+func (e *Evidence) ReelMatch(_, before, match string) *reel.Step {
+	e.result = tnf.FAILURE
+	matched := regexp.MustCompile(ExitStatusRegex).FindStringSubmatch(match)
+	if matched == nil {
+		return nil
+	}
+	// Ignore errors in converting matches to decimal integers, as the regular expression only matches digits.
+	status, _ := strconv.Atoi(matched[1])
+	if status != 0 {
+		e.err = fmt.Sprintf("the base image evidence could not be listed, exit status %d", status)
+		return nil
+	}
+	e.evidence = baseimage.ParseEvidence(before)
+	e.result = tnf.SUCCESS
+	return nil
+}
+
+// ReelTimeout does nothing;  no intervention is needed for a timeout.
+func (e *Evidence) ReelTimeout() *reel.Step {
+	return nil
+}
+
+// ReelEOF does nothing;  no intervention is needed for EOF.
+func (e *Evidence) ReelEOF() {
+}
+
+// GetEvidence returns the evidence of the base image of the container, or nil if it could not be listed.
+func (e *Evidence) GetEvidence() *baseimage.Evidence {
+	return e.evidence
+}
+
+// GetError returns why the evidence could not be listed, or an empty string.
+func (e *Evidence) GetError() string {
+	return e.err
+}
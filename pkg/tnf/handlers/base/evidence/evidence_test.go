@@ -0,0 +1,86 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package evidence_test
+
+import (
+	"io/ioutil"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/base/evidence"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 2
+)
+
+func getMockOutput(t *testing.T, name string) string {
+	b, err := ioutil.ReadFile(path.Join("testdata", name+".txt"))
+	assert.Nil(t, err)
+	return string(b)
+}
+
+func TestNewEvidence(t *testing.T) {
+	handler := evidence.NewEvidence(testTimeoutDuration)
+	assert.Equal(t, tnf.ERROR, handler.Result())
+	assert.Equal(t, testTimeoutDuration, handler.Timeout())
+	assert.Equal(t, identifier.BaseImageEvidenceIdentifier, handler.GetIdentifier())
+	assert.Equal(t, evidence.Command, strings.Join(handler.Args(), " "))
+	assert.Contains(t, evidence.Command, "for m in dnf yum microdnf rpm apt-get dpkg apk zypper;")
+	assert.Equal(t, []string{evidence.ExitStatusRegex}, handler.ReelFirst().Expect)
+	assert.Nil(t, handler.ReelTimeout())
+	handler.ReelEOF()
+}
+
+func TestEvidence_ReelMatch(t *testing.T) {
+	testCases := []struct {
+		output    string
+		osID      string
+		buildInfo []string
+		component string
+		managers  []string
+	}{
+		{"ubi8-minimal", "rhel", []string{"Dockerfile-ubi8-minimal-8.5-218", "content_manifests", "labels.json"},
+			"ubi8-minimal-container", []string{"microdnf", "rpm"}},
+		{"debian", "debian", nil, "", []string{"apt-get", "dpkg"}},
+		{"none", "", nil, "", nil},
+	}
+	for _, testCase := range testCases {
+		handler := evidence.NewEvidence(testTimeoutDuration)
+		assert.Nil(t, handler.ReelMatch(evidence.ExitStatusRegex, getMockOutput(t, testCase.output), "BASEIMAGE_EXIT_STATUS=0"))
+		assert.Equal(t, tnf.SUCCESS, handler.Result())
+		assert.Equal(t, "", handler.GetError())
+		e := handler.GetEvidence()
+		assert.Equal(t, testCase.osID, e.OSRelease["ID"], testCase.output)
+		assert.Equal(t, testCase.buildInfo, e.BuildInfo, testCase.output)
+		assert.Equal(t, testCase.component, e.Labels["com.redhat.component"], testCase.output)
+		assert.Equal(t, testCase.managers, e.Managers, testCase.output)
+	}
+}
+
+func TestEvidence_ReelMatchFailure(t *testing.T) {
+	handler := evidence.NewEvidence(testTimeoutDuration)
+	assert.Nil(t, handler.ReelMatch(evidence.ExitStatusRegex, "sh: syntax error\r\n", "BASEIMAGE_EXIT_STATUS=2"))
+	assert.Equal(t, tnf.FAILURE, handler.Result())
+	assert.Nil(t, handler.GetEvidence())
+	assert.Equal(t, "the base image evidence could not be listed, exit status 2", handler.GetError())
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configmaps
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/secretscan"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/ocjson"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+// ConfigMaps fetches the ConfigMaps of a namespace.
+type ConfigMaps struct {
+	*ocjson.OcJSON
+	configMaps []secretscan.ConfigMap
+}
+
+// NewConfigMaps creates a new `ConfigMaps` test which fetches the ConfigMaps `names` of `namespace`.  ConfigMaps which
+// do not exist are ignored.
+func NewConfigMaps(timeout time.Duration, namespace string, names []string) *ConfigMaps {
+	var conditions []string
+	for _, name := range names {
+		conditions = append(conditions, ".metadata.name == "+strconv.Quote(name))
+	}
+	selector := strings.Join(conditions, " or ")
+	if selector == "" {
+		selector = "false"
+	}
+	filter := fmt.Sprintf("'{items: [.items[] | select(%s) | {metadata: {name: .metadata.name}, data}]}'", selector)
+	c := &ConfigMaps{}
+	c.OcJSON = ocjson.NewOcJSON(timeout, identifier.ConfigMapsIdentifier, "the ConfigMaps",
+		[]string{dependencies.OcBinaryName, "get", "configmaps", "-n", namespace, "-o", "json", "|",
+			dependencies.JqBinaryName, "-c", filter},
+		func(data []byte) (err error) {
+			c.configMaps, err = secretscan.ParseConfigMaps(data)
+			return err
+		})
+	return c
+}
+
+// GetConfigMaps returns the fetched ConfigMaps.
+func (c *ConfigMaps) GetConfigMaps() []secretscan.ConfigMap {
+	return c.configMaps
+}
@@ -0,0 +1,86 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configmaps_test
+
+import (
+	"io/ioutil"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/configmaps"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/ocjson"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 2
+)
+
+func getMockOutput(t *testing.T, name string) string {
+	b, err := ioutil.ReadFile(path.Join("testdata", name+".txt"))
+	assert.Nil(t, err)
+	return string(b)
+}
+
+func TestNewConfigMaps(t *testing.T) {
+	handler := configmaps.NewConfigMaps(testTimeoutDuration, "tnf", []string{"app-config", "tls"})
+	assert.Equal(t, tnf.ERROR, handler.Result())
+	assert.Equal(t, testTimeoutDuration, handler.Timeout())
+	assert.Equal(t, identifier.ConfigMapsIdentifier, handler.GetIdentifier())
+	assert.Equal(t, `oc get configmaps -n tnf -o json | jq -c '{items: [.items[] | `+
+		`select(.metadata.name == "app-config" or .metadata.name == "tls") | {metadata: {name: .metadata.name}, data}]}' ; `+
+		`echo JSON_EXIT_STATUS=$?`, strings.Join(handler.Args(), " "))
+	assert.Equal(t, []string{ocjson.ExitStatusRegex}, handler.ReelFirst().Expect)
+	assert.Nil(t, handler.ReelTimeout())
+	handler.ReelEOF()
+
+	handler = configmaps.NewConfigMaps(testTimeoutDuration, "tnf", nil)
+	assert.Contains(t, strings.Join(handler.Args(), " "), "select(false)")
+}
+
+func TestConfigMaps_ReelMatch(t *testing.T) {
+	handler := configmaps.NewConfigMaps(testTimeoutDuration, "tnf", []string{"app-config"})
+	assert.Nil(t, handler.ReelMatch(ocjson.ExitStatusRegex, getMockOutput(t, "configmaps"), "JSON_EXIT_STATUS=0"))
+	assert.Equal(t, tnf.SUCCESS, handler.Result())
+	assert.Equal(t, "", handler.GetError())
+	configMaps := handler.GetConfigMaps()
+	if assert.Len(t, configMaps, 1) {
+		assert.Equal(t, "app-config", configMaps[0].Metadata.Name)
+		assert.Equal(t, "info", configMaps[0].Data["LOG_LEVEL"])
+		assert.Len(t, configMaps[0].Scan(), 1)
+	}
+}
+
+func TestConfigMaps_ReelMatchForbidden(t *testing.T) {
+	handler := configmaps.NewConfigMaps(testTimeoutDuration, "tnf", []string{"app-config"})
+	// jq outputs nothing when oc fails, and exits successfully.
+	assert.Nil(t, handler.ReelMatch(ocjson.ExitStatusRegex, getMockOutput(t, "forbidden"), "JSON_EXIT_STATUS=0"))
+	assert.Equal(t, tnf.FAILURE, handler.Result())
+	assert.Nil(t, handler.GetConfigMaps())
+	assert.Equal(t, "the ConfigMaps could not be fetched, exit status 0", handler.GetError())
+}
+
+func TestConfigMaps_ReelMatchInvalid(t *testing.T) {
+	handler := configmaps.NewConfigMaps(testTimeoutDuration, "tnf", []string{"app-config"})
+	assert.Nil(t, handler.ReelMatch(ocjson.ExitStatusRegex, "jq\n{\"items\": 1}\n", "JSON_EXIT_STATUS=0"))
+	assert.Equal(t, tnf.FAILURE, handler.Result())
+	assert.Contains(t, handler.GetError(), "unable to parse the ConfigMaps")
+}
@@ -0,0 +1,168 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package dnslookup
+
+import (
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	// RecordTypeA is the type of IPv4 address records.
+	RecordTypeA = "A"
+	// RecordTypeAAAA is the type of IPv6 address records.
+	RecordTypeAAAA = "AAAA"
+	// RecordTypeSRV is the type of service records.
+	RecordTypeSRV = "SRV"
+
+	// AnswerRegex matches the answer of `dig`, between the markers echoed around it, along with its exit status.
+	AnswerRegex = `(?ms)^DNS_ANSWER_BEGIN\r?$(.*?)^DNS_ANSWER_END=(\d+)\r?$`
+
+	answerBeginMarker = "DNS_ANSWER_BEGIN"
+	answerEndMarker   = "DNS_ANSWER_END=$?"
+)
+
+var (
+	// srvRecordRegex matches a SRV record as printed by `dig +short`: priority, weight, port and target.
+	srvRecordRegex = regexp.MustCompile(`^(\d+)\s+(\d+)\s+(\d+)\s+(\S+)$`)
+)
+
+// SRVRecord is a service record.
+type SRVRecord struct {
+	Priority int
+	Weight   int
+	Port     int
+	Target   string
+}
+
+// DNSLookup resolves a DNS name, using command line tool `dig`.
+type DNSLookup struct {
+	result     int
+	timeout    time.Duration
+	args       []string
+	recordType string
+	addresses  []string
+	srvRecords []SRVRecord
+}
+
+// NewDNSLookup creates a new `DNSLookup` test which resolves the `recordType` records of `name`.
+func NewDNSLookup(timeout time.Duration, recordType, name string) *DNSLookup {
+	return &DNSLookup{
+		result:     tnf.ERROR,
+		timeout:    timeout,
+		recordType: recordType,
+		args: []string{dependencies.EchoBinaryName, answerBeginMarker, ";", dependencies.DigBinaryName, "+short",
+			recordType, name, ";", dependencies.EchoBinaryName, answerEndMarker},
+	}
+}
+
+// Args returns the command line args for the test.
+func (d *DNSLookup) Args() []string {
+	return d.args
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (d *DNSLookup) GetIdentifier() identifier.Identifier {
+	return identifier.DNSLookupIdentifier
+}
+
+// Timeout returns the timeout in seconds for the test.
+func (d *DNSLookup) Timeout() time.Duration {
+	return d.timeout
+}
+
+// Result returns the test result.
+func (d *DNSLookup) Result() int {
+	return d.result
+}
+
+// ReelFirst returns a step which expects the answer within the test timeout.
+func (d *DNSLookup) ReelFirst() *reel.Step {
+	return &reel.Step{
+		Expect:  []string{AnswerRegex},
+		Timeout: d.timeout,
+	}
+}
+
+// ReelMatch parses the answer.  The result is success when `dig` got an answer, even an empty one, and error when no
+// name server could be reached.
+// Returns no step; the test is complete.
+func (d *DNSLookup) ReelMatch(_, _, match string) *reel.Step {
+	matched := regexp.MustCompile(AnswerRegex).FindStringSubmatch(match)
+	if matched == nil || matched[2] != "0" {
+		return nil
+	}
+	for _, line := range strings.Split(matched[1], "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		switch d.recordType {
+		case RecordTypeSRV:
+			if record, ok := parseSRVRecord(line); ok {
+				d.srvRecords = append(d.srvRecords, record)
+			}
+		case RecordTypeA, RecordTypeAAAA:
+			// Canonical names the address records were found through end with a dot, and are not IPs.
+			if ip := net.ParseIP(line); ip != nil && (ip.To4() != nil) == (d.recordType == RecordTypeA) {
+				d.addresses = append(d.addresses, ip.String())
+			}
+		}
+	}
+	sort.Strings(d.addresses)
+	d.result = tnf.SUCCESS
+	return nil
+}
+
+// ReelTimeout does nothing;  no intervention is needed for a timeout.
+func (d *DNSLookup) ReelTimeout() *reel.Step {
+	return nil
+}
+
+// ReelEOF does nothing;  no intervention is needed for EOF.
+func (d *DNSLookup) ReelEOF() {
+}
+
+// GetAddresses returns the resolved IPs of an A or AAAA lookup, sorted.
+func (d *DNSLookup) GetAddresses() []string {
+	return d.addresses
+}
+
+// GetSRVRecords returns the resolved records of a SRV lookup.
+func (d *DNSLookup) GetSRVRecords() []SRVRecord {
+	return d.srvRecords
+}
+
+func parseSRVRecord(line string) (SRVRecord, bool) {
+	matched := srvRecordRegex.FindStringSubmatch(line)
+	if matched == nil {
+		return SRVRecord{}, false
+	}
+	priority, _ := strconv.Atoi(matched[1])
+	weight, _ := strconv.Atoi(matched[2])
+	port, _ := strconv.Atoi(matched[3])
+	return SRVRecord{Priority: priority, Weight: weight, Port: port, Target: matched[4]}, true
+}
@@ -0,0 +1,86 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package dnslookup_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/dnslookup"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 2
+	testName            = "test-http.tnf.svc.cluster.local"
+)
+
+func run(t *testing.T, lookup *dnslookup.DNSLookup, output string) {
+	expect := lookup.ReelFirst().Expect[0]
+	match := regexp.MustCompile(expect).FindString(output)
+	assert.Nil(t, lookup.ReelMatch(expect, "", match))
+}
+
+func TestNewDNSLookup(t *testing.T) {
+	lookup := dnslookup.NewDNSLookup(testTimeoutDuration, dnslookup.RecordTypeA, testName)
+	assert.Equal(t, tnf.ERROR, lookup.Result())
+	assert.Equal(t, testTimeoutDuration, lookup.Timeout())
+	assert.Equal(t, identifier.DNSLookupIdentifier, lookup.GetIdentifier())
+	assert.Equal(t, "echo DNS_ANSWER_BEGIN ; dig +short A test-http.tnf.svc.cluster.local ; echo DNS_ANSWER_END=$?",
+		strings.Join(lookup.Args(), " "))
+	assert.Nil(t, lookup.ReelTimeout())
+	lookup.ReelEOF()
+}
+
+func TestDNSLookup_Addresses(t *testing.T) {
+	output := "sh-4.4$ echo DNS_ANSWER_BEGIN ; dig +short A x ; echo DNS_ANSWER_END=$?\nDNS_ANSWER_BEGIN\n" +
+		"alias.tnf.svc.cluster.local.\n10.217.1.90\n10.217.1.89\nfd00:10::59\nDNS_ANSWER_END=0\n"
+	lookup := dnslookup.NewDNSLookup(testTimeoutDuration, dnslookup.RecordTypeA, testName)
+	run(t, lookup, output)
+	assert.Equal(t, tnf.SUCCESS, lookup.Result())
+	assert.Equal(t, []string{"10.217.1.89", "10.217.1.90"}, lookup.GetAddresses())
+
+	lookup = dnslookup.NewDNSLookup(testTimeoutDuration, dnslookup.RecordTypeAAAA, testName)
+	run(t, lookup, output)
+	assert.Equal(t, tnf.SUCCESS, lookup.Result())
+	assert.Equal(t, []string{"fd00:10::59"}, lookup.GetAddresses())
+}
+
+func TestDNSLookup_EmptyAnswer(t *testing.T) {
+	lookup := dnslookup.NewDNSLookup(testTimeoutDuration, dnslookup.RecordTypeAAAA, testName)
+	run(t, lookup, "DNS_ANSWER_BEGIN\nDNS_ANSWER_END=0\n")
+	assert.Equal(t, tnf.SUCCESS, lookup.Result())
+	assert.Empty(t, lookup.GetAddresses())
+}
+
+func TestDNSLookup_NoServer(t *testing.T) {
+	lookup := dnslookup.NewDNSLookup(testTimeoutDuration, dnslookup.RecordTypeA, testName)
+	run(t, lookup, "DNS_ANSWER_BEGIN\n;; connection timed out; no servers could be reached\nDNS_ANSWER_END=9\n")
+	assert.Equal(t, tnf.ERROR, lookup.Result())
+}
+
+func TestDNSLookup_SRVRecords(t *testing.T) {
+	lookup := dnslookup.NewDNSLookup(testTimeoutDuration, dnslookup.RecordTypeSRV, "_web._tcp."+testName)
+	run(t, lookup, "DNS_ANSWER_BEGIN\r\n0 100 80 test-http.tnf.svc.cluster.local.\r\nDNS_ANSWER_END=0\r\n")
+	assert.Equal(t, tnf.SUCCESS, lookup.Result())
+	assert.Equal(t, []dnslookup.SRVRecord{{Priority: 0, Weight: 100, Port: 80, Target: "test-http.tnf.svc.cluster.local."}},
+		lookup.GetSRVRecords())
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package imagelabels
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/ocjson"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	// Platform selects the image of a manifest list whose labels are fetched.  The images of a manifest list are built
+	// from the same base image on each architecture, so any of them will do.
+	Platform = "linux/amd64"
+)
+
+// ImageLabels fetches the labels of the config of an image.
+type ImageLabels struct {
+	*ocjson.OcJSON
+	labels map[string]string
+}
+
+// NewImageLabels creates a new `ImageLabels` test which fetches the labels of the config of `image`, which is best
+// given by digest, as in the image ID of a container status.  An image without labels has none.
+func NewImageLabels(timeout time.Duration, image string) *ImageLabels {
+	i := &ImageLabels{}
+	i.OcJSON = ocjson.NewOcJSON(timeout, identifier.ImageLabelsIdentifier, "the image labels",
+		[]string{dependencies.OcBinaryName, "image", "info", "-o", "json", "--filter-by-os=" + Platform, image, "|",
+			dependencies.JqBinaryName, "-c", "'{labels: (.config.config.Labels // {})}'"},
+		func(data []byte) error {
+			var config struct {
+				Labels map[string]string `json:"labels"`
+			}
+			if err := json.Unmarshal(data, &config); err != nil {
+				return err
+			}
+			i.labels = config.Labels
+			return nil
+		})
+	return i
+}
+
+// GetLabels returns the labels of the image config.
+func (i *ImageLabels) GetLabels() map[string]string {
+	return i.labels
+}
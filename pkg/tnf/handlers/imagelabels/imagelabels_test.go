@@ -0,0 +1,80 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package imagelabels_test
+
+import (
+	"io/ioutil"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/imagelabels"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/ocjson"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 2
+	testImage           = "quay.io/testnetworkfunction/cnf-test-partner@sha256:5d7b9f7a6c9e2b1f3a8d4c0e6f1b2a3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f90"
+)
+
+func getMockOutput(t *testing.T, name string) string {
+	b, err := ioutil.ReadFile(path.Join("testdata", name+".txt"))
+	assert.Nil(t, err)
+	return string(b)
+}
+
+func TestNewImageLabels(t *testing.T) {
+	handler := imagelabels.NewImageLabels(testTimeoutDuration, testImage)
+	assert.Equal(t, tnf.ERROR, handler.Result())
+	assert.Equal(t, testTimeoutDuration, handler.Timeout())
+	assert.Equal(t, identifier.ImageLabelsIdentifier, handler.GetIdentifier())
+	assert.Equal(t, "oc image info -o json --filter-by-os=linux/amd64 "+testImage+
+		" | jq -c '{labels: (.config.config.Labels // {})}' ; echo JSON_EXIT_STATUS=$?", strings.Join(handler.Args(), " "))
+	assert.Equal(t, []string{ocjson.ExitStatusRegex}, handler.ReelFirst().Expect)
+	assert.Nil(t, handler.ReelTimeout())
+	handler.ReelEOF()
+}
+
+func TestImageLabels_ReelMatch(t *testing.T) {
+	handler := imagelabels.NewImageLabels(testTimeoutDuration, testImage)
+	assert.Nil(t, handler.ReelMatch(ocjson.ExitStatusRegex, getMockOutput(t, "labels"), "JSON_EXIT_STATUS=0"))
+	assert.Equal(t, tnf.SUCCESS, handler.Result())
+	assert.Equal(t, "", handler.GetError())
+	labels := handler.GetLabels()
+	assert.Equal(t, "cnf-test-partner-container", labels["com.redhat.component"])
+	assert.Equal(t, "Red Hat, Inc.", labels["vendor"])
+}
+
+func TestImageLabels_ReelMatchUnauthorized(t *testing.T) {
+	handler := imagelabels.NewImageLabels(testTimeoutDuration, testImage)
+	// jq outputs nothing when oc fails, and exits successfully.
+	assert.Nil(t, handler.ReelMatch(ocjson.ExitStatusRegex, getMockOutput(t, "unauthorized"), "JSON_EXIT_STATUS=0"))
+	assert.Equal(t, tnf.FAILURE, handler.Result())
+	assert.Nil(t, handler.GetLabels())
+	assert.Equal(t, "the image labels could not be fetched, exit status 0", handler.GetError())
+}
+
+func TestImageLabels_ReelMatchInvalid(t *testing.T) {
+	handler := imagelabels.NewImageLabels(testTimeoutDuration, testImage)
+	assert.Nil(t, handler.ReelMatch(ocjson.ExitStatusRegex, "jq\n{\"labels\": 1}\n", "JSON_EXIT_STATUS=0"))
+	assert.Equal(t, tnf.FAILURE, handler.Result())
+	assert.Contains(t, handler.GetError(), "unable to parse the image labels")
+}
@@ -35,6 +35,8 @@ type IPAddr struct {
 	args    []string
 	// The ipv4 address for a given device if the Handler matches.
 	ipv4Address string
+	// The global scope ipv6 address for a given device if the Handler matches.
+	ipv6Address string
 }
 
 const (
@@ -42,6 +44,9 @@ const (
 	DeviceDoesNotExistRegex = `(?m)Device \"(\w+)\" does not exist.$`
 	// SuccessfulOutputRegex matches `ip addr` output for a given device, and provides grouping to extract the associated Ipv4 address.
 	SuccessfulOutputRegex = `(?m)^\s+inet ((25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?))`
+	// SuccessfulIPv6OutputRegex matches `ip addr` output for a given device which has a global scope Ipv6 address, and
+	// provides grouping to extract it.  Link local addresses are not reachable from other pods, so are not matched.
+	SuccessfulIPv6OutputRegex = `(?m)^\s+inet6 ([0-9a-fA-F:]+)/\d+ scope global`
 )
 
 var (
@@ -69,15 +74,17 @@ func (i *IPAddr) Result() int {
 	return i.result
 }
 
-// ReelFirst returns a step which expects an ip summary for the given device.
+// ReelFirst returns a step which expects an ip summary for the given device.  The Ipv4 address is expected first, as
+// `ip addr` lists it before any Ipv6 address, so that the match of a dual-stack device contains both.
 func (i *IPAddr) ReelFirst() *reel.Step {
 	return &reel.Step{
-		Expect:  []string{SuccessfulOutputRegex, DeviceDoesNotExistRegex},
+		Expect:  []string{SuccessfulOutputRegex, SuccessfulIPv6OutputRegex, DeviceDoesNotExistRegex},
 		Timeout: i.timeout,
 	}
 }
 
-// ReelMatch parses the ip addr output and set the test result on match.
+// ReelMatch parses the ip addr output and set the test result on match.  The test succeeds when the device has either
+// an Ipv4 or a global scope Ipv6 address.
 // Returns no step; the test is complete.
 func (i *IPAddr) ReelMatch(pattern, _, match string) *reel.Step {
 	if pattern == DeviceDoesNotExistRegex {
@@ -90,6 +97,12 @@ func (i *IPAddr) ReelMatch(pattern, _, match string) *reel.Step {
 		i.ipv4Address = matched[1]
 		i.result = tnf.SUCCESS
 	}
+	re = regexp.MustCompile(SuccessfulIPv6OutputRegex)
+	matched = re.FindStringSubmatch(match)
+	if matched != nil {
+		i.ipv6Address = matched[1]
+		i.result = tnf.SUCCESS
+	}
 	return nil
 }
 
@@ -107,6 +120,12 @@ func (i *IPAddr) GetIPv4Address() string {
 	return i.ipv4Address
 }
 
+// GetIPv6Address returns the extracted global scope IPv6 address for the given device (interface), or an empty string
+// if the device has none.
+func (i *IPAddr) GetIPv6Address() string {
+	return i.ipv6Address
+}
+
 func ipAddrCmd(dev string) []string {
 	return strings.Split(fmt.Sprintf("%s %s", ipAddrCommand, dev), " ")
 }
@@ -40,6 +40,7 @@ type TestCase struct {
 	pattern             string
 	expectedResult      int
 	expectedIpv4Address string
+	expectedIpv6Address string
 }
 
 var testCases = map[string]TestCase{
@@ -48,12 +49,28 @@ var testCases = map[string]TestCase{
 		pattern:             ipaddr.SuccessfulOutputRegex,
 		expectedResult:      tnf.SUCCESS,
 		expectedIpv4Address: "172.17.0.7",
+		expectedIpv6Address: "",
+	},
+	"device_exists_dual_stack": {
+		device:              "eth0",
+		pattern:             ipaddr.SuccessfulOutputRegex,
+		expectedResult:      tnf.SUCCESS,
+		expectedIpv4Address: "10.128.0.45",
+		expectedIpv6Address: "fd01:0:0:1::2d",
+	},
+	"device_exists_ipv6_only": {
+		device:              "eth0",
+		pattern:             ipaddr.SuccessfulIPv6OutputRegex,
+		expectedResult:      tnf.SUCCESS,
+		expectedIpv4Address: "",
+		expectedIpv6Address: "fd01:0:0:1::2d",
 	},
 	"device_does_not_exist": {
 		device:              "dne",
 		pattern:             ipaddr.DeviceDoesNotExistRegex,
 		expectedResult:      tnf.ERROR,
 		expectedIpv4Address: "",
+		expectedIpv6Address: "",
 	},
 }
 
@@ -104,6 +121,7 @@ func TestIpAddr_ReelFirst(t *testing.T) {
 		step := ipAddr.ReelFirst()
 		assert.Equal(t, "", step.Execute)
 		assert.Contains(t, step.Expect, ipaddr.SuccessfulOutputRegex)
+		assert.Contains(t, step.Expect, ipaddr.SuccessfulIPv6OutputRegex)
 		assert.Equal(t, testTimeoutDuration, step.Timeout)
 	}
 }
@@ -127,6 +145,15 @@ func TestIpAddr_GetIpv4Address(t *testing.T) {
 	}
 }
 
+func TestIpAddr_GetIpv6Address(t *testing.T) {
+	for testName, testCase := range testCases {
+		ipAddr := ipaddr.NewIPAddr(testTimeoutDuration, testCase.device)
+		step := ipAddr.ReelMatch(testCase.pattern, "", getMockOutput(t, testName))
+		assert.Nil(t, step)
+		assert.Equal(t, testCase.expectedIpv6Address, ipAddr.GetIPv6Address())
+	}
+}
+
 func TestIpAddr_ReelTimeout(t *testing.T) {
 	for _, testCase := range testCases {
 		ipAddr := ipaddr.NewIPAddr(testTimeoutDuration, testCase.device)
@@ -0,0 +1,217 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package iperf3
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	// ServerStatusRegex matches the status of starting the server, which is zero when `iperf3` is installed.
+	ServerStatusRegex = `(?m)^IPERF3_SERVER_STATUS=(\d+)\r?$`
+	// ClientExitStatusRegex matches the exit status of the client, which follows its JSON report.
+	ClientExitStatusRegex = `(?m)^IPERF3_EXIT_STATUS=(\d+)\r?$`
+
+	// ProtocolTCP measures the TCP throughput.
+	ProtocolTCP = "TCP"
+	// ProtocolUDP measures the UDP throughput.
+	ProtocolUDP = "UDP"
+
+	// commandNotFoundStatus is the exit status of the shell when a command is not installed.
+	commandNotFoundStatus = 127
+	// serverStartupDelay gives the server time to listen before the status is reported.
+	serverStartupDelay = "1"
+)
+
+// Throughput is the outcome of a client run, as seen by the receiver.
+type Throughput struct {
+	// BitsPerSecond is the throughput received.
+	BitsPerSecond float64
+	// LostPercent is the share of UDP datagrams lost, always zero for TCP.
+	LostPercent float64
+	// JitterMilliseconds is the jitter of the UDP datagrams, always zero for TCP.
+	JitterMilliseconds float64
+}
+
+// report holds the parts of the `iperf3 --json` client report which are used.
+type report struct {
+	End struct {
+		SumReceived *struct {
+			BitsPerSecond float64 `json:"bits_per_second"`
+		} `json:"sum_received"`
+		Sum *struct {
+			BitsPerSecond float64 `json:"bits_per_second"`
+			JitterMs      float64 `json:"jitter_ms"`
+			LostPercent   float64 `json:"lost_percent"`
+		} `json:"sum"`
+	} `json:"end"`
+	Error string `json:"error"`
+}
+
+// Iperf3 runs one side of an `iperf3` measurement.
+type Iperf3 struct {
+	result     int
+	timeout    time.Duration
+	args       []string
+	regex      string
+	protocol   string
+	throughput Throughput
+	err        string
+}
+
+// NewServer creates a new `Iperf3` test which starts a single use server listening on `port` in the background.  The
+// server exits after serving one client, or after `lifetime` if no client connects.
+func NewServer(timeout time.Duration, port int, lifetime time.Duration) *Iperf3 {
+	server := fmt.Sprintf("(%s %d %s -s -1 -p %d >/dev/null 2>&1 &)", dependencies.TimeoutBinaryName,
+		int(lifetime.Seconds()), dependencies.Iperf3BinaryName, port)
+	return &Iperf3{
+		result:  tnf.ERROR,
+		timeout: timeout,
+		args: []string{"command", "-v", dependencies.Iperf3BinaryName, ">/dev/null", "&&", server, ";",
+			dependencies.EchoBinaryName, "IPERF3_SERVER_STATUS=$?", ";", "sleep", serverStartupDelay},
+		regex: ServerStatusRegex,
+	}
+}
+
+// NewClient creates a new `Iperf3` test which sends `protocol` traffic to the server on `port` of `host` for
+// `duration`.  UDP traffic is sent at `udpBitrate`, as given to `iperf3 --bitrate`, or at the `iperf3` default if
+// empty.  The timeout must leave room for the duration of the measurement.
+func NewClient(timeout time.Duration, host string, port int, protocol string, duration time.Duration, udpBitrate string) *Iperf3 {
+	args := []string{dependencies.Iperf3BinaryName, "-c", host, "-p", strconv.Itoa(port), "-t",
+		strconv.Itoa(int(duration.Seconds())), "-J"}
+	if protocol == ProtocolUDP {
+		args = append(args, "-u")
+		if udpBitrate != "" {
+			args = append(args, "-b", udpBitrate)
+		}
+	}
+	return &Iperf3{
+		result:   tnf.ERROR,
+		timeout:  timeout,
+		args:     append(args, ";", dependencies.EchoBinaryName, "IPERF3_EXIT_STATUS=$?"),
+		regex:    ClientExitStatusRegex,
+		protocol: protocol,
+	}
+}
+
+// Args returns the command line args for the test.
+func (i *Iperf3) Args() []string {
+	return i.args
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (i *Iperf3) GetIdentifier() identifier.Identifier {
+	return identifier.Iperf3Identifier
+}
+
+// Timeout returns the timeout in seconds for the test.
+func (i *Iperf3) Timeout() time.Duration {
+	return i.timeout
+}
+
+// Result returns the test result.
+func (i *Iperf3) Result() int {
+	return i.result
+}
+
+// ReelFirst returns a step which expects the status of the server or the client within the test timeout.
+func (i *Iperf3) ReelFirst() *reel.Step {
+	return &reel.Step{
+		Expect:  []string{i.regex},
+		Timeout: i.timeout,
+	}
+}
+
+// ReelMatch sets the test result from the exit status, and parses the JSON report of a client which precedes it.  The
+// result is success if the server started, or if the client completed its measurement, and failure otherwise.
+// Returns no step; the test is complete.
+func (i *Iperf3) ReelMatch(_, before, match string) *reel.Step {
+	matched := regexp.MustCompile(i.regex).FindStringSubmatch(match)
+	if matched == nil {
+		return nil
+	}
+	// Ignore errors in converting matches to decimal integers, as the regular expression only matches digits.
+	status, _ := strconv.Atoi(matched[1])
+	if status == commandNotFoundStatus || (i.regex == ServerStatusRegex && status != 0) {
+		i.err = fmt.Sprintf("%s is not installed", dependencies.Iperf3BinaryName)
+		i.result = tnf.FAILURE
+		return nil
+	}
+	if i.regex == ServerStatusRegex {
+		i.result = tnf.SUCCESS
+		return nil
+	}
+	i.parseReport(before, status)
+	return nil
+}
+
+// parseReport parses the JSON report of the client, found in `output`, along with its exit `status`.
+func (i *Iperf3) parseReport(output string, status int) {
+	i.result = tnf.FAILURE
+	start, end := strings.Index(output, "\n{"), strings.LastIndex(output, "}")
+	if start < 0 || end < start {
+		i.err = fmt.Sprintf("no report was produced, exit status %d", status)
+		return
+	}
+	parsed := report{}
+	if err := json.Unmarshal([]byte(output[start+1:end+1]), &parsed); err != nil {
+		i.err = fmt.Sprintf("unable to parse the report: %s", err)
+		return
+	}
+	switch {
+	case parsed.Error != "":
+		i.err = parsed.Error
+	case i.protocol == ProtocolUDP && parsed.End.Sum != nil:
+		i.throughput = Throughput{BitsPerSecond: parsed.End.Sum.BitsPerSecond, LostPercent: parsed.End.Sum.LostPercent,
+			JitterMilliseconds: parsed.End.Sum.JitterMs}
+		i.result = tnf.SUCCESS
+	case i.protocol == ProtocolTCP && parsed.End.SumReceived != nil:
+		i.throughput = Throughput{BitsPerSecond: parsed.End.SumReceived.BitsPerSecond}
+		i.result = tnf.SUCCESS
+	default:
+		i.err = "the report has no summary"
+	}
+}
+
+// ReelTimeout does nothing;  no intervention is needed for a timeout.
+func (i *Iperf3) ReelTimeout() *reel.Step {
+	return nil
+}
+
+// ReelEOF does nothing;  no intervention is needed for EOF.
+func (i *Iperf3) ReelEOF() {
+}
+
+// GetThroughput returns the throughput measured by a client.
+func (i *Iperf3) GetThroughput() Throughput {
+	return i.throughput
+}
+
+// GetError returns why the server did not start or the client did not complete its measurement, or an empty string.
+func (i *Iperf3) GetError() string {
+	return i.err
+}
@@ -0,0 +1,107 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package iperf3_test
+
+import (
+	"io/ioutil"
+	"path"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/iperf3"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 30
+	testDataDirectory   = "testdata"
+)
+
+// reelMatch passes the output in `fileName` to the handler the way the reel does, with the output preceding the first
+// match of `pattern` as `before`.
+func reelMatch(t *testing.T, handler *iperf3.Iperf3, pattern, fileName string) {
+	b, err := ioutil.ReadFile(path.Join(testDataDirectory, fileName))
+	assert.Nil(t, err)
+	output := string(b)
+	index := regexp.MustCompile(pattern).FindStringIndex(output)
+	assert.NotNil(t, index)
+	assert.Nil(t, handler.ReelMatch(pattern, output[:index[0]-1], output[index[0]:]))
+}
+
+func TestNewServer(t *testing.T) {
+	handler := iperf3.NewServer(testTimeoutDuration, 5201, time.Minute)
+	assert.Equal(t, tnf.ERROR, handler.Result())
+	assert.Equal(t, testTimeoutDuration, handler.Timeout())
+	assert.Equal(t, identifier.Iperf3Identifier, handler.GetIdentifier())
+	assert.Equal(t, "command -v iperf3 >/dev/null && (timeout 60 iperf3 -s -1 -p 5201 >/dev/null 2>&1 &) ; "+
+		"echo IPERF3_SERVER_STATUS=$? ; sleep 1", strings.Join(handler.Args(), " "))
+	assert.Equal(t, []string{iperf3.ServerStatusRegex}, handler.ReelFirst().Expect)
+	assert.Nil(t, handler.ReelTimeout())
+	handler.ReelEOF()
+}
+
+func TestServer_ReelMatch(t *testing.T) {
+	handler := iperf3.NewServer(testTimeoutDuration, 5201, time.Minute)
+	assert.Nil(t, handler.ReelMatch(iperf3.ServerStatusRegex, "", "IPERF3_SERVER_STATUS=0\n"))
+	assert.Equal(t, tnf.SUCCESS, handler.Result())
+	assert.Empty(t, handler.GetError())
+
+	handler = iperf3.NewServer(testTimeoutDuration, 5201, time.Minute)
+	assert.Nil(t, handler.ReelMatch(iperf3.ServerStatusRegex, "", "IPERF3_SERVER_STATUS=1\n"))
+	assert.Equal(t, tnf.FAILURE, handler.Result())
+	assert.Equal(t, "iperf3 is not installed", handler.GetError())
+}
+
+func TestNewClient(t *testing.T) {
+	handler := iperf3.NewClient(testTimeoutDuration, "10.128.2.15", 5201, iperf3.ProtocolTCP, 10*time.Second, "1G")
+	assert.Equal(t, tnf.ERROR, handler.Result())
+	assert.Equal(t, "iperf3 -c 10.128.2.15 -p 5201 -t 10 -J ; echo IPERF3_EXIT_STATUS=$?", strings.Join(handler.Args(), " "))
+	assert.Equal(t, []string{iperf3.ClientExitStatusRegex}, handler.ReelFirst().Expect)
+
+	handler = iperf3.NewClient(testTimeoutDuration, "10.128.2.15", 5201, iperf3.ProtocolUDP, 10*time.Second, "1G")
+	assert.Equal(t, "iperf3 -c 10.128.2.15 -p 5201 -t 10 -J -u -b 1G ; echo IPERF3_EXIT_STATUS=$?", strings.Join(handler.Args(), " "))
+	handler = iperf3.NewClient(testTimeoutDuration, "10.128.2.15", 5201, iperf3.ProtocolUDP, 10*time.Second, "")
+	assert.Equal(t, "iperf3 -c 10.128.2.15 -p 5201 -t 10 -J -u ; echo IPERF3_EXIT_STATUS=$?", strings.Join(handler.Args(), " "))
+}
+
+func TestClient_ReelMatch(t *testing.T) {
+	handler := iperf3.NewClient(testTimeoutDuration, "10.128.2.15", 5201, iperf3.ProtocolTCP, 10*time.Second, "")
+	reelMatch(t, handler, iperf3.ClientExitStatusRegex, "tcp_client.txt")
+	assert.Equal(t, tnf.SUCCESS, handler.Result())
+	assert.Empty(t, handler.GetError())
+	assert.Equal(t, iperf3.Throughput{BitsPerSecond: 9439754453.3}, handler.GetThroughput())
+
+	handler = iperf3.NewClient(testTimeoutDuration, "10.128.2.15", 5201, iperf3.ProtocolUDP, 10*time.Second, "1G")
+	reelMatch(t, handler, iperf3.ClientExitStatusRegex, "udp_client.txt")
+	assert.Equal(t, tnf.SUCCESS, handler.Result())
+	assert.Equal(t, iperf3.Throughput{BitsPerSecond: 999954037.1, LostPercent: 0.1788, JitterMilliseconds: 0.0123},
+		handler.GetThroughput())
+
+	handler = iperf3.NewClient(testTimeoutDuration, "10.128.2.15", 5201, iperf3.ProtocolTCP, 10*time.Second, "")
+	reelMatch(t, handler, iperf3.ClientExitStatusRegex, "connection_refused.txt")
+	assert.Equal(t, tnf.FAILURE, handler.Result())
+	assert.Equal(t, "unable to connect to server: Connection refused", handler.GetError())
+
+	handler = iperf3.NewClient(testTimeoutDuration, "10.128.2.15", 5201, iperf3.ProtocolTCP, 10*time.Second, "")
+	reelMatch(t, handler, iperf3.ClientExitStatusRegex, "not_installed.txt")
+	assert.Equal(t, tnf.FAILURE, handler.Result())
+	assert.Equal(t, "iperf3 is not installed", handler.GetError())
+}
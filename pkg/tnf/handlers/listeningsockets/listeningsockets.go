@@ -0,0 +1,281 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package listeningsockets
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	// SocketTableRegex matches the first line of the socket tables, each line being prefixed with the table path.
+	SocketTableRegex = `(?m)^/proc/net/(tcp|udp)6?:`
+	// EndOfTablesRegex matches the line output after the socket tables, whether or not any of them could be read.
+	EndOfTablesRegex = `(?m)^LISTENING_SOCKETS_END\r?$`
+
+	// ProtocolTCP is the protocol of a TCP socket.
+	ProtocolTCP = "TCP"
+	// ProtocolUDP is the protocol of a UDP socket.
+	ProtocolUDP = "UDP"
+
+	// tcpListenState is the state of a listening TCP socket in /proc/net/tcp.
+	tcpListenState = "0A"
+	// udpUnconnectedState is the state of a bound, unconnected UDP socket in /proc/net/udp.
+	udpUnconnectedState = "07"
+
+	// portRangeFile holds the range of the ephemeral ports, which are given to the sockets of clients.
+	portRangeFile = "/proc/sys/net/ipv4/ip_local_port_range"
+	// defaultEphemeralPortMin and defaultEphemeralPortMax are the range of the ephemeral ports when portRangeFile
+	// cannot be read, the Linux default.
+	defaultEphemeralPortMin = 32768
+	defaultEphemeralPortMax = 60999
+)
+
+var (
+	socketTables = []string{"/proc/net/tcp", "/proc/net/tcp6", "/proc/net/udp", "/proc/net/udp6"}
+	// socketLineRegex matches an entry of a socket table, capturing the table, the local address, the local port,
+	// the remote address, the remote port and the state.
+	socketLineRegex = regexp.MustCompile(`(?m)^/proc/net/(tcp|udp)6?:\s*\d+:\s+([0-9A-Fa-f]+):([0-9A-Fa-f]{4})\s+([0-9A-Fa-f]+):([0-9A-Fa-f]{4})\s+([0-9A-Fa-f]{2})\s`)
+	// portRangeRegex matches the contents of portRangeFile, capturing the first and last ephemeral ports.
+	portRangeRegex = regexp.MustCompile(`(?m)^` + regexp.QuoteMeta(portRangeFile) + `:(\d+)\s+(\d+)`)
+	// zeroAddressRegex matches the address of a socket table which is not set.
+	zeroAddressRegex = regexp.MustCompile(`^0+$`)
+)
+
+// Socket is a listening socket, or a declared port.
+type Socket struct {
+	// Protocol is either ProtocolTCP or ProtocolUDP.
+	Protocol string
+	// Address is the local address the socket is bound to, empty for a declared port.
+	Address string
+	// Port is the local port.
+	Port int
+}
+
+// String returns the socket as protocol/port, with its address when known.
+func (s Socket) String() string {
+	if s.Address == "" {
+		return fmt.Sprintf("%s/%d", s.Protocol, s.Port)
+	}
+	return fmt.Sprintf("%s/%s", s.Protocol, net.JoinHostPort(s.Address, strconv.Itoa(s.Port)))
+}
+
+// ListeningSockets lists the listening sockets of the network namespace of a container.  Containers of a pod share
+// its network namespace, so the sockets are those of the whole pod.
+type ListeningSockets struct {
+	result  int
+	timeout time.Duration
+	args    []string
+	sockets []Socket
+	err     string
+}
+
+// NewListeningSockets creates a new `ListeningSockets` test.
+func NewListeningSockets(timeout time.Duration) *ListeningSockets {
+	// A missing table, such as /proc/net/tcp6 when IPv6 is disabled, is not an error.
+	args := append([]string{dependencies.GrepBinaryName, "-s", "-H", "."}, socketTables...)
+	return &ListeningSockets{
+		result:  tnf.ERROR,
+		timeout: timeout,
+		args:    append(args, portRangeFile, ";", dependencies.EchoBinaryName, "LISTENING_SOCKETS_END"),
+	}
+}
+
+// Args returns the command line args for the test.
+func (l *ListeningSockets) Args() []string {
+	return l.args
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (l *ListeningSockets) GetIdentifier() identifier.Identifier {
+	return identifier.ListeningSocketsIdentifier
+}
+
+// Timeout returns the timeout in seconds for the test.
+func (l *ListeningSockets) Timeout() time.Duration {
+	return l.timeout
+}
+
+// Result returns the test result.
+func (l *ListeningSockets) Result() int {
+	return l.result
+}
+
+// ReelFirst returns a step which expects the end of the socket tables within the test timeout.
+func (l *ListeningSockets) ReelFirst() *reel.Step {
+	return &reel.Step{
+		Expect:  []string{EndOfTablesRegex},
+		Timeout: l.timeout,
+	}
+}
+
+// ReelMatch parses the socket tables output before the end line.  Sockets bound to a loopback address are not
+// reachable from outside the pod, so are left out, as are unconnected UDP sockets bound to an ephemeral port, which are
+// those of clients such as resolvers rather than listeners.  The result is an error if none of the tables could be read, as
+// the sockets are then unknown rather than absent.
+// Returns no step; the test is complete.
+func (l *ListeningSockets) ReelMatch(_, before, _ string) *reel.Step {
+	if !regexp.MustCompile(SocketTableRegex).MatchString(before) {
+		l.err = fmt.Sprintf("none of the socket tables %s could be read", strings.Join(socketTables, ", "))
+		l.result = tnf.ERROR
+		return nil
+	}
+	l.sockets = parseSocketTables(before)
+	l.result = tnf.SUCCESS
+	return nil
+}
+
+// ReelTimeout does nothing;  no intervention is needed for a timeout.
+func (l *ListeningSockets) ReelTimeout() *reel.Step {
+	return nil
+}
+
+// ReelEOF does nothing;  no intervention is needed for EOF.
+func (l *ListeningSockets) ReelEOF() {
+}
+
+// GetError returns why the sockets could not be listed, when the result is an error.
+func (l *ListeningSockets) GetError() string {
+	return l.err
+}
+
+// GetSockets returns the listening sockets, ordered by protocol and port.
+func (l *ListeningSockets) GetSockets() []Socket {
+	return l.sockets
+}
+
+// parseSocketTables returns the listening sockets found in the socket tables, excluding those bound to a loopback
+// address and the unconnected UDP sockets bound to an ephemeral port.
+func parseSocketTables(output string) []Socket {
+	var sockets []Socket
+	seen := make(map[Socket]bool)
+	ephemeralMin, ephemeralMax := parsePortRange(output)
+	for _, matched := range socketLineRegex.FindAllStringSubmatch(output, -1) {
+		protocol, state := strings.ToUpper(matched[1]), strings.ToUpper(matched[6])
+		if protocol == ProtocolTCP && state != tcpListenState {
+			continue
+		}
+		port, err := strconv.ParseUint(matched[3], 16, 16)
+		if err != nil {
+			continue
+		}
+		if protocol == ProtocolUDP && !isUDPListener(state, matched[4], matched[5], int(port), ephemeralMin, ephemeralMax) {
+			continue
+		}
+		address := decodeAddress(matched[2])
+		if address == nil || address.IsLoopback() {
+			continue
+		}
+		socket := Socket{Protocol: protocol, Address: address.String(), Port: int(port)}
+		if !seen[socket] {
+			seen[socket] = true
+			sockets = append(sockets, socket)
+		}
+	}
+	sort.Slice(sockets, func(i, j int) bool {
+		if sockets[i].Protocol != sockets[j].Protocol {
+			return sockets[i].Protocol < sockets[j].Protocol
+		}
+		if sockets[i].Port != sockets[j].Port {
+			return sockets[i].Port < sockets[j].Port
+		}
+		return sockets[i].Address < sockets[j].Address
+	})
+	return sockets
+}
+
+// isUDPListener tells whether a UDP socket in `state`, connected to `remoteAddress` and `remotePort` as written in the
+// socket table, and bound to `port`, is a listener: an unconnected socket bound to a port outside the ephemeral range
+// from `ephemeralMin` to `ephemeralMax`.
+func isUDPListener(state, remoteAddress, remotePort string, port, ephemeralMin, ephemeralMax int) bool {
+	if state != udpUnconnectedState || !zeroAddressRegex.MatchString(remoteAddress) || remotePort != "0000" {
+		return false
+	}
+	return port < ephemeralMin || port > ephemeralMax
+}
+
+// parsePortRange returns the first and last ephemeral ports read from portRangeFile, or the Linux default range when
+// the file could not be read.
+func parsePortRange(output string) (first, last int) {
+	matched := portRangeRegex.FindStringSubmatch(output)
+	if matched == nil {
+		return defaultEphemeralPortMin, defaultEphemeralPortMax
+	}
+	first, firstErr := strconv.Atoi(matched[1])
+	last, lastErr := strconv.Atoi(matched[2])
+	if firstErr != nil || lastErr != nil {
+		return defaultEphemeralPortMin, defaultEphemeralPortMax
+	}
+	return first, last
+}
+
+// decodeAddress decodes an address of a socket table, which is written as 32 bit words in host byte order.  Only
+// little endian hosts are supported.
+func decodeAddress(encoded string) net.IP {
+	raw, err := hex.DecodeString(encoded)
+	if err != nil || (len(raw) != net.IPv4len && len(raw) != net.IPv6len) {
+		return nil
+	}
+	ip := make(net.IP, len(raw))
+	for word := 0; word < len(raw); word += 4 {
+		for i := 0; i < 4; i++ {
+			ip[word+i] = raw[word+3-i]
+		}
+	}
+	return ip
+}
+
+// FindMismatches compares the listening sockets with the declared ports.  It returns the sockets listening on a port
+// that is neither declared nor allowed, and the declared ports that no socket listens on.  Sockets are matched on
+// protocol and port only.
+func FindMismatches(listening, declared, allowed []Socket) (undeclared, notListening []Socket) {
+	key := func(s Socket) Socket {
+		return Socket{Protocol: s.Protocol, Port: s.Port}
+	}
+	expected := make(map[Socket]bool)
+	for _, s := range declared {
+		expected[key(s)] = true
+	}
+	permitted := make(map[Socket]bool)
+	for _, s := range allowed {
+		permitted[key(s)] = true
+	}
+	open := make(map[Socket]bool)
+	for _, s := range listening {
+		open[key(s)] = true
+		if !expected[key(s)] && !permitted[key(s)] {
+			undeclared = append(undeclared, s)
+		}
+	}
+	for _, s := range declared {
+		if !open[key(s)] {
+			notListening = append(notListening, s)
+		}
+	}
+	return undeclared, notListening
+}
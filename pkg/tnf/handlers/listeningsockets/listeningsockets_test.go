@@ -0,0 +1,128 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package listeningsockets_test
+
+import (
+	"io/ioutil"
+	"path"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/listeningsockets"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 2
+)
+
+var (
+	testSocketsFilePath = path.Join("testdata", "sockets.txt")
+)
+
+func getMockOutput(t *testing.T) string {
+	b, err := ioutil.ReadFile(testSocketsFilePath)
+	assert.Nil(t, err)
+	return string(b)
+}
+
+func TestNewListeningSockets(t *testing.T) {
+	handler := listeningsockets.NewListeningSockets(testTimeoutDuration)
+	assert.Equal(t, tnf.ERROR, handler.Result())
+	assert.Equal(t, testTimeoutDuration, handler.Timeout())
+	assert.Equal(t, identifier.ListeningSocketsIdentifier, handler.GetIdentifier())
+	assert.Equal(t, "grep -s -H . /proc/net/tcp /proc/net/tcp6 /proc/net/udp /proc/net/udp6 /proc/sys/net/ipv4/ip_local_port_range ; echo LISTENING_SOCKETS_END",
+		strings.Join(handler.Args(), " "))
+	assert.Equal(t, []string{listeningsockets.EndOfTablesRegex}, handler.ReelFirst().Expect)
+	// The command line echoed back by the shell must not be mistaken for the end of the tables.
+	assert.False(t, regexp.MustCompile(listeningsockets.EndOfTablesRegex).MatchString(strings.Join(handler.Args(), " ")))
+	assert.Nil(t, handler.ReelTimeout())
+	handler.ReelEOF()
+}
+
+func TestListeningSockets_ReelMatch(t *testing.T) {
+	handler := listeningsockets.NewListeningSockets(testTimeoutDuration)
+	assert.Nil(t, handler.ReelMatch(listeningsockets.EndOfTablesRegex, getMockOutput(t), "LISTENING_SOCKETS_END"))
+	assert.Equal(t, tnf.SUCCESS, handler.Result())
+	assert.Empty(t, handler.GetError())
+	// Loopback and established sockets are left out, as are the UDP sockets of clients.
+	assert.Equal(t, []listeningsockets.Socket{
+		{Protocol: listeningsockets.ProtocolTCP, Address: "0.0.0.0", Port: 8080},
+		{Protocol: listeningsockets.ProtocolTCP, Address: "::", Port: 9090},
+		{Protocol: listeningsockets.ProtocolUDP, Address: "0.0.0.0", Port: 5353},
+	}, handler.GetSockets())
+}
+
+func TestListeningSockets_ReelMatchPortRange(t *testing.T) {
+	// A UDP socket on port 40000, which is only ephemeral in the default range.
+	udpTable := "/proc/net/udp:   0: 00000000:9C40 00000000:0000 07 00000000:00000000 00:00000000 00000000  1000        0 23462 2 0000000000000000 0\n"
+	handler := listeningsockets.NewListeningSockets(testTimeoutDuration)
+	assert.Nil(t, handler.ReelMatch(listeningsockets.EndOfTablesRegex, udpTable, "LISTENING_SOCKETS_END"))
+	assert.Empty(t, handler.GetSockets())
+
+	handler = listeningsockets.NewListeningSockets(testTimeoutDuration)
+	assert.Nil(t, handler.ReelMatch(listeningsockets.EndOfTablesRegex,
+		udpTable+"/proc/sys/net/ipv4/ip_local_port_range:50000\t60999\n", "LISTENING_SOCKETS_END"))
+	assert.Equal(t, []listeningsockets.Socket{{Protocol: listeningsockets.ProtocolUDP, Address: "0.0.0.0", Port: 40000}},
+		handler.GetSockets())
+}
+
+func TestListeningSockets_ReelMatchNoSockets(t *testing.T) {
+	handler := listeningsockets.NewListeningSockets(testTimeoutDuration)
+	assert.Nil(t, handler.ReelMatch(listeningsockets.EndOfTablesRegex,
+		"/proc/net/tcp:  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode\n",
+		"LISTENING_SOCKETS_END"))
+	assert.Equal(t, tnf.SUCCESS, handler.Result())
+	assert.Empty(t, handler.GetSockets())
+}
+
+func TestListeningSockets_ReelMatchNoTables(t *testing.T) {
+	handler := listeningsockets.NewListeningSockets(testTimeoutDuration)
+	assert.Nil(t, handler.ReelMatch(listeningsockets.EndOfTablesRegex, "", "LISTENING_SOCKETS_END"))
+	assert.Equal(t, tnf.ERROR, handler.Result())
+	assert.Equal(t, "none of the socket tables /proc/net/tcp, /proc/net/tcp6, /proc/net/udp, /proc/net/udp6 could be read",
+		handler.GetError())
+	assert.Empty(t, handler.GetSockets())
+}
+
+func TestFindMismatches(t *testing.T) {
+	handler := listeningsockets.NewListeningSockets(testTimeoutDuration)
+	handler.ReelMatch(listeningsockets.EndOfTablesRegex, getMockOutput(t), "LISTENING_SOCKETS_END")
+	declared := []listeningsockets.Socket{
+		{Protocol: listeningsockets.ProtocolTCP, Port: 8080},
+		{Protocol: listeningsockets.ProtocolTCP, Port: 8443},
+		{Protocol: listeningsockets.ProtocolUDP, Port: 5353},
+	}
+
+	undeclared, notListening := listeningsockets.FindMismatches(handler.GetSockets(), declared, nil)
+	assert.Equal(t, []listeningsockets.Socket{{Protocol: listeningsockets.ProtocolTCP, Address: "::", Port: 9090}}, undeclared)
+	assert.Equal(t, []listeningsockets.Socket{{Protocol: listeningsockets.ProtocolTCP, Port: 8443}}, notListening)
+
+	allowed := []listeningsockets.Socket{{Protocol: listeningsockets.ProtocolTCP, Port: 9090}}
+	undeclared, notListening = listeningsockets.FindMismatches(handler.GetSockets(), declared, allowed)
+	assert.Nil(t, undeclared)
+	assert.Equal(t, []listeningsockets.Socket{{Protocol: listeningsockets.ProtocolTCP, Port: 8443}}, notListening)
+}
+
+func TestSocket_String(t *testing.T) {
+	assert.Equal(t, "TCP/8080", listeningsockets.Socket{Protocol: listeningsockets.ProtocolTCP, Port: 8080}.String())
+	assert.Equal(t, "TCP/[::]:9090", listeningsockets.Socket{Protocol: listeningsockets.ProtocolTCP, Address: "::", Port: 9090}.String())
+}
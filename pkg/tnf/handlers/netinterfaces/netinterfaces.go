@@ -0,0 +1,175 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package netinterfaces
+
+import (
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	// LinkRegex matches a line of `ip -o link show`, and provides grouping to extract the interface name, without
+	// the peer of a veth, and its MTU.
+	LinkRegex = `(?m)^\d+: ([^:@\s]+)(?:@[^:\s]+)?: <[^>]*> mtu (\d+)`
+)
+
+var (
+	// addressRegex matches a line of `ip -o addr show`, capturing the interface name and the address with its
+	// prefix length.
+	addressRegex = regexp.MustCompile(`(?m)^\d+: ([^:@\s]+)\s+inet6? ([0-9a-fA-F.:]+/\d+) .*scope global`)
+)
+
+// Interface is a network interface which has at least one global scope address.
+type Interface struct {
+	// Name is the name of the interface, such as `eth0` or `net1`.
+	Name string
+	// MTU is the configured MTU of the interface.
+	MTU int
+	// Addresses are the global scope addresses of the interface, along with the mask of their subnet.
+	Addresses []*net.IPNet
+}
+
+// NetInterfaces lists the network interfaces of the network namespace of a container.  Containers of a pod share its
+// network namespace, so the interfaces are those of the whole pod.
+type NetInterfaces struct {
+	result     int
+	timeout    time.Duration
+	args       []string
+	interfaces []*Interface
+	links      []string
+}
+
+// NewNetInterfaces creates a new `NetInterfaces` test.
+func NewNetInterfaces(timeout time.Duration) *NetInterfaces {
+	return &NetInterfaces{
+		result:  tnf.ERROR,
+		timeout: timeout,
+		args: []string{dependencies.IPBinaryName, "-o", "link", "show", ";",
+			dependencies.IPBinaryName, "-o", "addr", "show", "scope", "global"},
+	}
+}
+
+// Args returns the command line args for the test.
+func (n *NetInterfaces) Args() []string {
+	return n.args
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (n *NetInterfaces) GetIdentifier() identifier.Identifier {
+	return identifier.NetInterfacesIdentifier
+}
+
+// Timeout returns the timeout in seconds for the test.
+func (n *NetInterfaces) Timeout() time.Duration {
+	return n.timeout
+}
+
+// Result returns the test result.
+func (n *NetInterfaces) Result() int {
+	return n.result
+}
+
+// ReelFirst returns a step which expects the interface list within the test timeout.  The loopback interface is always
+// listed, so the list is never empty.
+func (n *NetInterfaces) ReelFirst() *reel.Step {
+	return &reel.Step{
+		Expect:  []string{LinkRegex},
+		Timeout: n.timeout,
+	}
+}
+
+// ReelMatch parses the interfaces and their addresses.
+// Returns no step; the test is complete.
+func (n *NetInterfaces) ReelMatch(_, _, match string) *reel.Step {
+	n.interfaces = parseInterfaces(match)
+	n.links = parseLinks(match)
+	n.result = tnf.SUCCESS
+	return nil
+}
+
+// ReelTimeout does nothing;  no intervention is needed for a timeout.
+func (n *NetInterfaces) ReelTimeout() *reel.Step {
+	return nil
+}
+
+// ReelEOF does nothing;  no intervention is needed for EOF.
+func (n *NetInterfaces) ReelEOF() {
+}
+
+// GetInterfaces returns the interfaces which have a global scope address, ordered by name.
+func (n *NetInterfaces) GetInterfaces() []*Interface {
+	return n.interfaces
+}
+
+// GetLinkNames returns the names of all the interfaces, including those without a global scope address, ordered by
+// name.
+func (n *NetInterfaces) GetLinkNames() []string {
+	return n.links
+}
+
+// parseLinks returns the names of the interfaces listed by `ip -o link show`, ordered by name.
+func parseLinks(output string) []string {
+	var names []string
+	for _, matched := range regexp.MustCompile(LinkRegex).FindAllStringSubmatch(output, -1) {
+		names = append(names, matched[1])
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseInterfaces returns the interfaces listed by `ip -o link show` which `ip -o addr show` lists a global scope
+// address for.
+func parseInterfaces(output string) []*Interface {
+	mtus := make(map[string]int)
+	for _, matched := range regexp.MustCompile(LinkRegex).FindAllStringSubmatch(output, -1) {
+		// Ignore errors in converting matches to decimal integers, as the regular expression only matches digits.
+		mtus[matched[1]], _ = strconv.Atoi(matched[2])
+	}
+	interfaces := make(map[string]*Interface)
+	var names []string
+	for _, matched := range addressRegex.FindAllStringSubmatch(output, -1) {
+		mtu, ok := mtus[matched[1]]
+		if !ok {
+			continue
+		}
+		ip, subnet, err := net.ParseCIDR(matched[2])
+		if err != nil {
+			continue
+		}
+		iface, ok := interfaces[matched[1]]
+		if !ok {
+			iface = &Interface{Name: matched[1], MTU: mtu}
+			interfaces[iface.Name] = iface
+			names = append(names, iface.Name)
+		}
+		iface.Addresses = append(iface.Addresses, &net.IPNet{IP: ip, Mask: subnet.Mask})
+	}
+	sort.Strings(names)
+	result := make([]*Interface, 0, len(names))
+	for _, name := range names {
+		result = append(result, interfaces[name])
+	}
+	return result
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package netinterfaces_test
+
+import (
+	"io/ioutil"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/netinterfaces"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 2
+)
+
+var (
+	testInterfacesFilePath = path.Join("testdata", "interfaces.txt")
+)
+
+func getMockOutput(t *testing.T) string {
+	b, err := ioutil.ReadFile(testInterfacesFilePath)
+	assert.Nil(t, err)
+	return string(b)
+}
+
+func TestNewNetInterfaces(t *testing.T) {
+	handler := netinterfaces.NewNetInterfaces(testTimeoutDuration)
+	assert.Equal(t, tnf.ERROR, handler.Result())
+	assert.Equal(t, testTimeoutDuration, handler.Timeout())
+	assert.Equal(t, identifier.NetInterfacesIdentifier, handler.GetIdentifier())
+	assert.Equal(t, "ip -o link show ; ip -o addr show scope global", strings.Join(handler.Args(), " "))
+	assert.Equal(t, []string{netinterfaces.LinkRegex}, handler.ReelFirst().Expect)
+	assert.Nil(t, handler.ReelTimeout())
+	handler.ReelEOF()
+}
+
+func TestNetInterfaces_ReelMatch(t *testing.T) {
+	handler := netinterfaces.NewNetInterfaces(testTimeoutDuration)
+	assert.Nil(t, handler.ReelMatch(netinterfaces.LinkRegex, "", getMockOutput(t)))
+	assert.Equal(t, tnf.SUCCESS, handler.Result())
+
+	// The loopback interface and net2 have no global scope address, so are left out.
+	interfaces := handler.GetInterfaces()
+	assert.Len(t, interfaces, 2)
+	assert.Equal(t, "eth0", interfaces[0].Name)
+	assert.Equal(t, 1450, interfaces[0].MTU)
+	assert.Len(t, interfaces[0].Addresses, 2)
+	assert.Equal(t, "10.128.2.28/23", interfaces[0].Addresses[0].String())
+	assert.Equal(t, "fd01:0:0:3::1c/64", interfaces[0].Addresses[1].String())
+	assert.Equal(t, "net1", interfaces[1].Name)
+	assert.Equal(t, 9000, interfaces[1].MTU)
+	assert.Len(t, interfaces[1].Addresses, 1)
+	assert.Equal(t, "192.168.100.5/24", interfaces[1].Addresses[0].String())
+	assert.True(t, interfaces[1].Addresses[0].Contains([]byte{192, 168, 100, 9}))
+	assert.Equal(t, []string{"eth0", "lo", "net1", "net2"}, handler.GetLinkNames())
+}
+
+func TestNetInterfaces_ReelMatchNoAddress(t *testing.T) {
+	handler := netinterfaces.NewNetInterfaces(testTimeoutDuration)
+	match := "1: lo: <LOOPBACK,UP,LOWER_UP> mtu 65536 qdisc noqueue state UNKNOWN mode DEFAULT group default qlen 1000\n"
+	assert.Nil(t, handler.ReelMatch(netinterfaces.LinkRegex, "", match))
+	assert.Equal(t, tnf.SUCCESS, handler.Result())
+	assert.Empty(t, handler.GetInterfaces())
+	assert.Equal(t, []string{"lo"}, handler.GetLinkNames())
+}
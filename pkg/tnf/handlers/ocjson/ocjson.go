@@ -0,0 +1,136 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package ocjson
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	// ExitStatusRegex matches the exit status of the command, which follows the JSON document.
+	ExitStatusRegex = `(?m)^JSON_EXIT_STATUS=(\d+)\r?$`
+)
+
+// Handler is a test which fetches and parses a JSON document.
+type Handler interface {
+	tnf.Tester
+	reel.Handler
+	// GetError returns why the document could not be fetched or parsed, or an empty string.
+	GetError() string
+}
+
+// OcJSON runs a command which outputs a JSON document, and parses it.
+type OcJSON struct {
+	result     int
+	timeout    time.Duration
+	args       []string
+	identifier identifier.Identifier
+	subject    string
+	parse      func(data []byte) error
+	err        string
+}
+
+// NewOcJSON creates a new `OcJSON` test, identified by `id`, which runs `command` and parses the JSON document it
+// outputs with `parse`.  `subject` names what the document holds in errors, as in "the pod".
+func NewOcJSON(timeout time.Duration, id identifier.Identifier, subject string, command []string,
+	parse func(data []byte) error) *OcJSON {
+	return &OcJSON{
+		result:     tnf.ERROR,
+		timeout:    timeout,
+		args:       append(append([]string{}, command...), ";", dependencies.EchoBinaryName, "JSON_EXIT_STATUS=$?"),
+		identifier: id,
+		subject:    subject,
+		parse:      parse,
+	}
+}
+
+// Args returns the command line args for the test.
+func (o *OcJSON) Args() []string {
+	return o.args
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (o *OcJSON) GetIdentifier() identifier.Identifier {
+	return o.identifier
+}
+
+// Timeout returns the timeout in seconds for the test.
+func (o *OcJSON) Timeout() time.Duration {
+	return o.timeout
+}
+
+// Result returns the test result.
+func (o *OcJSON) Result() int {
+	return o.result
+}
+
+// ReelFirst returns a step which expects the exit status of the command within the test timeout.
+func (o *OcJSON) ReelFirst() *reel.Step {
+	return &reel.Step{
+		Expect:  []string{ExitStatusRegex},
+		Timeout: o.timeout,
+	}
+}
+
+// ReelMatch parses the JSON document, which precedes the exit status on a line of its own.  The result is success if
+// the document was output and parsed, and failure otherwise.  A command piped into `jq` outputs nothing when the
+// command fails.
+// Returns no step; the test is complete.
+func (o *OcJSON) ReelMatch(_, before, match string) *reel.Step {
+	o.result = tnf.FAILURE
+	matched := regexp.MustCompile(ExitStatusRegex).FindStringSubmatch(match)
+	if matched == nil {
+		return nil
+	}
+	// Ignore errors in converting matches to decimal integers, as the regular expression only matches digits.
+	status, _ := strconv.Atoi(matched[1])
+	start, end := strings.Index(before, "\n{"), strings.LastIndex(before, "}")
+	if status != 0 || start < 0 || end < start {
+		o.err = fmt.Sprintf("%s could not be fetched, exit status %d", o.subject, status)
+		return nil
+	}
+	if err := o.parse([]byte(before[start+1 : end+1])); err != nil {
+		o.err = fmt.Sprintf("unable to parse %s: %s", o.subject, err)
+		return nil
+	}
+	o.result = tnf.SUCCESS
+	return nil
+}
+
+// ReelTimeout records the timeout as the error;  no intervention is needed.
+func (o *OcJSON) ReelTimeout() *reel.Step {
+	o.err = fmt.Sprintf("timed out fetching %s", o.subject)
+	return nil
+}
+
+// ReelEOF does nothing;  no intervention is needed for EOF.
+func (o *OcJSON) ReelEOF() {
+}
+
+// GetError returns why the JSON document could not be fetched or parsed, or an empty string.
+func (o *OcJSON) GetError() string {
+	return o.err
+}
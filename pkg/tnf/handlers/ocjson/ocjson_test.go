@@ -0,0 +1,102 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package ocjson_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/ocjson"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 2
+)
+
+func getMockOutput(t *testing.T, name string) string {
+	b, err := ioutil.ReadFile(path.Join("testdata", name+".txt"))
+	assert.Nil(t, err)
+	return string(b)
+}
+
+// newNamespace creates a test which fetches the namespace `name` into `namespace`.
+func newNamespace(name string, namespace *struct{ Kind string }) *ocjson.OcJSON {
+	return ocjson.NewOcJSON(testTimeoutDuration, identifier.PodSpecIdentifier, "the namespace",
+		[]string{"oc", "get", "namespace", name, "-o", "json"}, func(data []byte) error {
+			return json.Unmarshal(data, namespace)
+		})
+}
+
+func TestNewOcJSON(t *testing.T) {
+	handler := newNamespace("tnf", &struct{ Kind string }{})
+	assert.Equal(t, tnf.ERROR, handler.Result())
+	assert.Equal(t, testTimeoutDuration, handler.Timeout())
+	assert.Equal(t, identifier.PodSpecIdentifier, handler.GetIdentifier())
+	assert.Equal(t, "oc get namespace tnf -o json ; echo JSON_EXIT_STATUS=$?", strings.Join(handler.Args(), " "))
+	assert.Equal(t, []string{ocjson.ExitStatusRegex}, handler.ReelFirst().Expect)
+	handler.ReelEOF()
+}
+
+func TestOcJSON_ReelMatch(t *testing.T) {
+	namespace := struct{ Kind string }{}
+	handler := newNamespace("tnf", &namespace)
+	assert.Nil(t, handler.ReelMatch(ocjson.ExitStatusRegex, getMockOutput(t, "document"), "JSON_EXIT_STATUS=0"))
+	assert.Equal(t, tnf.SUCCESS, handler.Result())
+	assert.Equal(t, "", handler.GetError())
+	assert.Equal(t, "Namespace", namespace.Kind)
+}
+
+func TestOcJSON_ReelMatchNotFound(t *testing.T) {
+	handler := newNamespace("missing", &struct{ Kind string }{})
+	assert.Nil(t, handler.ReelMatch(ocjson.ExitStatusRegex, getMockOutput(t, "not_found"), "JSON_EXIT_STATUS=1"))
+	assert.Equal(t, tnf.FAILURE, handler.Result())
+	assert.Equal(t, "the namespace could not be fetched, exit status 1", handler.GetError())
+
+	// A command piped into jq exits successfully, but outputs nothing, when it fails.
+	handler = newNamespace("missing", &struct{ Kind string }{})
+	assert.Nil(t, handler.ReelMatch(ocjson.ExitStatusRegex, "oc get namespace missing\n", "JSON_EXIT_STATUS=0"))
+	assert.Equal(t, tnf.FAILURE, handler.Result())
+	assert.Equal(t, "the namespace could not be fetched, exit status 0", handler.GetError())
+}
+
+func TestOcJSON_ReelMatchInvalid(t *testing.T) {
+	handler := newNamespace("tnf", &struct{ Kind string }{})
+	assert.Nil(t, handler.ReelMatch(ocjson.ExitStatusRegex, "oc get namespace\n{\"Kind\": []}\n", "JSON_EXIT_STATUS=0"))
+	assert.Equal(t, tnf.FAILURE, handler.Result())
+	assert.Contains(t, handler.GetError(), "unable to parse the namespace")
+
+	handler = ocjson.NewOcJSON(testTimeoutDuration, identifier.PodSpecIdentifier, "the namespace", []string{"oc"},
+		func([]byte) error { return errors.New("no name") })
+	assert.Nil(t, handler.ReelMatch(ocjson.ExitStatusRegex, getMockOutput(t, "document"), "JSON_EXIT_STATUS=0"))
+	assert.Equal(t, tnf.FAILURE, handler.Result())
+	assert.Equal(t, "unable to parse the namespace: no name", handler.GetError())
+}
+
+func TestOcJSON_ReelTimeout(t *testing.T) {
+	handler := newNamespace("tnf", &struct{ Kind string }{})
+	assert.Nil(t, handler.ReelTimeout())
+	assert.Equal(t, tnf.ERROR, handler.Result())
+	assert.Equal(t, "timed out fetching the namespace", handler.GetError())
+}
@@ -0,0 +1,140 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package packages
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/sbom"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	// ExitStatusRegex matches the exit status of the package listing, which follows the packages.
+	ExitStatusRegex = `(?m)^PACKAGES_EXIT_STATUS=(\d+)\r?$`
+)
+
+var (
+	// Command names the distribution of the container and the module streams enabled with dnf, then lists its packages
+	// with the first of rpm, dpkg and apk that it has, each as a tab separated line.  rpm versions are prefixed with their
+	// epoch when it is set, dpkg does not record licenses, and apk is listed from its database.
+	Command = strings.Join([]string{
+		`(. /etc/os-release 2>/dev/null; printf 'os\t%s\t%s\n' "$ID" "$VERSION_ID");`,
+		`(cat /etc/dnf/modules.d/*.module 2>/dev/null | awk -F= '/^\[/{if(e=="enabled")printf "module\t%s\t%s\n",n,s; n=""; s=""; e=""}`,
+		`/^name=/{n=$2} /^stream=/{s=$2} /^state=/{e=$2} END{if(e=="enabled")printf "module\t%s\t%s\n",n,s}');`,
+		`if command -v rpm >/dev/null 2>&1; then`,
+		`rpm -qa --queryformat 'rpm\t%{NAME}\t%|EPOCH?{%{EPOCH}:}:{}|%{VERSION}-%{RELEASE}\t%{ARCH}\t%{LICENSE}\n';`,
+		`elif command -v dpkg-query >/dev/null 2>&1; then`,
+		`dpkg-query -W -f 'deb\t${Package}\t${Version}\t${Architecture}\t\n';`,
+		`elif [ -f /lib/apk/db/installed ]; then`,
+		`(cat /lib/apk/db/installed; echo) | awk '/^P:/{p=substr($0,3)} /^V:/{v=substr($0,3)} /^A:/{a=substr($0,3)}`,
+		`/^L:/{l=substr($0,3)} /^$/{if(p!="")printf "apk\t%s\t%s\t%s\t%s\n",p,v,a,l; p=""; l=""}';`,
+		`fi;`,
+		`echo PACKAGES_EXIT_STATUS=$?`,
+	}, " ")
+)
+
+// Packages lists the packages installed in a container.
+type Packages struct {
+	result    int
+	timeout   time.Duration
+	args      []string
+	inventory *sbom.Inventory
+	err       string
+}
+
+// NewPackages creates a new `Packages` test which lists the packages installed in the container it runs in.
+func NewPackages(timeout time.Duration) *Packages {
+	return &Packages{
+		result:  tnf.ERROR,
+		timeout: timeout,
+		args:    strings.Split(Command, " "),
+	}
+}
+
+// Args returns the command line args for the test.
+func (p *Packages) Args() []string {
+	return p.args
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (p *Packages) GetIdentifier() identifier.Identifier {
+	return identifier.PackagesIdentifier
+}
+
+// Timeout returns the timeout in seconds for the test.
+func (p *Packages) Timeout() time.Duration {
+	return p.timeout
+}
+
+// Result returns the test result.
+func (p *Packages) Result() int {
+	return p.result
+}
+
+// ReelFirst returns a step which expects the exit status of the package listing within the test timeout.
+func (p *Packages) ReelFirst() *reel.Step {
+	return &reel.Step{
+		Expect:  []string{ExitStatusRegex},
+		Timeout: p.timeout,
+	}
+}
+
+// ReelMatch parses the packages, which precede the exit status.  The result is success if they were listed, even if
+// the container has none of the supported package managers, and failure otherwise.
+// Returns no step; the test is complete.
+func (p *Packages) ReelMatch(_, before, match string) *reel.Step {
+	p.result = tnf.FAILURE
+	matched := regexp.MustCompile(ExitStatusRegex).FindStringSubmatch(match)
+	if matched == nil {
+		return nil
+	}
+	// Ignore errors in converting matches to decimal integers, as the regular expression only matches digits.
+	status, _ := strconv.Atoi(matched[1])
+	if status != 0 {
+		p.err = fmt.Sprintf("the packages could not be listed, exit status %d", status)
+		return nil
+	}
+	p.inventory = sbom.ParseInventory(before)
+	p.result = tnf.SUCCESS
+	return nil
+}
+
+// ReelTimeout does nothing;  no intervention is needed for a timeout.
+func (p *Packages) ReelTimeout() *reel.Step {
+	return nil
+}
+
+// ReelEOF does nothing;  no intervention is needed for EOF.
+func (p *Packages) ReelEOF() {
+}
+
+// GetInventory returns the packages installed in the container, or nil if they could not be listed.
+func (p *Packages) GetInventory() *sbom.Inventory {
+	return p.inventory
+}
+
+// GetError returns why the packages could not be listed, or an empty string.
+func (p *Packages) GetError() string {
+	return p.err
+}
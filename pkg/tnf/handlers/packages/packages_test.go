@@ -0,0 +1,93 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package packages_test
+
+import (
+	"io/ioutil"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/sbom"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/packages"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 2
+)
+
+func getMockOutput(t *testing.T, name string) string {
+	b, err := ioutil.ReadFile(path.Join("testdata", name+".txt"))
+	assert.Nil(t, err)
+	return string(b)
+}
+
+func TestNewPackages(t *testing.T) {
+	handler := packages.NewPackages(testTimeoutDuration)
+	assert.Equal(t, tnf.ERROR, handler.Result())
+	assert.Equal(t, testTimeoutDuration, handler.Timeout())
+	assert.Equal(t, identifier.PackagesIdentifier, handler.GetIdentifier())
+	assert.Equal(t, packages.Command, strings.Join(handler.Args(), " "))
+	assert.Equal(t, []string{packages.ExitStatusRegex}, handler.ReelFirst().Expect)
+	assert.Nil(t, handler.ReelTimeout())
+	handler.ReelEOF()
+}
+
+func TestPackages_ReelMatch(t *testing.T) {
+	testCases := []struct {
+		output      string
+		osID        string
+		modules     []string
+		managers    []string
+		packageKeys []string
+	}{
+		{"rpm", "rhel", []string{"container-tools:rhel8", "nodejs:14"}, []string{sbom.ManagerRPM},
+			[]string{"bash 4.4.20-1.el8_4", "glibc 2.28-151.el8", "gpg-pubkey fd431d51-4ae0493b",
+				"openssl-libs 1:1.1.1g-15.el8_3"}},
+		{"dpkg", "debian", nil, []string{sbom.ManagerDeb}, []string{"base-files 11.1+deb11u2", "libc6 2.31-13+deb11u2",
+			"tzdata 2021a-1+deb11u2"}},
+		{"apk", "alpine", nil, []string{sbom.ManagerAPK}, []string{"busybox 1.33.1-r3", "musl 1.2.2-r3"}},
+		{"none", "", nil, nil, nil},
+	}
+	for _, testCase := range testCases {
+		handler := packages.NewPackages(testTimeoutDuration)
+		assert.Nil(t, handler.ReelMatch(packages.ExitStatusRegex, getMockOutput(t, testCase.output), "PACKAGES_EXIT_STATUS=0"))
+		assert.Equal(t, tnf.SUCCESS, handler.Result())
+		assert.Equal(t, "", handler.GetError())
+		inventory := handler.GetInventory()
+		assert.Equal(t, testCase.osID, inventory.OSID)
+		assert.Equal(t, testCase.modules, inventory.Modules, testCase.output)
+		assert.Equal(t, testCase.managers, inventory.Managers())
+		var packageKeys []string
+		for _, pkg := range inventory.Packages {
+			packageKeys = append(packageKeys, pkg.Name+" "+pkg.Version)
+		}
+		assert.Equal(t, testCase.packageKeys, packageKeys, testCase.output)
+	}
+}
+
+func TestPackages_ReelMatchFailure(t *testing.T) {
+	handler := packages.NewPackages(testTimeoutDuration)
+	assert.Nil(t, handler.ReelMatch(packages.ExitStatusRegex, "rpm: database is locked\r\n", "PACKAGES_EXIT_STATUS=1"))
+	assert.Equal(t, tnf.FAILURE, handler.Result())
+	assert.Nil(t, handler.GetInventory())
+	assert.Equal(t, "the packages could not be listed, exit status 1", handler.GetError())
+}
@@ -0,0 +1,173 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package pcidevices
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	// EndRegex matches the line which follows the description of the last device.
+	EndRegex = `(?m)^PCI_DEVICES_END\r?$`
+
+	// noneValue stands for a device with no driver bound, or with no network interface.
+	noneValue = "none"
+	// sysfsDevicesPath is the directory of the PCI devices in sysfs.
+	sysfsDevicesPath = "/sys/bus/pci/devices"
+)
+
+var (
+	// addressRegex matches a PCI address in its domain:bus:device.function form.
+	addressRegex = regexp.MustCompile(`^[0-9a-fA-F]{4}:[0-9a-fA-F]{2}:[0-9a-fA-F]{2}\.[0-7]$`)
+	// deviceRegex matches the description of a device, capturing its address, its kind, and, unless it is absent,
+	// its driver and network interface.
+	deviceRegex = regexp.MustCompile(`(?m)^PCI_DEVICE (\S+) (absent|vf|pf)(?: (\S+) (\S+))?\r?$`)
+)
+
+// Device is a PCI device as seen from a container.
+type Device struct {
+	// Address is the PCI address of the device, such as `0000:3b:02.1`.
+	Address string
+	// Present is false if the device cannot be found in the sysfs of the container.
+	Present bool
+	// VirtualFunction is true for an SR-IOV VF.
+	VirtualFunction bool
+	// Driver is the driver bound to the device, such as `iavf` or `vfio-pci`, or empty if none is bound.
+	Driver string
+	// NetInterface is the name of the network interface of the device, or empty if it has none, as is the case for
+	// devices bound to a userspace driver.
+	NetInterface string
+}
+
+// PCIDevices inspects PCI devices through the sysfs of a container.
+type PCIDevices struct {
+	result  int
+	timeout time.Duration
+	args    []string
+	devices []*Device
+}
+
+// IsValidAddress returns true if `address` is a PCI address in its domain:bus:device.function form.
+func IsValidAddress(address string) bool {
+	return addressRegex.MatchString(address)
+}
+
+// NewPCIDevices creates a new `PCIDevices` test which inspects the devices at `addresses`.  Anything which is not a
+// valid PCI address is ignored.
+func NewPCIDevices(timeout time.Duration, addresses []string) *PCIDevices {
+	args := []string{"for", "a", "in"}
+	for _, address := range addresses {
+		if IsValidAddress(address) {
+			args = append(args, address)
+		}
+	}
+	args = append(args, ";", "do", fmt.Sprintf("d=%s/$a", sysfsDevicesPath), ";",
+		"[", "-e", "$d", "]", "||", "{", dependencies.EchoBinaryName, "PCI_DEVICE", "$a", "absent", ";", "continue", ";", "}", ";",
+		"k=$([", "-e", "$d/physfn", "]", "&&", dependencies.EchoBinaryName, "vf", "||", dependencies.EchoBinaryName, "pf)", ";",
+		fmt.Sprintf("drv=$(%s $d/driver 2>/dev/null)", dependencies.ReadlinkBinaryName), ";", "drv=${drv##*/}", ";",
+		fmt.Sprintf("n=$(%s $d/net 2>/dev/null | %s -n 1)", dependencies.LsBinaryName, dependencies.HeadBinaryName), ";",
+		dependencies.EchoBinaryName, "PCI_DEVICE", "$a", "$k", fmt.Sprintf("${drv:-%s}", noneValue), fmt.Sprintf("${n:-%s}", noneValue), ";",
+		"done", ";", dependencies.EchoBinaryName, "PCI_DEVICES_END")
+	return &PCIDevices{
+		result:  tnf.ERROR,
+		timeout: timeout,
+		args:    args,
+	}
+}
+
+// Args returns the command line args for the test.
+func (p *PCIDevices) Args() []string {
+	return p.args
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (p *PCIDevices) GetIdentifier() identifier.Identifier {
+	return identifier.PCIDevicesIdentifier
+}
+
+// Timeout returns the timeout in seconds for the test.
+func (p *PCIDevices) Timeout() time.Duration {
+	return p.timeout
+}
+
+// Result returns the test result.
+func (p *PCIDevices) Result() int {
+	return p.result
+}
+
+// ReelFirst returns a step which expects the end of the device descriptions within the test timeout.
+func (p *PCIDevices) ReelFirst() *reel.Step {
+	return &reel.Step{
+		Expect:  []string{EndRegex},
+		Timeout: p.timeout,
+	}
+}
+
+// ReelMatch parses the device descriptions, which precede the match.
+// Returns no step; the test is complete.
+func (p *PCIDevices) ReelMatch(_, before, _ string) *reel.Step {
+	p.devices = nil
+	for _, matched := range deviceRegex.FindAllStringSubmatch(before, -1) {
+		device := &Device{Address: matched[1], Present: matched[2] != "absent", VirtualFunction: matched[2] == "vf"}
+		if device.Present {
+			device.Driver = valueOrEmpty(matched[3])
+			device.NetInterface = valueOrEmpty(matched[4])
+		}
+		p.devices = append(p.devices, device)
+	}
+	p.result = tnf.SUCCESS
+	return nil
+}
+
+// ReelTimeout does nothing;  no intervention is needed for a timeout.
+func (p *PCIDevices) ReelTimeout() *reel.Step {
+	return nil
+}
+
+// ReelEOF does nothing;  no intervention is needed for EOF.
+func (p *PCIDevices) ReelEOF() {
+}
+
+// GetDevices returns the inspected devices, in the order their addresses were given.
+func (p *PCIDevices) GetDevices() []*Device {
+	return p.devices
+}
+
+// GetDevice returns the inspected device at `address`, or nil if it was not inspected.
+func (p *PCIDevices) GetDevice(address string) *Device {
+	for _, device := range p.devices {
+		if device.Address == address {
+			return device
+		}
+	}
+	return nil
+}
+
+// valueOrEmpty returns `value`, or an empty string when it stands for no value.
+func valueOrEmpty(value string) string {
+	if value == noneValue {
+		return ""
+	}
+	return value
+}
@@ -0,0 +1,93 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package pcidevices_test
+
+import (
+	"io/ioutil"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/pcidevices"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 2
+	endMatch            = "PCI_DEVICES_END"
+)
+
+func getMockOutput(t *testing.T, name string) string {
+	b, err := ioutil.ReadFile(path.Join("testdata", name+".txt"))
+	assert.Nil(t, err)
+	return string(b)
+}
+
+func TestNewPCIDevices(t *testing.T) {
+	handler := pcidevices.NewPCIDevices(testTimeoutDuration, []string{"0000:3b:02.1", "$(reboot)", "0000:3b:02.2"})
+	assert.Equal(t, tnf.ERROR, handler.Result())
+	assert.Equal(t, testTimeoutDuration, handler.Timeout())
+	assert.Equal(t, identifier.PCIDevicesIdentifier, handler.GetIdentifier())
+	args := strings.Join(handler.Args(), " ")
+	assert.True(t, strings.HasPrefix(args, "for a in 0000:3b:02.1 0000:3b:02.2 ; do d=/sys/bus/pci/devices/$a ;"))
+	assert.NotContains(t, args, "reboot")
+	assert.True(t, strings.HasSuffix(args, "done ; echo PCI_DEVICES_END"))
+	assert.Equal(t, []string{pcidevices.EndRegex}, handler.ReelFirst().Expect)
+	assert.Nil(t, handler.ReelTimeout())
+	handler.ReelEOF()
+}
+
+func TestIsValidAddress(t *testing.T) {
+	assert.True(t, pcidevices.IsValidAddress("0000:3b:02.1"))
+	assert.True(t, pcidevices.IsValidAddress("0000:D8:1f.7"))
+	assert.False(t, pcidevices.IsValidAddress("3b:02.1"))
+	assert.False(t, pcidevices.IsValidAddress("0000:3b:02.8"))
+	assert.False(t, pcidevices.IsValidAddress("0000:3b:02.1 ; reboot"))
+}
+
+func TestPCIDevices_ReelMatch(t *testing.T) {
+	handler := pcidevices.NewPCIDevices(testTimeoutDuration, nil)
+	assert.Nil(t, handler.ReelMatch(pcidevices.EndRegex, getMockOutput(t, "devices"), endMatch))
+	assert.Equal(t, tnf.SUCCESS, handler.Result())
+
+	devices := handler.GetDevices()
+	assert.Equal(t, []*pcidevices.Device{
+		{Address: "0000:3b:02.1", Present: true, VirtualFunction: true, Driver: "iavf", NetInterface: "net1"},
+		{Address: "0000:3b:02.2", Present: true, VirtualFunction: true, Driver: "vfio-pci"},
+		{Address: "0000:3b:00.0", Present: true, Driver: "i40e", NetInterface: "ens1f0"},
+		{Address: "0000:d8:02.0"},
+	}, devices)
+	assert.Equal(t, devices[1], handler.GetDevice("0000:3b:02.2"))
+	assert.Nil(t, handler.GetDevice("0000:3b:02.9"))
+}
+
+func TestPCIDevices_ReelMatchNoDriver(t *testing.T) {
+	handler := pcidevices.NewPCIDevices(testTimeoutDuration, nil)
+	assert.Nil(t, handler.ReelMatch(pcidevices.EndRegex, getMockOutput(t, "no_driver"), endMatch))
+	assert.Equal(t, tnf.SUCCESS, handler.Result())
+	assert.Equal(t, []*pcidevices.Device{{Address: "0000:3b:02.3", Present: true, VirtualFunction: true}}, handler.GetDevices())
+}
+
+func TestPCIDevices_ReelMatchNoDevices(t *testing.T) {
+	handler := pcidevices.NewPCIDevices(testTimeoutDuration, nil)
+	assert.Nil(t, handler.ReelMatch(pcidevices.EndRegex, "", endMatch))
+	assert.Equal(t, tnf.SUCCESS, handler.Result())
+	assert.Empty(t, handler.GetDevices())
+}
@@ -17,6 +17,7 @@
 package ping
 
 import (
+	"net"
 	"regexp"
 	"strconv"
 	"time"
@@ -35,6 +36,31 @@ type Ping struct {
 	transmitted int
 	received    int
 	errors      int
+	// reportedMTU is the path MTU reported when a request was too large to be sent without fragmentation.
+	reportedMTU int
+	// rtt holds the round trip time statistics, which are only reported when at least one reply was received.
+	rtt *RTTStatistics
+}
+
+// RTTStatistics are the round trip time statistics of the replies, in milliseconds.
+type RTTStatistics struct {
+	Min float64
+	Avg float64
+	Max float64
+	// Mdev is the mean deviation of the round trip times, which measures their jitter.  Some `ping` implementations
+	// do not report it, in which case it is zero.
+	Mdev float64
+}
+
+// Options customizes the requests sent by `ping`.  The zero value sends requests of the default size, which may be
+// fragmented.
+type Options struct {
+	// PayloadSize is the number of data bytes of each request, or the `ping` default if not positive.
+	PayloadSize int
+	// DontFragment prohibits the fragmentation of the requests, so that requests larger than the path MTU fail.
+	DontFragment bool
+	// ReplyTimeoutSeconds is the time to wait for each reply, or the `ping` default if not positive.
+	ReplyTimeoutSeconds int
 }
 
 const (
@@ -43,6 +69,23 @@ const (
 	// SuccessfulOutputRegex matches a successfully run "ping" command.  That does not mean that no errors or drops
 	// occurred during the test.
 	SuccessfulOutputRegex = `(?m)(\d+) packets transmitted, (\d+)( packets){0,1} received, (?:\+(\d+) errors)?.*$`
+	// RTTStatisticsRegex matches the round trip time statistics of a "ping" command which received at least one reply,
+	// and provides grouping to extract the minimum, average, maximum and, when reported, mean deviation.
+	RTTStatisticsRegex = `(?m)^(?:rtt|round-trip) min/avg/max(?:/mdev)? = ([\d.]+)/([\d.]+)/([\d.]+)(?:/([\d.]+))? ms`
+	// FragmentationNeededRegex matches the errors reported for requests which do not fit the path MTU when
+	// fragmentation is prohibited, and provides grouping to extract the MTU when it is reported.
+	FragmentationNeededRegex = `(?m)(?:[Mm]essage too long, mtu=(\d+)|Frag needed and DF set \(mtu = (\d+)\)|Packet too big: mtu=(\d+)|[Mm]essage too long)`
+
+	// ipv6Flag restricts `ping` to IPv6.
+	ipv6Flag = "-6"
+	// payloadSizeFlag sets the number of data bytes of each request.
+	payloadSizeFlag = "-s"
+	// pmtuDiscoveryFlag sets the path MTU discovery strategy, which dontFragmentStrategy sets to prohibit
+	// fragmentation.
+	pmtuDiscoveryFlag    = "-M"
+	dontFragmentStrategy = "do"
+	// replyTimeoutFlag sets the time to wait for each reply, in seconds.
+	replyTimeoutFlag = "-W"
 )
 
 // Args returns the command line args for the test.
@@ -79,7 +122,8 @@ func (p *Ping) ReelFirst() *reel.Step {
 // response may be in flight).
 // The result is error if ping reported a protocol error (e.g. destination host
 // unreachable), no requests were sent or there was some test execution error.
-// Otherwise the result is failure.
+// Otherwise the result is failure, including when requests were too large to be
+// sent without fragmentation.
 // Returns no step; the test is complete.
 func (p *Ping) ReelMatch(_, _, match string) *reel.Step {
 	re := regexp.MustCompile(ConnectInvalidArgumentRegex)
@@ -95,7 +139,11 @@ func (p *Ping) ReelMatch(_, _, match string) *reel.Step {
 		p.transmitted, _ = strconv.Atoi(matched[1])
 		p.received, _ = strconv.Atoi(matched[2])
 		p.errors, _ = strconv.Atoi(matched[4])
+		p.rtt = parseRTTStatistics(match)
+		fragmentationNeeded := p.parseFragmentationNeeded(match)
 		switch {
+		case p.transmitted > 0 && fragmentationNeeded && p.received == 0:
+			p.result = tnf.FAILURE
 		case p.transmitted == 0 || p.errors > 0:
 			p.result = tnf.ERROR
 		case p.received > 0 && (p.transmitted-p.received) <= 1:
@@ -121,13 +169,92 @@ func (p *Ping) GetStats() (transmitted, received, errors int) {
 	return p.transmitted, p.received, p.errors
 }
 
+// parseRTTStatistics returns the round trip time statistics, or nil if they are not reported.
+func parseRTTStatistics(match string) *RTTStatistics {
+	matched := regexp.MustCompile(RTTStatisticsRegex).FindStringSubmatch(match)
+	if matched == nil {
+		return nil
+	}
+	// Ignore errors in converting matches to floats, as the regular expression only matches digits and dots.
+	rtt := &RTTStatistics{}
+	rtt.Min, _ = strconv.ParseFloat(matched[1], 64)
+	rtt.Avg, _ = strconv.ParseFloat(matched[2], 64)
+	rtt.Max, _ = strconv.ParseFloat(matched[3], 64)
+	rtt.Mdev, _ = strconv.ParseFloat(matched[4], 64)
+	return rtt
+}
+
+// GetRTTStatistics returns the round trip time statistics, and false if none were reported because no reply was
+// received.
+func (p *Ping) GetRTTStatistics() (RTTStatistics, bool) {
+	if p.rtt == nil {
+		return RTTStatistics{}, false
+	}
+	return *p.rtt, true
+}
+
+// GetPacketLossPercentage returns the share of the transmitted requests which were not answered, or 100 if no request
+// was transmitted.
+func (p *Ping) GetPacketLossPercentage() float64 {
+	if p.transmitted == 0 {
+		return 100
+	}
+	return float64(p.transmitted-p.received) * 100 / float64(p.transmitted)
+}
+
+// parseFragmentationNeeded records the path MTU if one is reported, and returns true if any request was too large to
+// be sent without fragmentation.
+func (p *Ping) parseFragmentationNeeded(match string) bool {
+	re := regexp.MustCompile(FragmentationNeededRegex)
+	matches := re.FindAllStringSubmatch(match, -1)
+	for _, matched := range matches {
+		for _, reported := range matched[1:] {
+			if mtu, err := strconv.Atoi(reported); err == nil && (p.reportedMTU == 0 || mtu < p.reportedMTU) {
+				p.reportedMTU = mtu
+			}
+		}
+	}
+	return len(matches) > 0
+}
+
+// GetReportedMTU returns the smallest path MTU reported for requests too large to be sent without fragmentation, or
+// zero if none was reported.
+func (p *Ping) GetReportedMTU() int {
+	return p.reportedMTU
+}
+
 // Command returns command line args for pinging `host` with `count` requests, or indefinitely if `count` is not
-// positive.
+// positive.  An IPv6 `host` is pinged with `ping -6`, as older `ping` binaries do not infer the address family.
 func Command(host string, count int) []string {
+	return CommandWithOptions(host, count, Options{})
+}
+
+// CommandWithOptions returns command line args for pinging `host` with `count` requests customized by `options`, or
+// indefinitely if `count` is not positive.
+func CommandWithOptions(host string, count int, options Options) []string {
+	args := []string{dependencies.PingBinaryName}
+	if IsIPv6Address(host) {
+		args = append(args, ipv6Flag)
+	}
 	if count > 0 {
-		return []string{dependencies.PingBinaryName, "-c", strconv.Itoa(count), host}
+		args = append(args, "-c", strconv.Itoa(count))
+	}
+	if options.PayloadSize > 0 {
+		args = append(args, payloadSizeFlag, strconv.Itoa(options.PayloadSize))
+	}
+	if options.DontFragment {
+		args = append(args, pmtuDiscoveryFlag, dontFragmentStrategy)
+	}
+	if options.ReplyTimeoutSeconds > 0 {
+		args = append(args, replyTimeoutFlag, strconv.Itoa(options.ReplyTimeoutSeconds))
 	}
-	return []string{dependencies.PingBinaryName, host}
+	return append(args, host)
+}
+
+// IsIPv6Address returns true if `host` is an IPv6 address literal.
+func IsIPv6Address(host string) bool {
+	ip := net.ParseIP(host)
+	return ip != nil && ip.To4() == nil
 }
 
 // NewPing creates a new `Ping` test which pings `hosts` with `count` requests, or indefinitely if `count` is not
@@ -140,6 +267,16 @@ func NewPing(timeout time.Duration, host string, count int) *Ping {
 	}
 }
 
+// NewPingWithOptions creates a new `Ping` test which pings `host` with `count` requests customized by `options`, or
+// indefinitely if `count` is not positive, and executes within `timeout` seconds.
+func NewPingWithOptions(timeout time.Duration, host string, count int, options Options) *Ping {
+	return &Ping{
+		result:  tnf.ERROR,
+		timeout: timeout,
+		args:    CommandWithOptions(host, count, options),
+	}
+}
+
 // GetReelFirstRegularExpressions returns the regular expressions used for matching in ReelFirst.
 func (p *Ping) GetReelFirstRegularExpressions() []string {
 	return []string{ConnectInvalidArgumentRegex, SuccessfulOutputRegex}
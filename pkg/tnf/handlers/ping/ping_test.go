@@ -43,6 +43,9 @@ type TestCase struct {
 	expectedReceived int
 	expectedErrors   int
 	expectedResult   int
+	expectedMTU      int
+	expectedRTT      *ping.RTTStatistics
+	expectedLoss     float64
 }
 
 var testCases = map[string]TestCase{
@@ -53,6 +56,8 @@ var testCases = map[string]TestCase{
 		expectedReceived: 4,
 		expectedErrors:   0,
 		expectedResult:   tnf.SUCCESS,
+		expectedRTT:      &ping.RTTStatistics{Min: 1.710, Avg: 3.446, Max: 7.994, Mdev: 2.633},
+		expectedLoss:     0,
 	},
 	"hostname_no_packet_loss": {
 		host:             "www.google.com",
@@ -61,6 +66,8 @@ var testCases = map[string]TestCase{
 		expectedReceived: 10,
 		expectedErrors:   0,
 		expectedResult:   tnf.SUCCESS,
+		expectedRTT:      &ping.RTTStatistics{Min: 21.650, Avg: 27.619, Max: 37.003, Mdev: 3.885},
+		expectedLoss:     0,
 	},
 	"ip_address_error_packet_loss": {
 		host:             "192.168.1.1",
@@ -69,6 +76,8 @@ var testCases = map[string]TestCase{
 		expectedReceived: 16,
 		expectedErrors:   4,
 		expectedResult:   tnf.ERROR,
+		expectedRTT:      &ping.RTTStatistics{Min: 1.582, Avg: 134.079, Max: 585.861, Mdev: 179.394},
+		expectedLoss:     20,
 	},
 	"ip_address_failing_packet_loss": {
 		host:             "192.168.1.2",
@@ -77,6 +86,7 @@ var testCases = map[string]TestCase{
 		expectedReceived: 0,
 		expectedErrors:   0,
 		expectedResult:   tnf.FAILURE,
+		expectedLoss:     100,
 	},
 	"ip_address_passing_packet_loss": {
 		host:             "192.168.1.1",
@@ -85,6 +95,48 @@ var testCases = map[string]TestCase{
 		expectedReceived: 19,
 		expectedErrors:   0,
 		expectedResult:   tnf.SUCCESS,
+		expectedRTT:      &ping.RTTStatistics{Min: 3.381, Avg: 7.772, Max: 14.867, Mdev: 4.167},
+		expectedLoss:     5,
+	},
+	"ip_address_fragmentation_needed_local": {
+		host:             "192.168.1.1",
+		count:            2,
+		expectedSent:     2,
+		expectedReceived: 0,
+		expectedErrors:   2,
+		expectedResult:   tnf.FAILURE,
+		expectedMTU:      1450,
+		expectedLoss:     100,
+	},
+	"ip_address_fragmentation_needed_remote": {
+		host:             "192.168.1.1",
+		count:            2,
+		expectedSent:     2,
+		expectedReceived: 0,
+		expectedErrors:   2,
+		expectedResult:   tnf.FAILURE,
+		expectedMTU:      1400,
+		expectedLoss:     100,
+	},
+	"ipv6_address_packet_too_big": {
+		host:             "fd01:0:0:1::2d",
+		count:            2,
+		expectedSent:     2,
+		expectedReceived: 0,
+		expectedErrors:   2,
+		expectedResult:   tnf.FAILURE,
+		expectedMTU:      1280,
+		expectedLoss:     100,
+	},
+	"busybox_no_packet_loss": {
+		host:             "192.168.1.1",
+		count:            3,
+		expectedSent:     3,
+		expectedReceived: 3,
+		expectedErrors:   0,
+		expectedResult:   tnf.SUCCESS,
+		expectedRTT:      &ping.RTTStatistics{Min: 0.058, Avg: 0.065, Max: 0.076},
+		expectedLoss:     0,
 	},
 	"incorrect_ip_address": {
 		host:             "0.0.1.2",
@@ -93,6 +145,7 @@ var testCases = map[string]TestCase{
 		expectedReceived: 0,
 		expectedErrors:   0,
 		expectedResult:   tnf.ERROR,
+		expectedLoss:     100,
 	},
 }
 
@@ -122,6 +175,9 @@ func TestPing_Args(t *testing.T) {
 		request := ping.NewPing(testTimeoutDuration, testCase.host, testCase.count)
 		assert.NotNil(t, request)
 		args := []string{"ping", "-c", strconv.Itoa(testCase.count), testCase.host}
+		if ping.IsIPv6Address(testCase.host) {
+			args = []string{"ping", "-6", "-c", strconv.Itoa(testCase.count), testCase.host}
+		}
 		assert.Equal(t, args, request.Args())
 	}
 }
@@ -166,6 +222,15 @@ func TestPing_ReelMatch(t *testing.T) {
 		assert.Equal(t, testCase.expectedErrors, actualErrors)
 		actualResult := request.Result()
 		assert.Equal(t, testCase.expectedResult, actualResult)
+		assert.Equal(t, testCase.expectedMTU, request.GetReportedMTU())
+		assert.Equal(t, testCase.expectedLoss, request.GetPacketLossPercentage())
+		rtt, ok := request.GetRTTStatistics()
+		if testCase.expectedRTT != nil {
+			assert.True(t, ok)
+			assert.Equal(t, *testCase.expectedRTT, rtt)
+		} else {
+			assert.False(t, ok)
+		}
 	}
 }
 
@@ -197,4 +262,29 @@ func TestPingCmd(t *testing.T) {
 	assert.Equal(t, []string{"ping", "192.168.1.1"}, cmd)
 	cmd = ping.Command("192.168.1.1", 1)
 	assert.Equal(t, []string{"ping", "-c", "1", "192.168.1.1"}, cmd)
+	cmd = ping.Command("fd01:0:0:1::2d", 0)
+	assert.Equal(t, []string{"ping", "-6", "fd01:0:0:1::2d"}, cmd)
+	cmd = ping.Command("fd01:0:0:1::2d", 1)
+	assert.Equal(t, []string{"ping", "-6", "-c", "1", "fd01:0:0:1::2d"}, cmd)
+}
+
+func TestPingCmdWithOptions(t *testing.T) {
+	cmd := ping.CommandWithOptions("192.168.1.1", 1, ping.Options{})
+	assert.Equal(t, ping.Command("192.168.1.1", 1), cmd)
+	cmd = ping.CommandWithOptions("192.168.1.1", 2, ping.Options{PayloadSize: 8972, DontFragment: true, ReplyTimeoutSeconds: 1})
+	assert.Equal(t, []string{"ping", "-c", "2", "-s", "8972", "-M", "do", "-W", "1", "192.168.1.1"}, cmd)
+	cmd = ping.CommandWithOptions("fd01:0:0:1::2d", 0, ping.Options{PayloadSize: 1452})
+	assert.Equal(t, []string{"ping", "-6", "-s", "1452", "fd01:0:0:1::2d"}, cmd)
+
+	request := ping.NewPingWithOptions(testTimeoutDuration, "192.168.1.1", 2, ping.Options{DontFragment: true})
+	assert.Equal(t, []string{"ping", "-c", "2", "-M", "do", "192.168.1.1"}, request.Args())
+	assert.Equal(t, tnf.ERROR, request.Result())
+}
+
+func TestIsIPv6Address(t *testing.T) {
+	assert.False(t, ping.IsIPv6Address("192.168.1.1"))
+	assert.False(t, ping.IsIPv6Address("::ffff:192.168.1.1"))
+	assert.False(t, ping.IsIPv6Address("www.redhat.com"))
+	assert.True(t, ping.IsIPv6Address("fd01:0:0:1::2d"))
+	assert.True(t, ping.IsIPv6Address("::1"))
 }
@@ -0,0 +1,49 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package podspec
+
+import (
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/podpolicy"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/ocjson"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+// PodSpec fetches a Pod.
+type PodSpec struct {
+	*ocjson.OcJSON
+	pod *podpolicy.Pod
+}
+
+// NewPodSpec creates a new `PodSpec` test which fetches the pod `name` of `namespace`.
+func NewPodSpec(timeout time.Duration, namespace, name string) *PodSpec {
+	p := &PodSpec{}
+	p.OcJSON = ocjson.NewOcJSON(timeout, identifier.PodSpecIdentifier, "the pod",
+		[]string{dependencies.OcBinaryName, "get", "pod", name, "-n", namespace, "-o", "json"},
+		func(data []byte) (err error) {
+			p.pod, err = podpolicy.ParsePod(data)
+			return err
+		})
+	return p
+}
+
+// GetPod returns the fetched pod, or nil if it could not be fetched.
+func (p *PodSpec) GetPod() *podpolicy.Pod {
+	return p.pod
+}
@@ -0,0 +1,157 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package portprobe
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	// TCPExitStatusRegex matches the exit status of `nc -z`, which is zero when the TCP port accepted the connection.
+	// The UDP probe reports it too, when `nc` is not installed.
+	TCPExitStatusRegex = `(?m)^PORT_PROBE_EXIT_STATUS=(\d+)\r?$`
+	// UDPResponseBytesRegex matches the number of bytes the UDP port answered the probe payload with.
+	UDPResponseBytesRegex = `(?m)^PORT_PROBE_RESPONSE_BYTES=\s*(\d+)\r?$`
+
+	// probeTimeoutSeconds is how long nc waits for a connection or an answer.
+	probeTimeoutSeconds = "5"
+	// connectFailedStatus is the exit status of `nc -z` when the connection was refused or timed out.
+	connectFailedStatus = 1
+	// commandNotFoundStatus is the exit status of the shell when a command is not installed.
+	commandNotFoundStatus = 127
+)
+
+// PortProbe checks a single port of a host, using command line tool `nc`.
+type PortProbe struct {
+	result  int
+	timeout time.Duration
+	args    []string
+	regexes []string
+	err     string
+}
+
+// NewTCPPortProbe creates a new `PortProbe` test which checks that `port` of `host` accepts TCP connections.
+func NewTCPPortProbe(timeout time.Duration, host string, port int) *PortProbe {
+	return &PortProbe{
+		result:  tnf.ERROR,
+		timeout: timeout,
+		args: []string{dependencies.NcBinaryName, "-z", "-w", probeTimeoutSeconds, host, strconv.Itoa(port), ";",
+			dependencies.EchoBinaryName, "PORT_PROBE_EXIT_STATUS=$?"},
+		regexes: []string{TCPExitStatusRegex},
+	}
+}
+
+// NewUDPPortProbe creates a new `PortProbe` test which sends `payload` to the UDP `port` of `host`, and checks that an
+// answer is received.  As the exit status of `nc` is lost in the pipe, its absence is checked for first.
+func NewUDPPortProbe(timeout time.Duration, host string, port int, payload string) *PortProbe {
+	probe := fmt.Sprintf("printf '%%s' %s | %s %s %s -u %s %d | %s -c", shellQuote(payload), dependencies.TimeoutBinaryName,
+		probeTimeoutSeconds, dependencies.NcBinaryName, host, port, dependencies.WcBinaryName)
+	return &PortProbe{
+		result:  tnf.ERROR,
+		timeout: timeout,
+		args: []string{"command", "-v", dependencies.NcBinaryName, ">/dev/null", "||", dependencies.EchoBinaryName,
+			fmt.Sprintf("PORT_PROBE_EXIT_STATUS=%d", commandNotFoundStatus), ";",
+			dependencies.EchoBinaryName, fmt.Sprintf("PORT_PROBE_RESPONSE_BYTES=$(%s)", probe)},
+		// The missing nc is reported before the empty answer, so it is expected first.
+		regexes: []string{TCPExitStatusRegex, UDPResponseBytesRegex},
+	}
+}
+
+// shellQuote quotes `s` so that the shell passes it as a single argument, whatever it contains.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// Args returns the command line args for the test.
+func (p *PortProbe) Args() []string {
+	return p.args
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (p *PortProbe) GetIdentifier() identifier.Identifier {
+	return identifier.PortProbeIdentifier
+}
+
+// Timeout returns the timeout in seconds for the test.
+func (p *PortProbe) Timeout() time.Duration {
+	return p.timeout
+}
+
+// Result returns the test result.
+func (p *PortProbe) Result() int {
+	return p.result
+}
+
+// GetError returns why the probe could not check the port, when the result is an error.
+func (p *PortProbe) GetError() string {
+	return p.err
+}
+
+// ReelFirst returns a step which expects the outcome of the probe within the test timeout.
+func (p *PortProbe) ReelFirst() *reel.Step {
+	return &reel.Step{
+		Expect:  p.regexes,
+		Timeout: p.timeout,
+	}
+}
+
+// ReelMatch sets the test result from the outcome of the probe.  The result is success if the TCP connection was
+// accepted, or if the UDP port answered, and failure if the connection failed or the UDP port did not answer.  It is
+// an error if `nc` is not installed or fails otherwise, as the port was not checked.
+func (p *PortProbe) ReelMatch(pattern, _, match string) *reel.Step {
+	matched := regexp.MustCompile(pattern).FindStringSubmatch(match)
+	if matched == nil {
+		return nil
+	}
+	// Ignore errors in converting matches to decimal integers, as the regular expressions only match digits.
+	value, _ := strconv.Atoi(matched[1])
+	switch {
+	case pattern == UDPResponseBytesRegex && value > 0:
+		p.result = tnf.SUCCESS
+	case pattern == UDPResponseBytesRegex:
+		p.result = tnf.FAILURE
+	case value == 0:
+		p.result = tnf.SUCCESS
+	case value == connectFailedStatus:
+		p.result = tnf.FAILURE
+	case value == commandNotFoundStatus:
+		p.err = fmt.Sprintf("%s is not installed", dependencies.NcBinaryName)
+		p.result = tnf.ERROR
+	default:
+		p.err = fmt.Sprintf("%s failed with exit status %d", dependencies.NcBinaryName, value)
+		p.result = tnf.ERROR
+	}
+	return nil
+}
+
+// ReelTimeout does nothing;  no intervention is needed for a probe timeout.
+func (p *PortProbe) ReelTimeout() *reel.Step {
+	return nil
+}
+
+// ReelEOF does nothing;  no intervention is needed for a probe EOF.
+func (p *PortProbe) ReelEOF() {
+}
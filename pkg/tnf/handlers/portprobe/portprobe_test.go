@@ -0,0 +1,103 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package portprobe_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/portprobe"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 10
+	testHost            = "10.217.1.89"
+	testPort            = 8080
+)
+
+func TestNewTCPPortProbe(t *testing.T) {
+	probe := portprobe.NewTCPPortProbe(testTimeoutDuration, testHost, testPort)
+	assert.Equal(t, tnf.ERROR, probe.Result())
+	assert.Equal(t, testTimeoutDuration, probe.Timeout())
+	assert.Equal(t, identifier.PortProbeIdentifier, probe.GetIdentifier())
+	assert.Equal(t, "nc -z -w 5 10.217.1.89 8080 ; echo PORT_PROBE_EXIT_STATUS=$?", strings.Join(probe.Args(), " "))
+	assert.Equal(t, []string{portprobe.TCPExitStatusRegex}, probe.ReelFirst().Expect)
+}
+
+func TestNewUDPPortProbe(t *testing.T) {
+	probe := portprobe.NewUDPPortProbe(testTimeoutDuration, "fd00:10::3", 5353, "it's a probe")
+	assert.Equal(t, tnf.ERROR, probe.Result())
+	assert.Equal(t, `command -v nc >/dev/null || echo PORT_PROBE_EXIT_STATUS=127 ; `+
+		`echo PORT_PROBE_RESPONSE_BYTES=$(printf '%s' 'it'\''s a probe' | timeout 5 nc -u fd00:10::3 5353 | wc -c)`,
+		strings.Join(probe.Args(), " "))
+	assert.Equal(t, []string{portprobe.TCPExitStatusRegex, portprobe.UDPResponseBytesRegex}, probe.ReelFirst().Expect)
+}
+
+func TestPortProbe_ReelMatch(t *testing.T) {
+	testCases := []struct {
+		probe          *portprobe.PortProbe
+		output         string
+		expectedResult int
+		expectedError  string
+	}{
+		{portprobe.NewTCPPortProbe(testTimeoutDuration, testHost, testPort), "PORT_PROBE_EXIT_STATUS=0\n", tnf.SUCCESS, ""},
+		{portprobe.NewTCPPortProbe(testTimeoutDuration, testHost, testPort), "PORT_PROBE_EXIT_STATUS=1\n", tnf.FAILURE, ""},
+		{portprobe.NewTCPPortProbe(testTimeoutDuration, testHost, testPort), "PORT_PROBE_EXIT_STATUS=127\n", tnf.ERROR,
+			"nc is not installed"},
+		{portprobe.NewTCPPortProbe(testTimeoutDuration, testHost, testPort), "PORT_PROBE_EXIT_STATUS=2\n", tnf.ERROR,
+			"nc failed with exit status 2"},
+		{portprobe.NewUDPPortProbe(testTimeoutDuration, testHost, testPort, "ping"), "PORT_PROBE_RESPONSE_BYTES=12\n", tnf.SUCCESS, ""},
+		{portprobe.NewUDPPortProbe(testTimeoutDuration, testHost, testPort, "ping"), "PORT_PROBE_RESPONSE_BYTES=0\n", tnf.FAILURE, ""},
+		{portprobe.NewUDPPortProbe(testTimeoutDuration, testHost, testPort, "ping"),
+			"PORT_PROBE_EXIT_STATUS=127\nPORT_PROBE_RESPONSE_BYTES=0\n", tnf.ERROR, "nc is not installed"},
+	}
+	for _, testCase := range testCases {
+		// As the expecter does, the first expected pattern which matches is used.
+		var expect, match string
+		for _, expect = range testCase.probe.ReelFirst().Expect {
+			if match = regexp.MustCompile(expect).FindString(testCase.output); match != "" {
+				break
+			}
+		}
+		assert.NotEmpty(t, match)
+		assert.Nil(t, testCase.probe.ReelMatch(expect, "", match))
+		assert.Equal(t, testCase.expectedResult, testCase.probe.Result())
+		assert.Equal(t, testCase.expectedError, testCase.probe.GetError())
+	}
+}
+
+// The command line echoed back by the shell must not be mistaken for the outcome of the probe.
+func TestPortProbe_CommandEchoDoesNotMatch(t *testing.T) {
+	tcpProbe := portprobe.NewTCPPortProbe(testTimeoutDuration, testHost, testPort)
+	assert.False(t, regexp.MustCompile(portprobe.TCPExitStatusRegex).MatchString(strings.Join(tcpProbe.Args(), " ")))
+	udpProbe := portprobe.NewUDPPortProbe(testTimeoutDuration, testHost, testPort, "ping")
+	for _, expect := range udpProbe.ReelFirst().Expect {
+		assert.False(t, regexp.MustCompile(expect).MatchString(strings.Join(udpProbe.Args(), " ")))
+	}
+}
+
+func TestPortProbe_ReelTimeout(t *testing.T) {
+	probe := portprobe.NewTCPPortProbe(testTimeoutDuration, testHost, testPort)
+	assert.Nil(t, probe.ReelTimeout())
+	probe.ReelEOF()
+	assert.Equal(t, tnf.ERROR, probe.Result())
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package rbac
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/rbacpolicy"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/ocjson"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	bindingResources = "clusterrolebindings,rolebindings"
+	roleResources    = "clusterroles,roles"
+	// bindingFields and roleFields are the fields of the objects kept by the filter.  Bindings keep the annotation
+	// which marks the default bindings of the cluster.
+	bindingFields = `{kind, metadata: {name: .metadata.name, namespace: .metadata.namespace, annotations: ` +
+		`{"rbac.authorization.kubernetes.io/autoupdate": .metadata.annotations["rbac.authorization.kubernetes.io/autoupdate"]}}, ` +
+		`subjects, roleRef}`
+	roleFields = "{kind, metadata: {name: .metadata.name, namespace: .metadata.namespace}, rules}"
+)
+
+// RBAC fetches RBAC objects.
+type RBAC struct {
+	*ocjson.OcJSON
+	objects []rbacpolicy.Object
+}
+
+// NewBindings creates a new `RBAC` test which fetches the RoleBindings and ClusterRoleBindings with a subject named
+// one of `subjectNames`.
+func NewBindings(timeout time.Duration, subjectNames []string) *RBAC {
+	var conditions []string
+	for _, name := range subjectNames {
+		conditions = append(conditions, ".name == "+strconv.Quote(name))
+	}
+	selector := fmt.Sprintf("any(.subjects[]?; %s)", strings.Join(conditions, " or "))
+	return newRBAC(timeout, bindingResources, selector, bindingFields)
+}
+
+// NewRoles creates a new `RBAC` test which fetches the Roles and ClusterRoles of `keys`.
+func NewRoles(timeout time.Duration, keys []rbacpolicy.RoleKey) *RBAC {
+	var conditions []string
+	for _, key := range keys {
+		condition := fmt.Sprintf(".kind == %s and .metadata.name == %s", strconv.Quote(key.Kind), strconv.Quote(key.Name))
+		if key.Namespace != "" {
+			condition += " and .metadata.namespace == " + strconv.Quote(key.Namespace)
+		}
+		conditions = append(conditions, "("+condition+")")
+	}
+	selector := strings.Join(conditions, " or ")
+	if selector == "" {
+		selector = "false"
+	}
+	return newRBAC(timeout, roleResources, selector, roleFields)
+}
+
+// newRBAC creates a new `RBAC` test which fetches the objects of `resources` in all namespaces, and keeps the
+// `fields` of those matching `selector`.
+func newRBAC(timeout time.Duration, resources, selector, fields string) *RBAC {
+	filter := fmt.Sprintf("'{items: [.items[] | select(%s) | %s]}'", selector, fields)
+	r := &RBAC{}
+	r.OcJSON = ocjson.NewOcJSON(timeout, identifier.RBACIdentifier, "the RBAC objects",
+		[]string{dependencies.OcBinaryName, "get", resources, "--all-namespaces", "-o", "json", "|",
+			dependencies.JqBinaryName, "-c", filter},
+		func(data []byte) (err error) {
+			r.objects, err = rbacpolicy.ParseObjects(data)
+			return err
+		})
+	return r
+}
+
+// GetObjects returns the fetched objects.
+func (r *RBAC) GetObjects() []rbacpolicy.Object {
+	return r.objects
+}
@@ -0,0 +1,113 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package rbac_test
+
+import (
+	"io/ioutil"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/rbacpolicy"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/ocjson"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/rbac"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 2
+)
+
+var testServiceAccount = rbacpolicy.ServiceAccount{Namespace: "tnf", Name: "test-sa"}
+
+func getMockOutput(t *testing.T, name string) string {
+	b, err := ioutil.ReadFile(path.Join("testdata", name+".txt"))
+	assert.Nil(t, err)
+	return string(b)
+}
+
+func TestNewBindings(t *testing.T) {
+	handler := rbac.NewBindings(testTimeoutDuration, testServiceAccount.SubjectNames())
+	assert.Equal(t, tnf.ERROR, handler.Result())
+	assert.Equal(t, testTimeoutDuration, handler.Timeout())
+	assert.Equal(t, identifier.RBACIdentifier, handler.GetIdentifier())
+	assert.Equal(t, "oc get clusterrolebindings,rolebindings --all-namespaces -o json | jq -c '{items: [.items[] | "+
+		`select(any(.subjects[]?; .name == "test-sa" or .name == "system:serviceaccount:tnf:test-sa" or `+
+		`.name == "system:serviceaccounts" or .name == "system:serviceaccounts:tnf" or .name == "system:authenticated")) | `+
+		`{kind, metadata: {name: .metadata.name, namespace: .metadata.namespace, annotations: `+
+		`{"rbac.authorization.kubernetes.io/autoupdate": .metadata.annotations["rbac.authorization.kubernetes.io/autoupdate"]}}, `+
+		"subjects, roleRef}]}' ; "+
+		"echo JSON_EXIT_STATUS=$?", strings.Join(handler.Args(), " "))
+	assert.Equal(t, []string{ocjson.ExitStatusRegex}, handler.ReelFirst().Expect)
+	assert.Nil(t, handler.ReelTimeout())
+	handler.ReelEOF()
+}
+
+func TestNewRoles(t *testing.T) {
+	handler := rbac.NewRoles(testTimeoutDuration, []rbacpolicy.RoleKey{
+		{Kind: rbacpolicy.KindRole, Namespace: "tnf", Name: "app-reader"},
+		{Kind: rbacpolicy.KindClusterRole, Name: "secret-reader"},
+	})
+	assert.Equal(t, "oc get clusterroles,roles --all-namespaces -o json | jq -c '{items: [.items[] | "+
+		`select((.kind == "Role" and .metadata.name == "app-reader" and .metadata.namespace == "tnf") or `+
+		`(.kind == "ClusterRole" and .metadata.name == "secret-reader")) | `+
+		"{kind, metadata: {name: .metadata.name, namespace: .metadata.namespace}, rules}]}' ; "+
+		"echo JSON_EXIT_STATUS=$?", strings.Join(handler.Args(), " "))
+	assert.Contains(t, strings.Join(rbac.NewRoles(testTimeoutDuration, nil).Args(), " "), "select(false)")
+}
+
+func TestRBAC_ReelMatchBindings(t *testing.T) {
+	handler := rbac.NewBindings(testTimeoutDuration, testServiceAccount.SubjectNames())
+	assert.Nil(t, handler.ReelMatch(ocjson.ExitStatusRegex, getMockOutput(t, "bindings"), "JSON_EXIT_STATUS=0"))
+	assert.Equal(t, tnf.SUCCESS, handler.Result())
+	assert.Equal(t, "", handler.GetError())
+	objects := handler.GetObjects()
+	assert.Len(t, objects, 8)
+	assert.Equal(t, "RoleBinding tnf/app-reader", objects[0].String())
+	assert.Equal(t, "ClusterRoleBinding other-admin", objects[7].String())
+	assert.Equal(t, "true", objects[6].Metadata.Annotations["rbac.authorization.kubernetes.io/autoupdate"])
+	assert.Equal(t, "", objects[7].Metadata.Annotations["rbac.authorization.kubernetes.io/autoupdate"])
+	assert.Len(t, rbacpolicy.RoleKeys(testServiceAccount, objects), 6)
+}
+
+func TestRBAC_ReelMatchRoles(t *testing.T) {
+	handler := rbac.NewRoles(testTimeoutDuration, nil)
+	assert.Nil(t, handler.ReelMatch(ocjson.ExitStatusRegex, getMockOutput(t, "roles"), "JSON_EXIT_STATUS=0"))
+	assert.Equal(t, tnf.SUCCESS, handler.Result())
+	objects := handler.GetObjects()
+	assert.Len(t, objects, 2)
+	assert.Equal(t, "ClusterRole secret-reader", objects[1].String())
+	assert.Equal(t, []string{"secrets"}, objects[1].Rules[0].Resources)
+}
+
+func TestRBAC_ReelMatchUnauthorized(t *testing.T) {
+	handler := rbac.NewBindings(testTimeoutDuration, testServiceAccount.SubjectNames())
+	assert.Nil(t, handler.ReelMatch(ocjson.ExitStatusRegex, getMockOutput(t, "unauthorized"), "JSON_EXIT_STATUS=0"))
+	assert.Equal(t, tnf.FAILURE, handler.Result())
+	assert.Nil(t, handler.GetObjects())
+	assert.Equal(t, "the RBAC objects could not be fetched, exit status 0", handler.GetError())
+}
+
+func TestRBAC_ReelMatchInvalid(t *testing.T) {
+	handler := rbac.NewBindings(testTimeoutDuration, testServiceAccount.SubjectNames())
+	assert.Nil(t, handler.ReelMatch(ocjson.ExitStatusRegex, "oc get\n{\"items\": {}}\n", "JSON_EXIT_STATUS=0"))
+	assert.Equal(t, tnf.FAILURE, handler.Result())
+	assert.Contains(t, handler.GetError(), "unable to parse the RBAC objects")
+}
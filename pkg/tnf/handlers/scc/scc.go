@@ -0,0 +1,49 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package scc
+
+import (
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/podpolicy"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/ocjson"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+// SCC fetches SecurityContextConstraints.
+type SCC struct {
+	*ocjson.OcJSON
+	scc *podpolicy.SecurityContextConstraints
+}
+
+// NewSCC creates a new `SCC` test which fetches the SecurityContextConstraints `name`.
+func NewSCC(timeout time.Duration, name string) *SCC {
+	s := &SCC{}
+	s.OcJSON = ocjson.NewOcJSON(timeout, identifier.SCCIdentifier, "the SCC",
+		[]string{dependencies.OcBinaryName, "get", "scc", name, "-o", "json"},
+		func(data []byte) (err error) {
+			s.scc, err = podpolicy.ParseSecurityContextConstraints(data)
+			return err
+		})
+	return s
+}
+
+// GetSCC returns the fetched SecurityContextConstraints, or nil if they could not be fetched.
+func (s *SCC) GetSCC() *podpolicy.SecurityContextConstraints {
+	return s.scc
+}
@@ -0,0 +1,80 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package scc_test
+
+import (
+	"io/ioutil"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/podpolicy"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/ocjson"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/scc"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 2
+)
+
+func getMockOutput(t *testing.T, name string) string {
+	b, err := ioutil.ReadFile(path.Join("testdata", name+".txt"))
+	assert.Nil(t, err)
+	return string(b)
+}
+
+func TestNewSCC(t *testing.T) {
+	handler := scc.NewSCC(testTimeoutDuration, "anyuid")
+	assert.Equal(t, tnf.ERROR, handler.Result())
+	assert.Equal(t, testTimeoutDuration, handler.Timeout())
+	assert.Equal(t, identifier.SCCIdentifier, handler.GetIdentifier())
+	assert.Equal(t, "oc get scc anyuid -o json ; echo JSON_EXIT_STATUS=$?", strings.Join(handler.Args(), " "))
+	assert.Equal(t, []string{ocjson.ExitStatusRegex}, handler.ReelFirst().Expect)
+	assert.Nil(t, handler.ReelTimeout())
+	handler.ReelEOF()
+}
+
+func TestSCC_ReelMatch(t *testing.T) {
+	handler := scc.NewSCC(testTimeoutDuration, "anyuid")
+	assert.Nil(t, handler.ReelMatch(ocjson.ExitStatusRegex, getMockOutput(t, "scc"), "JSON_EXIT_STATUS=0"))
+	assert.Equal(t, tnf.SUCCESS, handler.Result())
+	assert.Equal(t, "", handler.GetError())
+	constraints := handler.GetSCC()
+	assert.NotNil(t, constraints)
+	assert.Equal(t, "anyuid", constraints.Name())
+	assert.Equal(t, podpolicy.RunAsUserRunAsAny, constraints.RunAsUser.Type)
+	assert.False(t, constraints.AllowHostNetwork)
+}
+
+func TestSCC_ReelMatchNotFound(t *testing.T) {
+	handler := scc.NewSCC(testTimeoutDuration, "missing")
+	assert.Nil(t, handler.ReelMatch(ocjson.ExitStatusRegex, getMockOutput(t, "not_found"), "JSON_EXIT_STATUS=1"))
+	assert.Equal(t, tnf.FAILURE, handler.Result())
+	assert.Nil(t, handler.GetSCC())
+	assert.Equal(t, "the SCC could not be fetched, exit status 1", handler.GetError())
+}
+
+func TestSCC_ReelMatchInvalid(t *testing.T) {
+	handler := scc.NewSCC(testTimeoutDuration, "anyuid")
+	assert.Nil(t, handler.ReelMatch(ocjson.ExitStatusRegex, "oc get scc\n{\"volumes\": 1}\n", "JSON_EXIT_STATUS=0"))
+	assert.Equal(t, tnf.FAILURE, handler.Result())
+	assert.Contains(t, handler.GetError(), "unable to parse the SCC")
+}
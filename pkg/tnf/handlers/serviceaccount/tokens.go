@@ -0,0 +1,149 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package serviceaccount
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/ocjson"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	// tokenSecretType is the type of the legacy long-lived token Secrets of service accounts.
+	tokenSecretType = "kubernetes.io/service-account-token"
+	// dockercfgSecretType is the type of the image pull Secrets which OpenShift generates for service accounts.
+	dockercfgSecretType = "kubernetes.io/dockercfg"
+	// tokenSecretServiceAccountAnnotation names the service account of a token or image pull Secret.
+	tokenSecretServiceAccountAnnotation = "kubernetes.io/service-account.name"
+	// tokenSecretNameAnnotation names the token Secret which OpenShift generated along with an image pull Secret.
+	tokenSecretNameAnnotation = "openshift.io/token-secret.name"
+	kindServiceAccount        = "ServiceAccount"
+
+	// generatedTokenSecretNameTemplate matches the names which the token controllers give the token Secrets they
+	// generate for the service account named by the parameter: its name followed by -token- and 5 random characters.
+	generatedTokenSecretNameTemplate = `^%s-token-[a-z0-9]{5}$`
+)
+
+// tokensObject is a ServiceAccount, a token Secret or an image pull Secret, as output by the filter of Tokens.  The
+// data of the Secrets is never output.
+type tokensObject struct {
+	Kind     string `json:"kind"`
+	Type     string `json:"type"`
+	Metadata struct {
+		Name        string            `json:"name"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"metadata"`
+	AutomountServiceAccountToken *bool `json:"automountServiceAccountToken"`
+	// Secrets lists the Secrets of a ServiceAccount, among which are the token Secrets generated for it.
+	Secrets []struct {
+		Name string `json:"name"`
+	} `json:"secrets"`
+}
+
+// Tokens holds whether a ServiceAccount automounts its token, and the legacy long-lived token Secrets tied to it.
+type Tokens struct {
+	*ocjson.OcJSON
+	name                      string
+	found                     bool
+	automountToken            *bool
+	tokenSecretNames          []string
+	generatedTokenSecretNames []string
+}
+
+// NewTokens creates a new Tokens tnf.Test, which fetches the ServiceAccount `name` of `namespace` along with its token
+// and image pull Secrets.
+func NewTokens(timeout time.Duration, name, namespace string) *Tokens {
+	selector := fmt.Sprintf(`(.kind == "%s" and .metadata.name == %s) or ((.type == "%s" or .type == "%s") and .metadata.annotations["%s"] == %s)`,
+		kindServiceAccount, strconv.Quote(name), tokenSecretType, dockercfgSecretType, tokenSecretServiceAccountAnnotation,
+		strconv.Quote(name))
+	filter := fmt.Sprintf(`'{items: [.items[] | select(%s) | {kind, type, metadata: {name: .metadata.name, `+
+		`annotations: {"%s": .metadata.annotations["%s"]}}, automountServiceAccountToken, secrets}]}'`,
+		selector, tokenSecretNameAnnotation, tokenSecretNameAnnotation)
+	t := &Tokens{name: name}
+	t.OcJSON = ocjson.NewOcJSON(timeout, identifier.ServiceAccountTokensIdentifier, "the service account",
+		[]string{dependencies.OcBinaryName, "get", "serviceaccounts,secrets", "-n", namespace, "-o", "json", "|",
+			dependencies.JqBinaryName, "-c", filter},
+		func(data []byte) error {
+			list := struct {
+				Items []tokensObject `json:"items"`
+			}{}
+			if err := json.Unmarshal(data, &list); err != nil {
+				return err
+			}
+			t.classifyTokenSecrets(list.Items)
+			return nil
+		})
+	return t
+}
+
+// classifyTokenSecrets tells the token Secrets generated for the service account, which are referenced by it or by
+// its image pull Secrets and named by the token controllers, from those created by users.
+func (t *Tokens) classifyTokenSecrets(objects []tokensObject) {
+	referenced := make(map[string]bool)
+	for i := range objects {
+		object := &objects[i]
+		switch {
+		case object.Kind == kindServiceAccount:
+			t.found = true
+			t.automountToken = object.AutomountServiceAccountToken
+			for _, secret := range object.Secrets {
+				referenced[secret.Name] = true
+			}
+		case object.Type == dockercfgSecretType:
+			referenced[object.Metadata.Annotations[tokenSecretNameAnnotation]] = true
+		}
+	}
+	generatedName := regexp.MustCompile(fmt.Sprintf(generatedTokenSecretNameTemplate, regexp.QuoteMeta(t.name)))
+	for i := range objects {
+		object := &objects[i]
+		if object.Kind == kindServiceAccount || object.Type != tokenSecretType {
+			continue
+		}
+		if referenced[object.Metadata.Name] && generatedName.MatchString(object.Metadata.Name) {
+			t.generatedTokenSecretNames = append(t.generatedTokenSecretNames, object.Metadata.Name)
+		} else {
+			t.tokenSecretNames = append(t.tokenSecretNames, object.Metadata.Name)
+		}
+	}
+}
+
+// Found returns true if the service account exists.
+func (t *Tokens) Found() bool {
+	return t.found
+}
+
+// GetAutomountToken returns the automountServiceAccountToken setting of the service account, or nil if it is unset.
+func (t *Tokens) GetAutomountToken() *bool {
+	return t.automountToken
+}
+
+// GetTokenSecretNames returns the names of the legacy long-lived token Secrets which users tied to the service account.
+func (t *Tokens) GetTokenSecretNames() []string {
+	return t.tokenSecretNames
+}
+
+// GetGeneratedTokenSecretNames returns the names of the legacy long-lived token Secrets which the cluster generated
+// for the service account, as before Kubernetes 1.24 and OpenShift 4.16.
+func (t *Tokens) GetGeneratedTokenSecretNames() []string {
+	return t.generatedTokenSecretNames
+}
@@ -0,0 +1,99 @@
+// Copyright (C) 2021 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package serviceaccount_test
+
+import (
+	"io/ioutil"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/ocjson"
+	sa "github.com/test-network-function/test-network-function/pkg/tnf/handlers/serviceaccount"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+func getMockOutput(t *testing.T, name string) string {
+	b, err := ioutil.ReadFile(path.Join("testdata", name+".txt"))
+	assert.Nil(t, err)
+	return string(b)
+}
+
+func TestNewTokens(t *testing.T) {
+	handler := sa.NewTokens(testTimeoutDuration, "test-sa", "tnf")
+	assert.Equal(t, tnf.ERROR, handler.Result())
+	assert.Equal(t, testTimeoutDuration, handler.Timeout())
+	assert.Equal(t, identifier.ServiceAccountTokensIdentifier, handler.GetIdentifier())
+	assert.Equal(t, "oc get serviceaccounts,secrets -n tnf -o json | jq -c '{items: [.items[] | "+
+		`select((.kind == "ServiceAccount" and .metadata.name == "test-sa") or `+
+		`((.type == "kubernetes.io/service-account-token" or .type == "kubernetes.io/dockercfg") and `+
+		`.metadata.annotations["kubernetes.io/service-account.name"] == "test-sa")) | `+
+		`{kind, type, metadata: {name: .metadata.name, annotations: {"openshift.io/token-secret.name": `+
+		`.metadata.annotations["openshift.io/token-secret.name"]}}, automountServiceAccountToken, secrets}]}' `+
+		"; echo JSON_EXIT_STATUS=$?", strings.Join(handler.Args(), " "))
+	assert.Equal(t, []string{ocjson.ExitStatusRegex}, handler.ReelFirst().Expect)
+	assert.Nil(t, handler.ReelTimeout())
+	handler.ReelEOF()
+}
+
+func TestTokens_ReelMatch(t *testing.T) {
+	handler := sa.NewTokens(testTimeoutDuration, "test-sa", "tnf")
+	assert.Nil(t, handler.ReelMatch(ocjson.ExitStatusRegex, getMockOutput(t, "tokens"), "JSON_EXIT_STATUS=0"))
+	assert.Equal(t, tnf.SUCCESS, handler.Result())
+	assert.Equal(t, "", handler.GetError())
+	assert.True(t, handler.Found())
+	assert.NotNil(t, handler.GetAutomountToken())
+	assert.True(t, *handler.GetAutomountToken())
+	// The token Secrets referenced by the service account or by its image pull Secret were generated for it.
+	assert.Equal(t, []string{"test-sa-long-lived"}, handler.GetTokenSecretNames())
+	assert.Equal(t, []string{"test-sa-token-m4r9t", "test-sa-token-x7k2p"}, handler.GetGeneratedTokenSecretNames())
+}
+
+func TestTokens_ReelMatchUnreferenced(t *testing.T) {
+	// A token Secret named like a generated one, but which nothing references, was created by a user.
+	output := "oc get serviceaccounts,secrets\n" + `{"items":[{"kind":"ServiceAccount","metadata":{"name":"test-sa"}},` +
+		`{"kind":"Secret","type":"kubernetes.io/service-account-token","metadata":{"name":"test-sa-token-abcde"}}]}` + "\n"
+	handler := sa.NewTokens(testTimeoutDuration, "test-sa", "tnf")
+	assert.Nil(t, handler.ReelMatch(ocjson.ExitStatusRegex, output, "JSON_EXIT_STATUS=0"))
+	assert.Equal(t, tnf.SUCCESS, handler.Result())
+	assert.Equal(t, []string{"test-sa-token-abcde"}, handler.GetTokenSecretNames())
+	assert.Empty(t, handler.GetGeneratedTokenSecretNames())
+}
+
+func TestTokens_ReelMatchNotFound(t *testing.T) {
+	handler := sa.NewTokens(testTimeoutDuration, "missing", "tnf")
+	assert.Nil(t, handler.ReelMatch(ocjson.ExitStatusRegex, getMockOutput(t, "tokens_not_found"), "JSON_EXIT_STATUS=0"))
+	assert.Equal(t, tnf.SUCCESS, handler.Result())
+	assert.False(t, handler.Found())
+	assert.Nil(t, handler.GetAutomountToken())
+	assert.Empty(t, handler.GetTokenSecretNames())
+	assert.Empty(t, handler.GetGeneratedTokenSecretNames())
+}
+
+func TestTokens_ReelMatchFailure(t *testing.T) {
+	handler := sa.NewTokens(testTimeoutDuration, "test-sa", "tnf")
+	assert.Nil(t, handler.ReelMatch(ocjson.ExitStatusRegex, "oc get serviceaccounts,secrets\n", "JSON_EXIT_STATUS=0"))
+	assert.Equal(t, tnf.FAILURE, handler.Result())
+	assert.Equal(t, "the service account could not be fetched, exit status 0", handler.GetError())
+
+	handler = sa.NewTokens(testTimeoutDuration, "test-sa", "tnf")
+	assert.Nil(t, handler.ReelMatch(ocjson.ExitStatusRegex, "oc get\n{\"items\": {}}\n", "JSON_EXIT_STATUS=0"))
+	assert.Equal(t, tnf.FAILURE, handler.Result())
+	assert.Contains(t, handler.GetError(), "unable to parse the service account")
+}
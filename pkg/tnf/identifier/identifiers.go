@@ -57,6 +57,20 @@ const (
 	podantiaffinityIdentifierURL          = "http://test-network-function.com/tests/testPodHighAvailability"
 	shutdownIdentifierURL                 = "http://test-network-function.com/tests/shutdown"
 	scalingIdentifierURL                  = "http://test-network-function.com/tests/scaling"
+	portProbeIdentifierURL                = "http://test-network-function.com/tests/portprobe"
+	listeningSocketsIdentifierURL         = "http://test-network-function.com/tests/listeningsockets"
+	dnsLookupIdentifierURL                = "http://test-network-function.com/tests/dnslookup"
+	netInterfacesIdentifierURL            = "http://test-network-function.com/tests/netinterfaces"
+	iperf3IdentifierURL                   = "http://test-network-function.com/tests/iperf3"
+	pciDevicesIdentifierURL               = "http://test-network-function.com/tests/pcidevices"
+	podSpecIdentifierURL                  = "http://test-network-function.com/tests/podspec"
+	sccIdentifierURL                      = "http://test-network-function.com/tests/scc"
+	rbacIdentifierURL                     = "http://test-network-function.com/tests/rbac"
+	serviceAccountTokensIdentifierURL     = "http://test-network-function.com/tests/serviceaccounttokens"
+	configMapsIdentifierURL               = "http://test-network-function.com/tests/configmaps"
+	packagesIdentifierURL                 = "http://test-network-function.com/tests/packages"
+	baseImageEvidenceIdentifierURL        = "http://test-network-function.com/tests/baseimageevidence"
+	imageLabelsIdentifierURL              = "http://test-network-function.com/tests/imagelabels"
 
 	versionOne = "v1.0.0"
 )
@@ -584,6 +598,198 @@ var Catalog = map[string]TestCatalogEntry{
 			dependencies.OcBinaryName,
 		},
 	},
+	portProbeIdentifierURL: {
+		Identifier: PortProbeIdentifier,
+		Description: "A generic test used to check that a TCP port accepts connections, or that a UDP port answers a " +
+			"probe payload, using nc.",
+		Type: Normative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.NcBinaryName,
+			dependencies.TimeoutBinaryName,
+			dependencies.WcBinaryName,
+		},
+	},
+	listeningSocketsIdentifierURL: {
+		Identifier: ListeningSocketsIdentifier,
+		Description: "A generic test used to list the TCP and UDP sockets listening in a container, by reading the " +
+			"socket tables in /proc/net.",
+		Type: Normative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.GrepBinaryName,
+		},
+	},
+	dnsLookupIdentifierURL: {
+		Identifier:  DNSLookupIdentifier,
+		Description: "A generic test used to resolve the A, AAAA or SRV records of a DNS name.",
+		Type:        Normative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.DigBinaryName,
+		},
+	},
+	netInterfacesIdentifierURL: {
+		Identifier: NetInterfacesIdentifier,
+		Description: "A generic test used to list the network interfaces of a container, along with their MTU and " +
+			"global scope addresses.",
+		Type: Normative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.IPBinaryName,
+		},
+	},
+	iperf3IdentifierURL: {
+		Identifier: Iperf3Identifier,
+		Description: "A generic test used to measure the TCP or UDP throughput between two containers with iperf3, one " +
+			"running a single use server and the other a client.",
+		Type: Normative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.Iperf3BinaryName,
+			dependencies.TimeoutBinaryName,
+		},
+	},
+	pciDevicesIdentifierURL: {
+		Identifier: PCIDevicesIdentifier,
+		Description: "A generic test used to inspect PCI devices, such as SR-IOV VFs, as seen from a container: whether " +
+			"each is present, is a VF, and which driver and network interface it has.",
+		Type: Normative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.LsBinaryName,
+			dependencies.ReadlinkBinaryName,
+			dependencies.HeadBinaryName,
+		},
+	},
+	podSpecIdentifierURL: {
+		Identifier:  PodSpecIdentifier,
+		Description: "A generic test used to fetch a pod, so that its spec can be checked against policy rules.",
+		Type:        Normative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.OcBinaryName,
+			dependencies.EchoBinaryName,
+		},
+	},
+	sccIdentifierURL: {
+		Identifier:  SCCIdentifier,
+		Description: "A generic test used to fetch SecurityContextConstraints, so that the SCC a pod runs under can be compared with those its spec needs.",
+		Type:        Normative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.OcBinaryName,
+			dependencies.EchoBinaryName,
+		},
+	},
+	rbacIdentifierURL: {
+		Identifier:  RBACIdentifier,
+		Description: "A generic test used to fetch the RBAC bindings of a service account, or the roles they grant, so that its effective permissions can be analyzed.",
+		Type:        Normative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.OcBinaryName,
+			dependencies.JqBinaryName,
+			dependencies.EchoBinaryName,
+		},
+	},
+	serviceAccountTokensIdentifierURL: {
+		Identifier:  ServiceAccountTokensIdentifier,
+		Description: "A generic test used to fetch whether a ServiceAccount automounts its token, and the legacy long-lived token Secrets tied to it.",
+		Type:        Normative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.OcBinaryName,
+			dependencies.JqBinaryName,
+			dependencies.EchoBinaryName,
+		},
+	},
+	configMapsIdentifierURL: {
+		Identifier:  ConfigMapsIdentifier,
+		Description: "A generic test used to fetch the data of the ConfigMaps referenced by a pod, so that they can be scanned for exposed credentials.",
+		Type:        Normative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.OcBinaryName,
+			dependencies.JqBinaryName,
+			dependencies.EchoBinaryName,
+		},
+	},
+	packagesIdentifierURL: {
+		Identifier:  PackagesIdentifier,
+		Description: "A generic test used to list the packages installed in a container with rpm, dpkg or apk, so that a software bill of materials can be produced.",
+		Type:        Normative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.CatBinaryName,
+			dependencies.AwkBinaryName,
+			dependencies.EchoBinaryName,
+		},
+	},
+	baseImageEvidenceIdentifierURL: {
+		Identifier:  BaseImageEvidenceIdentifier,
+		Description: "A generic test used to collect the /etc/os-release, the Red Hat build information and the package managers of a container, so that its base image can be classified.",
+		Type:        Normative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.CatBinaryName,
+			dependencies.LsBinaryName,
+			dependencies.EchoBinaryName,
+		},
+	},
+	imageLabelsIdentifierURL: {
+		Identifier:  ImageLabelsIdentifier,
+		Description: "A generic test used to fetch the labels of the config of an image with oc image info, so that the base image of a container can be classified.",
+		Type:        Normative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.OcBinaryName,
+			dependencies.JqBinaryName,
+			dependencies.EchoBinaryName,
+		},
+	},
 }
 
 // HostnameIdentifier is the Identifier used to represent the generic hostname test case.
@@ -814,3 +1020,89 @@ var ScalingIdentifier = Identifier{
 	URL:             scalingIdentifierURL,
 	SemanticVersion: versionOne,
 }
+
+// PortProbeIdentifier is the Identifier used to represent a test that checks a TCP or UDP port answers.
+var PortProbeIdentifier = Identifier{
+	URL:             portProbeIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// ListeningSocketsIdentifier is the Identifier used to represent a test that lists the listening sockets of a container.
+var ListeningSocketsIdentifier = Identifier{
+	URL:             listeningSocketsIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// DNSLookupIdentifier is the Identifier used to represent a test that resolves a DNS name.
+var DNSLookupIdentifier = Identifier{
+	URL:             dnsLookupIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// NetInterfacesIdentifier is the Identifier used to represent a test that lists the network interfaces of a container.
+var NetInterfacesIdentifier = Identifier{
+	URL:             netInterfacesIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// Iperf3Identifier is the Identifier used to represent a test that measures the network throughput with iperf3.
+var Iperf3Identifier = Identifier{
+	URL:             iperf3IdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// PCIDevicesIdentifier is the Identifier used to represent a test that inspects the PCI devices of a container.
+var PCIDevicesIdentifier = Identifier{
+	URL:             pciDevicesIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// PodSpecIdentifier is the Identifier used to represent a test that fetches the spec of a pod.
+var PodSpecIdentifier = Identifier{
+	URL:             podSpecIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// SCCIdentifier is the Identifier used to represent a test that fetches SecurityContextConstraints.
+var SCCIdentifier = Identifier{
+	URL:             sccIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// RBACIdentifier is the Identifier used to represent a test that fetches RBAC bindings or roles.
+var RBACIdentifier = Identifier{
+	URL:             rbacIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// ServiceAccountTokensIdentifier is the Identifier used to represent a test that fetches the token settings and the
+// legacy token Secrets of a service account.
+var ServiceAccountTokensIdentifier = Identifier{
+	URL:             serviceAccountTokensIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// ConfigMapsIdentifier is the Identifier used to represent a test that fetches the data of ConfigMaps.
+var ConfigMapsIdentifier = Identifier{
+	URL:             configMapsIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// PackagesIdentifier is the Identifier used to represent a test that lists the packages installed in a container.
+var PackagesIdentifier = Identifier{
+	URL:             packagesIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// BaseImageEvidenceIdentifier is the Identifier used to represent a test that collects the evidence of the base
+// image of a container.
+var BaseImageEvidenceIdentifier = Identifier{
+	URL:             baseImageEvidenceIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// ImageLabelsIdentifier is the Identifier used to represent a test that fetches the labels of an image config.
+var ImageLabelsIdentifier = Identifier{
+	URL:             imageLabelsIdentifierURL,
+	SemanticVersion: versionOne,
+}
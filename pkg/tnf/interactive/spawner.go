@@ -18,9 +18,7 @@ package interactive
 
 import (
 	"io"
-	"os"
 	"os/exec"
-	"strconv"
 	"time"
 
 	expect "github.com/google/goexpect"
@@ -28,10 +26,8 @@ import (
 )
 
 const (
-	// defaultBufferSize is the size of the input/output buffers in bytes.
+	// defaultBufferSize is the size of the input/output buffers in bytes, unless set through BufferSize.
 	defaultBufferSize = 32768
-	// defaultBufferSizeEnvironmentVariableKey is the OS environment variable name to override defaultBufferSize.
-	defaultBufferSizeEnvironmentVariableKey = "TNF_DEFAULT_BUFFER_SIZE"
 )
 
 // UnitTestMode is used to determine if the context is unit test oriented v.s. an actual CNF test run, so appropriate
@@ -191,20 +187,6 @@ func VerboseWriter(verboseWriter io.Writer) Option {
 	}
 }
 
-// getDefaultBufferSize returns the default buffer size as sourced from TNF_DEFAULT_BUFFER_SIZE.  If
-// TNF_DEFAULT_BUFFER_SIZE is not set or cannot be parsed as an integer, defaultBufferSize is returned.
-func getDefaultBufferSize() int {
-	bufferSizeFromEnv := os.Getenv(defaultBufferSizeEnvironmentVariableKey)
-	if bufferSizeFromEnv != "" {
-		if bufferSize, err := strconv.Atoi(bufferSizeFromEnv); err == nil {
-			log.Debugf("Utilizing buffer size as sourced from %s: %dB", defaultBufferSizeEnvironmentVariableKey, bufferSize)
-			return bufferSize
-		}
-	}
-	log.Debugf("Utilizing the default buffer size: %d", defaultBufferSize)
-	return defaultBufferSize
-}
-
 // GetGoExpectOptions renders the GoExpectSpawner Option(s) as expect.Option(s).
 func (g *GoExpectSpawner) GetGoExpectOptions() []expect.Option {
 	opts := make([]expect.Option, 0)
@@ -213,7 +195,7 @@ func (g *GoExpectSpawner) GetGoExpectOptions() []expect.Option {
 	if g.bufferSizeIsSet {
 		opts = append(opts, expect.BufferSize(g.bufferSize))
 	} else {
-		opts = append(opts, expect.BufferSize(getDefaultBufferSize()))
+		opts = append(opts, expect.BufferSize(defaultBufferSize))
 	}
 
 	if g.environmentSettingsIsSet {
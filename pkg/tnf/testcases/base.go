@@ -82,8 +82,6 @@ const (
 const (
 	// GatherFacts is name of the test case template for  gathering pod facts
 	GatherFacts = "GATHER_FACTS_POD"
-	// PrivilegedPod is name of the test case template for running pod privilege tests
-	PrivilegedPod = "PRIVILEGED_POD"
 	// PrivilegedRoles is name of the test case template for running cluster roles and permission tests
 	PrivilegedRoles = "PRIVILEGED_ROLE"
 	// OperatorStatus checks if csv for a given operator is installed
@@ -125,7 +123,6 @@ type PodFact struct {
 // PodTestTemplateDataMap  is map of available json data test case templates
 var PodTestTemplateDataMap = map[string]string{
 	GatherFacts:     cnf.GatherPodFactsJSON,
-	PrivilegedPod:   cnf.PrivilegedPodJSON,
 	PrivilegedRoles: cnf.RolesJSON,
 }
 
@@ -136,7 +133,6 @@ var OperatorTestTemplateDataMap = map[string]string{
 
 // CnfTestTemplateFileMap is map of configured test case filenames
 var CnfTestTemplateFileMap = map[string]string{
-	PrivilegedPod:   "privilegedpod.yml",
 	PrivilegedRoles: "privilegedroles.yml",
 	"ReadMeTxt":     "readme.txt",
 }
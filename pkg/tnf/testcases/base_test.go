@@ -70,12 +70,6 @@ func teardown() {
 	}
 }
 
-func TestLoadCNFPodPrivilegedPodTestCaseSpecs(t *testing.T) {
-	testCase, err := testcases.LoadCnfTestCaseSpecs(testcases.PrivilegedPod)
-	assert.Nil(t, err)
-	assert.NotNil(t, testCase)
-}
-
 func TestLoadInvalidTestCaseSpecs(t *testing.T) {
 	testCase, err := testcases.LoadCnfTestCaseSpecs(InValidData)
 	assert.NotNil(t, err)
@@ -142,12 +136,12 @@ func TestConfiguredTest_Operator_RenderTestCaseSpec(t *testing.T) {
 	assert.Equal(t, "CSV_INSTALLED", b.TestCase[0].Name)
 	assert.Equal(t, "CSV_SCC", b.TestCase[1].Name)
 
-	c.Name = "PRIVILEGED_POD"
-	c.Tests = []string{"HOST_NETWORK_CHECK"}
-	b, err = c.RenderTestCaseSpec(testcases.Cnf, testcases.PrivilegedPod)
+	c.Name = "PRIVILEGED_ROLE"
+	c.Tests = []string{"CLUSTER_ROLE_BINDING_BY_SA"}
+	b, err = c.RenderTestCaseSpec(testcases.Cnf, testcases.PrivilegedRoles)
 	assert.Nil(t, err)
 	assert.NotNil(t, b)
-	assert.Equal(t, "HOST_NETWORK_CHECK", b.TestCase[0].Name)
+	assert.Equal(t, "CLUSTER_ROLE_BINDING_BY_SA", b.TestCase[0].Name)
 
 	b, err = c.RenderTestCaseSpec(testcases.Cnf, InValidKey)
 	assert.NotNil(t, err)
@@ -156,12 +150,13 @@ func TestConfiguredTest_Operator_RenderTestCaseSpec(t *testing.T) {
 
 func TestConfiguredTest_CNF_RenderTestCaseSpec(t *testing.T) {
 	var c = testcases.ConfiguredTest{}
-	c.Name = "PRIVILEGED_POD"
-	c.Tests = []string{"HOST_NETWORK_CHECK"}
-	b, err := c.RenderTestCaseSpec(testcases.Cnf, testcases.PrivilegedPod)
+	c.Name = "PRIVILEGED_ROLE"
+	c.Tests = []string{"CLUSTER_ROLE_BINDING_BY_SA"}
+	b, err := c.RenderTestCaseSpec(testcases.Cnf, testcases.PrivilegedRoles)
 	assert.Nil(t, err)
 	assert.NotNil(t, b)
-	assert.Equal(t, "HOST_NETWORK_CHECK", b.TestCase[0].Name)
+	assert.Equal(t, "CLUSTER_ROLE_BINDING_BY_SA", b.TestCase[0].Name)
+	assert.False(t, b.TestCase[0].SkipTest)
 }
 
 func TestGetOutRegExp(t *testing.T) {
@@ -25,11 +25,19 @@ import (
 	ginkgoconfig "github.com/onsi/ginkgo/config"
 	"github.com/onsi/gomega"
 	log "github.com/sirupsen/logrus"
-	configpkg "github.com/test-network-function/test-network-function/pkg/config"
+	"github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/imagepolicy"
+	"github.com/test-network-function/test-network-function/pkg/podpolicy"
+	"github.com/test-network-function/test-network-function/pkg/rbacpolicy"
+	"github.com/test-network-function/test-network-function/pkg/secretscan"
 	"github.com/test-network-function/test-network-function/pkg/tnf"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/clusterrolebinding"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/configmaps"
 	containerpkg "github.com/test-network-function/test-network-function/pkg/tnf/handlers/container"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/podspec"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/rbac"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/rolebinding"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/scc"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/serviceaccount"
 	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
 	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
@@ -39,24 +47,40 @@ import (
 	"github.com/test-network-function/test-network-function/test-network-function/results"
 )
 
+const (
+	// defaultServiceAccountName is the service account of the pods which do not name one.
+	defaultServiceAccountName = "default"
+)
+
 var _ = ginkgo.Describe(common.AccessControlTestKey, func() {
 	if testcases.IsInFocus(ginkgoconfig.GinkgoConfig.FocusStrings, common.AccessControlTestKey) {
 		configData := common.ConfigurationData{}
-		configData.SetNeedsRefresh()
 		ginkgo.BeforeEach(func() {
-			common.ReloadConfiguration(&configData)
+			common.EnsureConfiguration(common.GetConfigProvider(), &configData)
 		})
 
 		testNamespace(&configData)
 
 		testRoles(&configData)
 
+		testSecurityContext()
+
+		testSCC()
+
+		testServiceAccountPermissions()
+
+		testServiceAccountTokens()
+
+		testSecretExposure()
+
+		testImageProvenance()
+
 		// Former "container" tests
 		defer ginkgo.GinkgoRecover()
 
 		// Run the tests that interact with the pods
 		ginkgo.When("under test", func() {
-			conf := configpkg.GetConfigInstance()
+			conf := common.GetConfigProvider().GetConfig()
 			podsUnderTest := conf.PodsUnderTest
 			gomega.Expect(podsUnderTest).ToNot(gomega.BeNil())
 			for _, pod := range podsUnderTest {
@@ -93,11 +117,17 @@ var _ = ginkgo.Describe(common.AccessControlTestKey, func() {
 					})
 					continue
 				}
+				testPodPolicy(pod.Namespace, pod.Name)
 				for _, testType := range pod.Tests {
 					testFile, err := testcases.LoadConfiguredTestFile(common.ConfiguredTestFile)
 					gomega.Expect(testFile).ToNot(gomega.BeNil())
 					gomega.Expect(err).To(gomega.BeNil())
 					testConfigure := testcases.ContainsConfiguredTest(testFile.CnfTest, testType)
+					if _, ok := testcases.PodTestTemplateDataMap[testType]; !ok {
+						// Such as PRIVILEGED_POD, whose checks are now the host resource rules.
+						log.Warnf("ignoring the test %s of pod %s/%s, which has no test case template", testType, pod.Namespace, pod.Name)
+						continue
+					}
 					renderedTestCase, err := testConfigure.RenderTestCaseSpec(testcases.Cnf, testType)
 					gomega.Expect(err).To(gomega.BeNil())
 					gomega.Expect(renderedTestCase).ToNot(gomega.BeNil())
@@ -165,6 +195,355 @@ func runTestsOnPod(containerCount int, testCmd testcases.BaseTestCase,
 	})
 }
 
+// testPodPolicy fetches the pod `name` of `namespace`, and evaluates the host resource rules selected by the
+// hostResourceRules setting against it, or all of them if there is no such setting.  Each rule is recorded as a
+// separate result for the pod, or for each of its containers, init containers or volumes.
+func testPodPolicy(namespace, name string) {
+	ginkgo.It(fmt.Sprintf("should not use host resources : %s/%s", namespace, name), func() {
+		defer results.RecordResult(identifiers.TestHostResourceIdentifier)
+		rules := podpolicy.HostResourceRules
+		if ruleIDs := common.GetConfigProvider().GetConfig().HostResourceRules; len(ruleIDs) > 0 {
+			var unknown []string
+			rules, unknown = podpolicy.SelectRules(podpolicy.HostResourceRules, ruleIDs)
+			if len(unknown) > 0 {
+				log.Warnf("ignoring the unknown host resource rules %v", unknown)
+			}
+		}
+		pod := getPodSpec(namespace, name)
+		var violations []string
+		for _, finding := range podpolicy.Evaluate(pod, rules) {
+			item := fmt.Sprintf("pod %s/%s %s rule %s", namespace, name, finding.Subject, finding.Rule.ID)
+			results.RecordDetailedResult(identifiers.TestHostResourceIdentifier, item, finding.Passed, finding.Describe())
+			if !finding.Passed {
+				violations = append(violations, fmt.Sprintf("%s: %s", item, finding.Reason))
+			}
+		}
+		gomega.Expect(violations).To(gomega.BeEmpty(), "host resource rules violated: %v", violations)
+	})
+}
+
+// getPodSpec fetches the pod `name` of `namespace`.
+func getPodSpec(namespace, name string) *podpolicy.Pod {
+	tester := podspec.NewPodSpec(common.DefaultTimeout, namespace, name)
+	gomega.Expect(common.RunJSONHandler(tester)).To(gomega.BeEmpty())
+	return tester.GetPod()
+}
+
+// securityContextIdentifiers maps each security context rule to the identifier its results are recorded under.
+var securityContextIdentifiers = map[string]claim.Identifier{
+	podpolicy.ReadOnlyRootFilesystemRuleID: identifiers.TestReadOnlyRootFilesystemIdentifier,
+	podpolicy.NoPrivilegeEscalationRuleID:  identifiers.TestNoPrivilegeEscalationIdentifier,
+	podpolicy.RunAsNonRootRuleID:           identifiers.TestRunAsNonRootIdentifier,
+	podpolicy.SeccompProfileRuleID:         identifiers.TestSeccompProfileIdentifier,
+	podpolicy.DropAllCapabilitiesRuleID:    identifiers.TestDropAllCapabilitiesIdentifier,
+}
+
+// testSecurityContext evaluates each security context rule against every container and init container of the pods
+// under test, recording a separate result for each of them.
+func testSecurityContext() {
+	// The pods under test are only known once the tests run, but the allowed capabilities are a setting.
+	allowedCapabilities := common.GetConfigProvider().GetConfig().AllowedCapabilities
+	pods := make(map[string]*podpolicy.Pod)
+	ginkgo.When("security context", func() {
+		for _, rule := range podpolicy.SecurityContextRules(allowedCapabilities) {
+			rule := rule
+			identifier := securityContextIdentifiers[rule.ID]
+			ginkgo.It(fmt.Sprintf("should be hardened : %s", rule.Description), func() {
+				defer results.RecordResult(identifier)
+				var violations []string
+				for _, podUnderTest := range common.GetConfigProvider().GetConfig().PodsUnderTest {
+					key := podUnderTest.Namespace + "/" + podUnderTest.Name
+					pod, ok := pods[key]
+					if !ok {
+						pod = getPodSpec(podUnderTest.Namespace, podUnderTest.Name)
+						pods[key] = pod
+					}
+					for _, finding := range podpolicy.Evaluate(pod, []*podpolicy.Rule{rule}) {
+						item := fmt.Sprintf("pod %s %s", key, finding.Subject)
+						results.RecordDetailedResult(identifier, item, finding.Passed, finding.Describe())
+						if !finding.Passed {
+							violations = append(violations, fmt.Sprintf("%s: %s", item, finding.Reason))
+						}
+					}
+				}
+				gomega.Expect(violations).To(gomega.BeEmpty(), "%s rule violated: %v", rule.ID, violations)
+			})
+		}
+	})
+}
+
+// testSCC compares the SecurityContextConstraints which admitted each pod under test with the least permissive
+// SecurityContextConstraints of the configured ladder which admit its spec, recording a separate result for each pod.
+func testSCC() {
+	ginkgo.It("should run under the least permissive SCC that admits it", func() {
+		if common.IsMinikube() {
+			ginkgo.Skip("SecurityContextConstraints are only available on OpenShift")
+		}
+		defer results.RecordResult(identifiers.TestPodSCCIdentifier)
+		conf := common.GetConfigProvider().GetConfig()
+		ladderNames := conf.SCCLadder
+		if len(ladderNames) == 0 {
+			ladderNames = podpolicy.DefaultSCCLadder
+		}
+		sccs := make(map[string]*podpolicy.SecurityContextConstraints)
+		var ladder []*podpolicy.SecurityContextConstraints
+		for _, name := range ladderNames {
+			tester := scc.NewSCC(common.DefaultTimeout, name)
+			if reason := common.RunJSONHandler(tester); reason != "" {
+				// The -v2 SCCs of the default ladder only exist from OpenShift 4.11.
+				log.Warnf("SCC %s of the ladder is left out: %s", name, reason)
+				continue
+			}
+			sccs[name] = tester.GetSCC()
+			ladder = append(ladder, tester.GetSCC())
+		}
+		var violations []string
+		for _, podUnderTest := range conf.PodsUnderTest {
+			item := fmt.Sprintf("pod %s/%s", podUnderTest.Namespace, podUnderTest.Name)
+			pod := getPodSpec(podUnderTest.Namespace, podUnderTest.Name)
+			assigned := pod.Metadata.Annotations[podpolicy.SCCAnnotation]
+			if assigned == "" {
+				reason := fmt.Sprintf("the pod has no %s annotation", podpolicy.SCCAnnotation)
+				results.RecordDetailedResult(identifiers.TestPodSCCIdentifier, item, false, reason)
+				violations = append(violations, fmt.Sprintf("%s: %s", item, reason))
+				continue
+			}
+			if _, ok := sccs[assigned]; !ok {
+				tester := scc.NewSCC(common.DefaultTimeout, assigned)
+				gomega.Expect(common.RunJSONHandler(tester)).To(gomega.BeEmpty())
+				sccs[assigned] = tester.GetSCC()
+			}
+			assessment := podpolicy.AssessSCC(pod, sccs[assigned], ladder)
+			log.Infof("%s: %s", item, assessment.Describe())
+			if assessment.Passed() {
+				results.RecordDetailedResult(identifiers.TestPodSCCIdentifier, item, true, "")
+				continue
+			}
+			results.RecordDetailedResult(identifiers.TestPodSCCIdentifier, item, false, assessment.Describe())
+			violations = append(violations, fmt.Sprintf("%s: %s", item, assessment.Describe()))
+		}
+		gomega.Expect(violations).To(gomega.BeEmpty(), "pods run under more permissive SCCs than needed: %v", violations)
+	})
+}
+
+// testServiceAccountPermissions resolves the roles bound to the service account of each pod under test, and flags the
+// dangerous rules among those it is effectively granted.  The permission report of each service account is added to
+// the claim, along with a separate result for each flagged rule.
+func testServiceAccountPermissions() {
+	ginkgo.It("should not grant dangerous permissions to the service accounts", func() {
+		defer results.RecordResult(identifiers.TestServiceAccountPermissionsIdentifier)
+		var serviceAccounts []rbacpolicy.ServiceAccount
+		seen := make(map[rbacpolicy.ServiceAccount]bool)
+		for _, pod := range getPodServiceAccounts() {
+			if !seen[pod.serviceAccount] {
+				seen[pod.serviceAccount] = true
+				serviceAccounts = append(serviceAccounts, pod.serviceAccount)
+			}
+		}
+		writeInfo := tnf.CreateTestExtraInfoWriter()
+		var violations []string
+		for _, serviceAccount := range serviceAccounts {
+			bindings := getRBACObjects(rbac.NewBindings(common.DefaultTimeout, serviceAccount.SubjectNames()))
+			roles := getRBACObjects(rbac.NewRoles(common.DefaultTimeout, rbacpolicy.RoleKeys(serviceAccount, bindings)))
+			report := rbacpolicy.Analyze(serviceAccount, append(bindings, roles...), rbacpolicy.Checks)
+			lines := report.Lines()
+			log.Info(strings.Join(lines, "\n"))
+			for _, line := range lines {
+				writeInfo(line)
+			}
+			item := fmt.Sprintf("service account %s", serviceAccount)
+			if len(report.Findings) == 0 {
+				results.RecordDetailedResult(identifiers.TestServiceAccountPermissionsIdentifier, item, true, "")
+			}
+			for _, finding := range report.Findings {
+				results.RecordDetailedResult(identifiers.TestServiceAccountPermissionsIdentifier,
+					fmt.Sprintf("%s %s via %s", item, finding.Check.ID, finding.Grant.Binding), false, finding.String())
+				violations = append(violations, fmt.Sprintf("%s: %s", item, finding))
+			}
+		}
+		gomega.Expect(violations).To(gomega.BeEmpty(), "dangerous permissions granted: %v", violations)
+	})
+}
+
+// testServiceAccountTokens flags the pods which mount the token of a service account that has no bound permissions,
+// and the service accounts which have legacy long-lived token Secrets.
+func testServiceAccountTokens() {
+	ginkgo.It("should not mount unneeded service account tokens nor have legacy token Secrets", func() {
+		defer results.RecordResult(identifiers.TestServiceAccountTokenIdentifier)
+		tokens := make(map[rbacpolicy.ServiceAccount]*serviceaccount.Tokens)
+		directBindings := make(map[rbacpolicy.ServiceAccount][]string)
+		var serviceAccounts []rbacpolicy.ServiceAccount
+		var violations []string
+		for _, pod := range getPodServiceAccounts() {
+			if _, ok := tokens[pod.serviceAccount]; !ok {
+				tokens[pod.serviceAccount] = getServiceAccountTokens(pod.serviceAccount)
+				bindings := getRBACObjects(rbac.NewBindings(common.DefaultTimeout, pod.serviceAccount.SubjectNames()))
+				directBindings[pod.serviceAccount] = rbacpolicy.DirectBindings(pod.serviceAccount, bindings)
+				serviceAccounts = append(serviceAccounts, pod.serviceAccount)
+			}
+			item := fmt.Sprintf("pod %s/%s", pod.spec.Metadata.Namespace, pod.spec.Metadata.Name)
+			reason := getTokenMountReason(pod, tokens[pod.serviceAccount], directBindings[pod.serviceAccount])
+			if reason != "" {
+				violations = append(violations, fmt.Sprintf("%s: %s", item, reason))
+			}
+			results.RecordDetailedResult(identifiers.TestServiceAccountTokenIdentifier, item, reason == "", reason)
+		}
+		for _, serviceAccount := range serviceAccounts {
+			if !tokens[serviceAccount].Found() {
+				// Already reported for each of its pods.
+				continue
+			}
+			item := fmt.Sprintf("service account %s", serviceAccount)
+			if generated := tokens[serviceAccount].GetGeneratedTokenSecretNames(); len(generated) > 0 {
+				log.Infof("%s: the cluster generated the token Secrets %s", item, strings.Join(generated, ", "))
+			}
+			reason := ""
+			if secrets := tokens[serviceAccount].GetTokenSecretNames(); len(secrets) > 0 {
+				reason = fmt.Sprintf("legacy long-lived token Secrets %s; use projected tokens instead",
+					strings.Join(secrets, ", "))
+				violations = append(violations, fmt.Sprintf("%s: %s", item, reason))
+			}
+			results.RecordDetailedResult(identifiers.TestServiceAccountTokenIdentifier, item, reason == "", reason)
+		}
+		gomega.Expect(violations).To(gomega.BeEmpty(), "service account tokens exposed: %v", violations)
+	})
+}
+
+// getTokenMountReason returns why `pod` should not mount the token of its service account, which has `tokens` and
+// `directBindings`, or an empty string.  A service account which does not exist is reported rather than assumed to
+// mount its token.
+func getTokenMountReason(pod podServiceAccount, tokens *serviceaccount.Tokens, directBindings []string) string {
+	if !tokens.Found() {
+		return fmt.Sprintf("service account %s does not exist", pod.serviceAccount)
+	}
+	if pod.spec.AutomountsServiceAccountToken(tokens.GetAutomountToken()) && len(directBindings) == 0 {
+		return fmt.Sprintf("the token of service account %s is mounted, but it has no bound permissions; "+
+			"set automountServiceAccountToken to false", pod.serviceAccount)
+	}
+	return ""
+}
+
+// testSecretExposure scans the environment variables, commands and arguments of each pod under test, and the
+// ConfigMaps they reference, for likely credentials.  Each finding is added to the claim with its value redacted.
+func testSecretExposure() {
+	ginkgo.It("should not expose credentials outside of Secrets", func() {
+		defer results.RecordResult(identifiers.TestSecretExposureIdentifier)
+		writeInfo := tnf.CreateTestExtraInfoWriter()
+		var violations []string
+		// The location of each finding follows `prefix`, while `item` names what was scanned.
+		record := func(item, prefix string, findings []secretscan.Finding) {
+			if len(findings) == 0 {
+				results.RecordDetailedResult(identifiers.TestSecretExposureIdentifier, item, true, "")
+			}
+			for i := range findings {
+				line := fmt.Sprintf("%s %s", prefix, findings[i].String())
+				writeInfo(line)
+				results.RecordDetailedResult(identifiers.TestSecretExposureIdentifier, line, false,
+					fmt.Sprintf("likely %s; %s", findings[i].Detector, secretscan.Remediation))
+				violations = append(violations, line)
+			}
+		}
+		var namespaces []string
+		configMapNames := make(map[string][]string)
+		for _, podUnderTest := range common.GetConfigProvider().GetConfig().PodsUnderTest {
+			pod := getPodSpec(podUnderTest.Namespace, podUnderTest.Name)
+			item := fmt.Sprintf("pod %s/%s", podUnderTest.Namespace, podUnderTest.Name)
+			record(item, item, secretscan.ScanPod(pod))
+			if _, ok := configMapNames[podUnderTest.Namespace]; !ok {
+				namespaces = append(namespaces, podUnderTest.Namespace)
+			}
+			configMapNames[podUnderTest.Namespace] = append(configMapNames[podUnderTest.Namespace],
+				secretscan.ConfigMapNames(pod)...)
+		}
+		for _, namespace := range namespaces {
+			// ConfigMaps shared by several pods are only fetched, and scanned, once.
+			configMaps := getConfigMaps(namespace, configMapNames[namespace])
+			for i := range configMaps {
+				configMap := &configMaps[i]
+				record(fmt.Sprintf("configmap %s/%s", namespace, configMap.Metadata.Name), "namespace "+namespace,
+					configMap.Scan())
+			}
+		}
+		gomega.Expect(violations).To(gomega.BeEmpty(), "credentials exposed: %v", violations)
+	})
+}
+
+// getConfigMaps fetches the ConfigMaps `names` of `namespace`.
+func getConfigMaps(namespace string, names []string) []secretscan.ConfigMap {
+	tester := configmaps.NewConfigMaps(common.DefaultTimeout, namespace, names)
+	gomega.Expect(common.RunJSONHandler(tester)).To(gomega.BeEmpty())
+	return tester.GetConfigMaps()
+}
+
+// testImageProvenance flags the images of the containers and init containers of the pods under test which are not
+// referenced as the image policy requires, and those whose tag resolves to different images.  The image each container
+// runs, as resolved in the pod status, is added to the claim.
+func testImageProvenance() {
+	ginkgo.It("should reference images by pinned tag or digest from allowed registries", func() {
+		defer results.RecordResult(identifiers.TestImageProvenanceIdentifier)
+		conf := common.GetConfigProvider().GetConfig()
+		policy := &imagepolicy.Policy{
+			RequireDigest:     conf.ImagePolicy.RequireDigest,
+			AllowedRegistries: conf.ImagePolicy.AllowedRegistries,
+		}
+		var containers []imagepolicy.Container
+		for _, podUnderTest := range conf.PodsUnderTest {
+			containers = append(containers, imagepolicy.Containers(getPodSpec(podUnderTest.Namespace, podUnderTest.Name))...)
+		}
+		writeInfo := tnf.CreateTestExtraInfoWriter()
+		flagged := make(map[*imagepolicy.Container]bool)
+		var violations []string
+		for _, finding := range policy.Evaluate(containers) {
+			flagged[finding.Container] = true
+			results.RecordDetailedResult(identifiers.TestImageProvenanceIdentifier,
+				fmt.Sprintf("%s %s", finding.Container, finding.CheckID), false, finding.Reason)
+			violations = append(violations, finding.String())
+		}
+		for i := range containers {
+			if containers[i].ImageID != "" {
+				writeInfo(fmt.Sprintf("%s: image %s runs as %s", &containers[i], containers[i].Image, containers[i].ImageID))
+			}
+			if !flagged[&containers[i]] {
+				results.RecordDetailedResult(identifiers.TestImageProvenanceIdentifier, containers[i].String(), true, "")
+			}
+		}
+		gomega.Expect(violations).To(gomega.BeEmpty(), "image references violate the image policy: %v", violations)
+	})
+}
+
+// podServiceAccount is a pod under test along with its service account.
+type podServiceAccount struct {
+	spec           *podpolicy.Pod
+	serviceAccount rbacpolicy.ServiceAccount
+}
+
+// getPodServiceAccounts fetches each pod under test, along with the service account it runs as.
+func getPodServiceAccounts() []podServiceAccount {
+	var pods []podServiceAccount
+	for _, podUnderTest := range common.GetConfigProvider().GetConfig().PodsUnderTest {
+		pod := getPodSpec(podUnderTest.Namespace, podUnderTest.Name)
+		serviceAccount := rbacpolicy.ServiceAccount{Namespace: podUnderTest.Namespace, Name: pod.Spec.ServiceAccountName}
+		if serviceAccount.Name == "" {
+			serviceAccount.Name = defaultServiceAccountName
+		}
+		pods = append(pods, podServiceAccount{spec: pod, serviceAccount: serviceAccount})
+	}
+	return pods
+}
+
+// getServiceAccountTokens fetches the token settings and the legacy token Secrets of `serviceAccount`.
+func getServiceAccountTokens(serviceAccount rbacpolicy.ServiceAccount) *serviceaccount.Tokens {
+	tester := serviceaccount.NewTokens(common.DefaultTimeout, serviceAccount.Name, serviceAccount.Namespace)
+	gomega.Expect(common.RunJSONHandler(tester)).To(gomega.BeEmpty())
+	return tester
+}
+
+// getRBACObjects runs `tester`, and returns the RBAC objects it fetched.
+func getRBACObjects(tester *rbac.RBAC) []rbacpolicy.Object {
+	gomega.Expect(common.RunJSONHandler(tester)).To(gomega.BeEmpty())
+	return tester.GetObjects()
+}
+
 func testNamespace(configData *common.ConfigurationData) {
 	ginkgo.When("test deployment namespace", func() {
 		ginkgo.It("Should not be 'default' and should not begin with 'openshift-'", func() {
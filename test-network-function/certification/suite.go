@@ -18,12 +18,16 @@ package certification
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/onsi/ginkgo"
 	ginkgoconfig "github.com/onsi/ginkgo/config"
 	"github.com/onsi/gomega"
 	"github.com/test-network-function/test-network-function/internal/api"
-	configpkg "github.com/test-network-function/test-network-function/pkg/config"
+	"github.com/test-network-function/test-network-function/pkg/advisory"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"github.com/test-network-function/test-network-function/pkg/sbom"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
 	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
 	"github.com/test-network-function/test-network-function/test-network-function/common"
 	"github.com/test-network-function/test-network-function/test-network-function/identifiers"
@@ -35,16 +39,20 @@ const (
 	eventuallyTimeoutSeconds = 30
 	// interval of time
 	interval = 1
+	// defaultFailSeverity is the lowest severity of the fixable vulnerabilities which fail the test, unless configured.
+	defaultFailSeverity = advisory.SeverityImportant
 )
 
 var certAPIClient api.CertAPIClient
 
 var _ = ginkgo.Describe(common.AffiliatedCertTestKey, func() {
 	if testcases.IsInFocus(ginkgoconfig.GinkgoConfig.FocusStrings, common.AffiliatedCertTestKey) {
-		return
+		testContainerCertificationStatus()
+
+		testOperatorCertificationStatus()
+
+		testContainerVulnerabilities()
 	}
-	testContainerCertificationStatus()
-	testOperatorCertificationStatus()
 })
 
 func testContainerCertificationStatus() {
@@ -52,7 +60,7 @@ func testContainerCertificationStatus() {
 	ginkgo.When("getting certification status", func() {
 		ginkgo.It("get certification status", func() {
 			defer results.RecordResult(identifiers.TestContainerIsCertifiedIdentifier)
-			conf := configpkg.GetConfigInstance()
+			conf := common.GetConfigProvider().GetConfig()
 			cnfsToQuery := conf.CertifiedContainerInfo
 			if len(cnfsToQuery) > 0 {
 				certAPIClient = api.NewHTTPClient()
@@ -73,7 +81,7 @@ func testContainerCertificationStatus() {
 func testOperatorCertificationStatus() {
 	ginkgo.It("Verify operator as certified", func() {
 		defer results.RecordResult(identifiers.TestOperatorIsCertifiedIdentifier)
-		operatorsToQuery := configpkg.GetConfigInstance().CertifiedOperatorInfo
+		operatorsToQuery := common.GetConfigProvider().GetConfig().CertifiedOperatorInfo
 		if len(operatorsToQuery) > 0 {
 			certAPIClient := api.NewHTTPClient()
 			for _, certified := range operatorsToQuery {
@@ -88,3 +96,74 @@ func testOperatorCertificationStatus() {
 		}
 	})
 }
+
+func testContainerVulnerabilities() {
+	ginkgo.It("should have no fixable vulnerabilities in the packages of the containers", func() {
+		conf := common.GetConfigProvider().GetConfig()
+		feed := conf.VulnerabilityFeed
+		if len(feed.Paths) == 0 {
+			ginkgo.Skip("no vulnerability feed is configured")
+		}
+		defer results.RecordResult(identifiers.TestContainerVulnerabilitiesIdentifier)
+		failSeverity := defaultFailSeverity
+		if feed.FailSeverity != "" {
+			var err error
+			failSeverity, err = advisory.ParseSeverity(feed.FailSeverity)
+			gomega.Expect(err).To(gomega.BeNil())
+		}
+		advisories, err := advisory.LoadFeed(feed.Paths)
+		gomega.Expect(err).To(gomega.BeNil())
+		gomega.Expect(advisories).NotTo(gomega.BeEmpty(), "the vulnerability feed %v has no advisories", feed.Paths)
+		writeInfo := tnf.CreateTestExtraInfoWriter()
+		writeInfo(fmt.Sprintf("%d advisories read from %s", len(advisories), strings.Join(feed.Paths, ", ")))
+
+		violations := matchContainers(conf.ContainersUnderTest, advisories, failSeverity, writeInfo)
+		gomega.Expect(violations).To(gomega.BeEmpty(), "images have fixable vulnerabilities of %s severity or higher: %v",
+			failSeverity, violations)
+	})
+}
+
+// matchContainers matches the packages of each image of `containers` once, reusing the packages listed by the
+// diagnostic suite, and returns the fixable vulnerabilities of `failSeverity` or higher, along with the images whose
+// packages could not be listed.
+func matchContainers(containers []configsections.Container, advisories []advisory.Advisory, failSeverity advisory.Severity,
+	writeInfo func(string)) []string {
+	matched := make(map[string]bool)
+	var violations []string
+	for i := range containers {
+		image := common.ImageKey(&containers[i])
+		if matched[image] {
+			continue
+		}
+		matched[image] = true
+		inventory, reason := common.GetPackageInventory(&containers[i], nil, common.DefaultTimeout)
+		if reason != "" {
+			results.RecordDetailedResult(identifiers.TestContainerVulnerabilitiesIdentifier, "image "+image, false, reason)
+			violations = append(violations, fmt.Sprintf("image %s: the packages could not be listed: %s", image, reason))
+			continue
+		}
+		violations = append(violations, matchImage(image, inventory, advisories, failSeverity, writeInfo)...)
+	}
+	return violations
+}
+
+// matchImage records and writes with `writeInfo` the fixable vulnerabilities of the packages in `inventory`, listed
+// from `image`, and returns those of `failSeverity` or higher.
+func matchImage(image string, inventory *sbom.Inventory, advisories []advisory.Advisory, failSeverity advisory.Severity,
+	writeInfo func(string)) []string {
+	findings := advisory.Match(inventory, advisories)
+	if len(findings) == 0 {
+		results.RecordDetailedResult(identifiers.TestContainerVulnerabilitiesIdentifier, "image "+image, true, "")
+	}
+	var violations []string
+	for i := range findings {
+		item := fmt.Sprintf("image %s %s %s", image, findings[i].Package, findings[i].CVE)
+		passed := findings[i].Severity < failSeverity
+		results.RecordDetailedResult(identifiers.TestContainerVulnerabilitiesIdentifier, item, passed, findings[i].String())
+		writeInfo(fmt.Sprintf("image %s: %s", image, &findings[i]))
+		if !passed {
+			violations = append(violations, fmt.Sprintf("image %s: %s", image, &findings[i]))
+		}
+	}
+	return violations
+}
@@ -17,17 +17,18 @@
 package common
 
 import (
-	"os"
 	"path"
-	"strconv"
 	"time"
 
 	"github.com/onsi/gomega"
 	log "github.com/sirupsen/logrus"
 	"github.com/test-network-function/test-network-function/pkg/config"
 	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"github.com/test-network-function/test-network-function/pkg/sbom"
 	"github.com/test-network-function/test-network-function/pkg/tnf"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/ipaddr"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/ocjson"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/packages"
 	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
 	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
 )
@@ -63,7 +64,7 @@ func getOcSession(pod, container, namespace string, timeout time.Duration, optio
 	var spawner interactive.Spawner = goExpectSpawner
 
 	go func() {
-		oc, outCh, err := interactive.SpawnOc(&spawner, pod, container, namespace, timeout, options...)
+		oc, outCh, err := interactive.SpawnOc(&spawner, pod, container, namespace, timeout, append(GetSpawnerOptions(), options...)...)
 		gomega.Expect(outCh).ToNot(gomega.BeNil())
 		gomega.Expect(err).To(gomega.BeNil())
 		ocChan <- oc
@@ -85,12 +86,13 @@ func getOcSession(pod, container, namespace string, timeout time.Duration, optio
 // Container is an internal construct which follows the Container design pattern.  Essentially, a Container holds the
 // pertinent information to perform a test against or using an Operating System Container.  This includes facets such
 // as the reference to the interactive.Oc instance, the reference to the test configuration, and the default network
-// IP address.
+// IP addresses.  DefaultNetworkIPv6Address is empty when the default network has no global IPv6 address.
 type Container struct {
-	ContainerConfiguration  configsections.Container
-	Oc                      *interactive.Oc
-	DefaultNetworkIPAddress string
-	ContainerIdentifier     configsections.ContainerIdentifier
+	ContainerConfiguration    configsections.Container
+	Oc                        *interactive.Oc
+	DefaultNetworkIPAddress   string
+	DefaultNetworkIPv6Address string
+	ContainerIdentifier       configsections.ContainerIdentifier
 }
 
 // createContainers contains the general steps involved in creating "oc" sessions and other configuration. A map of the
@@ -100,28 +102,31 @@ func createContainers(containerDefinitions []configsections.Container) map[confi
 	for _, c := range containerDefinitions {
 		oc := getOcSession(c.PodName, c.ContainerName, c.Namespace, DefaultTimeout, interactive.Verbose(true))
 		var defaultIPAddress = "UNKNOWN"
+		var defaultIPv6Address string
 		if _, ok := ContainersToExcludeFromConnectivityTests[c.ContainerIdentifier]; !ok {
-			defaultIPAddress = getContainerDefaultNetworkIPAddress(oc, c.DefaultNetworkDevice)
+			defaultIPAddress, defaultIPv6Address = getContainerDefaultNetworkIPAddresses(oc, c.DefaultNetworkDevice)
 		}
 		createdContainers[c.ContainerIdentifier] = &Container{
-			ContainerConfiguration:  c,
-			Oc:                      oc,
-			DefaultNetworkIPAddress: defaultIPAddress,
-			ContainerIdentifier:     c.ContainerIdentifier,
+			ContainerConfiguration:    c,
+			Oc:                        oc,
+			DefaultNetworkIPAddress:   defaultIPAddress,
+			DefaultNetworkIPv6Address: defaultIPv6Address,
+			ContainerIdentifier:       c.ContainerIdentifier,
 		}
 	}
 	return createdContainers
 }
 
-// Extract a container IP address for a particular device.  This is needed since container default network IP address
-// is served by dhcp, and thus is ephemeral.
-func getContainerDefaultNetworkIPAddress(oc *interactive.Oc, dev string) string {
+// Extract the container IPv4 and IPv6 addresses for a particular device.  This is needed since container default
+// network IP address is served by dhcp, and thus is ephemeral.  Either address is empty when the device has none, as on
+// single-stack clusters.
+func getContainerDefaultNetworkIPAddresses(oc *interactive.Oc, dev string) (ipv4Address, ipv6Address string) {
 	log.Infof("Getting IP Information for: %s(%s) in ns=%s", oc.GetPodName(), oc.GetPodContainerName(), oc.GetPodNamespace())
 	ipTester := ipaddr.NewIPAddr(DefaultTimeout, dev)
 	test, err := tnf.NewTest(oc.GetExpecter(), ipTester, []reel.Handler{ipTester}, oc.GetErrorChannel())
 	gomega.Expect(err).To(gomega.BeNil())
 	RunAndValidateTest(test)
-	return ipTester.GetIPv4Address()
+	return ipTester.GetIPv4Address(), ipTester.GetIPv6Address()
 }
 
 // CreateContainersUnderTest sets up the test containers.
@@ -136,7 +141,8 @@ func CreatePartnerContainers(conf *configsections.TestConfiguration) map[configs
 
 // GetContext spawns a new shell session and returns its context
 func GetContext() *interactive.Context {
-	context, err := interactive.SpawnShell(interactive.CreateGoExpectSpawner(), DefaultTimeout, interactive.Verbose(true))
+	options := append(GetSpawnerOptions(), interactive.Verbose(true))
+	context, err := interactive.SpawnShell(interactive.CreateGoExpectSpawner(), DefaultTimeout, options...)
 	gomega.Expect(err).To(gomega.BeNil())
 	gomega.Expect(context).ToNot(gomega.BeNil())
 	gomega.Expect(context.GetExpecter()).ToNot(gomega.BeNil())
@@ -150,22 +156,99 @@ func RunAndValidateTest(test *tnf.Test) {
 	gomega.Expect(err).To(gomega.BeNil())
 }
 
-// GetTestConfiguration returns the cnf-certification-generic-tests test configuration.
-func GetTestConfiguration() *configsections.TestConfiguration {
-	conf := config.GetConfigInstance()
+// RunJSONHandler runs `handler`, which fetches and parses a JSON document, and returns why it could not, or an empty
+// string.
+func RunJSONHandler(handler ocjson.Handler) string {
+	context := GetContext()
+	test, err := tnf.NewTest(context.GetExpecter(), handler, []reel.Handler{handler}, context.GetErrorChannel())
+	gomega.Expect(err).To(gomega.BeNil())
+	testResult, err := test.Run()
+	gomega.Expect(err).To(gomega.BeNil())
+	if testResult == tnf.SUCCESS {
+		return ""
+	}
+	if reason := handler.GetError(); reason != "" {
+		return reason
+	}
+	return "the command did not complete"
+}
+
+// inventories holds the packages installed in each image of the containers under test, by ImageKey, so that an image is
+// only listed once whichever suites need its packages.
+var inventories = make(map[string]*sbom.Inventory)
+
+// ImageKey returns the image ID of `container`, or its image reference when it has not reported its image ID.
+func ImageKey(container *configsections.Container) string {
+	if container.ImageID != "" {
+		return container.ImageID
+	}
+	return container.Image
+}
+
+// GetPackageInventory returns the packages installed in the image of `container`, and why they could not be listed, or
+// an empty string.  Unless the image was listed already, the packages are listed through `oc`, or through a session
+// opened for the purpose when `oc` is nil.
+func GetPackageInventory(container *configsections.Container, oc *interactive.Oc, timeout time.Duration) (*sbom.Inventory, string) {
+	key := ImageKey(container)
+	if inventory, ok := inventories[key]; ok {
+		return inventory, ""
+	}
+	if oc == nil {
+		oc = getOcSession(container.PodName, container.ContainerName, container.Namespace, DefaultTimeout, interactive.Verbose(true))
+		defer closeSession(oc)
+	}
+	tester := packages.NewPackages(timeout)
+	test, err := tnf.NewTest(oc.GetExpecter(), tester, []reel.Handler{tester}, oc.GetErrorChannel())
+	gomega.Expect(err).To(gomega.BeNil())
+	testResult, err := test.Run()
+	gomega.Expect(err).To(gomega.BeNil())
+	if testResult != tnf.SUCCESS {
+		if reason := tester.GetError(); reason != "" {
+			return nil, reason
+		}
+		return nil, "the command did not complete"
+	}
+	inventories[key] = tester.GetInventory()
+	return inventories[key], ""
+}
+
+// configProvider supplies the test configuration to the test suites.  It defaults to the environment driven provider,
+// and is replaced by the test entrypoint through SetConfigProvider.
+var configProvider config.Provider = config.NewEnvProvider()
+
+// SetConfigProvider sets the configuration provider used by the test suites.
+func SetConfigProvider(provider config.Provider) {
+	configProvider = provider
+}
+
+// GetConfigProvider returns the configuration provider used by the test suites.
+func GetConfigProvider() config.Provider {
+	return configProvider
+}
+
+// GetTestConfiguration returns the cnf-certification-generic-tests test configuration supplied by `provider`.
+func GetTestConfiguration(provider config.Provider) *configsections.TestConfiguration {
+	conf := provider.GetConfig()
 	return &conf
 }
 
-// IsMinikube returns true when the env var is set, OCP only test would be skipped based on this flag
+// IsMinikube returns true when the minikubeOnly setting is set, OCP only test would be skipped based on this flag
 func IsMinikube() bool {
-	b, _ := strconv.ParseBool(os.Getenv("TNF_MINIKUBE_ONLY"))
-	return b
+	return configProvider.GetConfig().Settings.MinikubeOnly
 }
 
 // NonIntrusive is for skipping tests that would impact the CNF or test environment in an intrusive way
 func NonIntrusive() bool {
-	b, _ := strconv.ParseBool(os.Getenv("TNF_NON_INTRUSIVE_ONLY"))
-	return b
+	return configProvider.GetConfig().Settings.NonIntrusiveOnly
+}
+
+// GetSpawnerOptions returns the interactive session options that follow from the settings, such as the
+// defaultBufferSize setting, which every session spawned by the suites should be given.
+func GetSpawnerOptions() []interactive.Option {
+	if bufferSize := configProvider.GetConfig().Settings.DefaultBufferSize; bufferSize > 0 {
+		return []interactive.Option{interactive.BufferSize(bufferSize)}
+	}
+	return nil
 }
 
 // ConfigurationData is used to host test configuration
@@ -174,8 +257,6 @@ type ConfigurationData struct {
 	PartnerContainers   map[configsections.ContainerIdentifier]*Container
 	TestOrchestrator    *Container
 	FsDiffContainer     *Container
-
-	needsRefresh bool
 }
 
 // createContainersUnderTest sets up the test containers.
@@ -188,10 +269,10 @@ func createPartnerContainers(conf *configsections.TestConfiguration) map[configs
 	return createContainers(conf.PartnerContainers)
 }
 
-// Loadconfiguration the configuration into ConfigurationData
-func Loadconfiguration(configData *ConfigurationData) {
-	conf := GetTestConfiguration()
-	log.Infof("Test Configuration: %s", conf)
+// Loadconfiguration the configuration supplied by `provider` into ConfigurationData
+func Loadconfiguration(provider config.Provider, configData *ConfigurationData) {
+	conf := GetTestConfiguration(provider)
+	log.Infof("Test Configuration: %+v", conf)
 
 	for _, cid := range conf.ExcludeContainersFromConnectivityTests {
 		ContainersToExcludeFromConnectivityTests[cid] = ""
@@ -204,16 +285,39 @@ func Loadconfiguration(configData *ConfigurationData) {
 	log.Info(configData.ContainersUnderTest)
 }
 
-// ReloadConfiguration force the autodiscovery to run again
-func ReloadConfiguration(configData *ConfigurationData) {
-	if configData.needsRefresh {
-		config.SetNeedsRefresh()
-		Loadconfiguration(configData)
+// EnsureConfiguration reloads the configuration supplied by `provider` into `configData`, unless it holds one already.
+// Suites call it before each test, so that each suite sets up its containers once, from the pods found when its first
+// test runs rather than those found by an earlier suite, which may have been recreated since.
+func EnsureConfiguration(provider config.Provider, configData *ConfigurationData) {
+	if configData.ContainersUnderTest == nil {
+		ReloadConfiguration(provider, configData)
+	}
+}
+
+// CloseConfiguration ends the oc sessions of the containers held by `configData`.
+func CloseConfiguration(configData *ConfigurationData) {
+	for _, containers := range []map[configsections.ContainerIdentifier]*Container{configData.ContainersUnderTest, configData.PartnerContainers} {
+		for _, container := range containers {
+			closeSession(container.Oc)
+		}
 	}
-	configData.needsRefresh = false
+	*configData = ConfigurationData{}
 }
 
-// SetNeedsRefresh indicate the config should be reloaded after this test
-func (configData *ConfigurationData) SetNeedsRefresh() {
-	configData.needsRefresh = true
+// closeSession ends the oc session `oc`.
+func closeSession(oc *interactive.Oc) {
+	if err := (*oc.GetExpecter()).Close(); err != nil {
+		log.Debugf("unable to close the session of container %s/%s/%s: %s", oc.GetPodNamespace(), oc.GetPodName(),
+			oc.GetPodContainerName(), err)
+	}
+}
+
+// ReloadConfiguration force the autodiscovery to run again, then loads the refreshed configuration into `configData`,
+// ending the sessions of the containers it held.  Tests which cause the pods under test to be recreated call it once
+// they are done.
+func ReloadConfiguration(provider config.Provider, configData *ConfigurationData) {
+	CloseConfiguration(configData)
+	err := provider.Refresh()
+	gomega.Expect(err).To(gomega.BeNil())
+	Loadconfiguration(provider, configData)
 }
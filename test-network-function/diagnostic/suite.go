@@ -2,6 +2,7 @@ package diagnostic
 
 import (
 	"encoding/json"
+	"fmt"
 	"path"
 	"strings"
 	"time"
@@ -12,8 +13,13 @@ import (
 
 	"github.com/onsi/ginkgo"
 	"github.com/onsi/gomega"
+	"github.com/test-network-function/test-network-function/pkg/baseimage"
+	"github.com/test-network-function/test-network-function/pkg/imagepolicy"
+	"github.com/test-network-function/test-network-function/pkg/sbom"
 	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/base/evidence"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/generic"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/imagelabels"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/nodedebug"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/nodenames"
 	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
@@ -35,6 +41,13 @@ var (
 
 	nodesHwInfo = NodesHwInfo{}
 
+	// sboms stores the software bill of materials of each image of the containers under test, by image ID, or by image
+	// reference for containers which have not reported their image ID.
+	sboms = make(map[string]*sbom.CycloneDX)
+
+	// baseImages stores the class of the base image of each container under test, by namespace, pod and container name.
+	baseImages = make(map[string]baseimage.Classification)
+
 	// nodesTestPath is the file location of the nodes.json test case relative to the project root.
 	nodesTestPath = path.Join("pkg", "tnf", "handlers", "node", "nodes.json")
 
@@ -89,6 +102,20 @@ var _ = ginkgo.Describe(common.DiagnosticTestKey, func() {
 			testNodesHwInfo()
 		})
 	})
+	ginkgo.When("containers are under test", func() {
+		configData := common.ConfigurationData{}
+		ginkgo.BeforeEach(func() {
+			common.EnsureConfiguration(common.GetConfigProvider(), &configData)
+		})
+		ginkgo.It("should report the installed packages of each container", func() {
+			defer results.RecordResult(identifiers.TestPackageInventoryIdentifier)
+			testPackageInventory(&configData)
+		})
+		ginkgo.It("should report the base image of each container", func() {
+			defer results.RecordResult(identifiers.TestBaseImageClassIdentifier)
+			testBaseImageClass(&configData)
+		})
+	})
 })
 
 // CniPlugin holds info about a CNI plugin
@@ -127,6 +154,85 @@ func GetNodesHwInfo() NodesHwInfo {
 	return nodesHwInfo
 }
 
+// GetSBOMs returns the software bill of materials of each image of the containers under test, by image ID.
+func GetSBOMs() map[string]*sbom.CycloneDX {
+	return sboms
+}
+
+// GetBaseImages returns the class of the base image of each container under test, by namespace, pod and container
+// name.
+func GetBaseImages() map[string]baseimage.Classification {
+	return baseImages
+}
+
+// testBaseImageClass classifies the base image of each container under test from the evidence collected in the
+// container and the labels of its image, either of which may be unavailable.
+func testBaseImageClass(configData *common.ConfigurationData) {
+	writeInfo := tnf.CreateTestExtraInfoWriter()
+	for _, cut := range configData.ContainersUnderTest {
+		conf := cut.ContainerConfiguration
+		item := fmt.Sprintf("%s/%s/%s", conf.Namespace, conf.PodName, conf.ContainerName)
+		containerEvidence, reason := getBaseImageEvidence(cut)
+		if containerEvidence == nil {
+			writeInfo(fmt.Sprintf("container %s: %s", item, reason))
+		}
+		labelsTester := imagelabels.NewImageLabels(defaultTestTimeout, imageInfoReference(conf.Image, conf.ImageID))
+		if reason := common.RunJSONHandler(labelsTester); reason != "" {
+			writeInfo(fmt.Sprintf("container %s: %s", item, reason))
+		}
+		classification := baseimage.Classify(containerEvidence, labelsTester.GetLabels())
+		baseImages[item] = classification
+		results.RecordDetailedResult(identifiers.TestBaseImageClassIdentifier, "container "+item, true, "")
+		writeInfo(fmt.Sprintf("container %s: base image %s, from %s", item, &classification, classification.Reason))
+	}
+}
+
+// getBaseImageEvidence collects the evidence of the base image of the container under test `cut`, and returns it, or
+// why it could not be collected.
+func getBaseImageEvidence(cut *common.Container) (*baseimage.Evidence, string) {
+	tester := evidence.NewEvidence(defaultTestTimeout)
+	test, err := tnf.NewTest(cut.Oc.GetExpecter(), tester, []reel.Handler{tester}, cut.Oc.GetErrorChannel())
+	gomega.Expect(err).To(gomega.BeNil())
+	testResult, err := test.Run()
+	gomega.Expect(err).To(gomega.BeNil())
+	if testResult == tnf.ERROR {
+		return nil, "timed out collecting the base image evidence"
+	}
+	return tester.GetEvidence(), tester.GetError()
+}
+
+// imageInfoReference returns the reference of the image a container runs, which is pinned by the digest of its
+// `imageID` when it has one, or `image` otherwise.
+func imageInfoReference(image, imageID string) string {
+	if i := strings.Index(imageID, "://"); i >= 0 {
+		imageID = imageID[i+len("://"):]
+	}
+	if strings.Contains(imageID, "@") {
+		return imageID
+	}
+	return image
+}
+
+// testPackageInventory lists the packages installed in each container under test, once per image, and records the
+// software bill of materials of the image.
+func testPackageInventory(configData *common.ConfigurationData) {
+	writeInfo := tnf.CreateTestExtraInfoWriter()
+	for _, cut := range configData.ContainersUnderTest {
+		conf := cut.ContainerConfiguration
+		item := fmt.Sprintf("container %s/%s/%s", conf.Namespace, conf.PodName, conf.ContainerName)
+		key := common.ImageKey(&conf)
+		if _, ok := sboms[key]; ok {
+			writeInfo(fmt.Sprintf("%s: image %s already listed", item, key))
+			continue
+		}
+		inventory, reason := common.GetPackageInventory(&conf, cut.Oc, defaultTestTimeout)
+		gomega.Expect(reason).To(gomega.BeEmpty(), "%s: the packages could not be listed", item)
+		sboms[key] = sbom.NewCycloneDX(conf.Image, imagepolicy.ImageIDDigest(conf.ImageID), inventory)
+		writeInfo(fmt.Sprintf("%s: %d packages listed with %s in image %s", item, len(inventory.Packages),
+			strings.Join(inventory.Managers(), ", "), key))
+	}
+}
+
 func getFirstNode(labelFilter map[string]*string) string {
 	context := common.GetContext()
 	tester := nodenames.NewNodeNames(defaultTestTimeout, labelFilter)
@@ -43,9 +43,8 @@ const (
 var _ = ginkgo.Describe(testsKey, func() {
 	if testcases.IsInFocus(ginkgoconfig.GinkgoConfig.FocusStrings, testsKey) {
 		configData := common.ConfigurationData{}
-		configData.SetNeedsRefresh()
 		ginkgo.BeforeEach(func() {
-			common.ReloadConfiguration(&configData)
+			common.EnsureConfiguration(common.GetConfigProvider(), &configData)
 		})
 
 		testIsRedHatRelease(&configData)
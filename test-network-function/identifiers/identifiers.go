@@ -61,6 +61,12 @@ var (
 		Url:     formTestURL(common.AffiliatedCertTestKey, "container-is-certified"),
 		Version: versionOne,
 	}
+	// TestContainerVulnerabilitiesIdentifier matches the packages of the containers under test against a local
+	// advisory feed.
+	TestContainerVulnerabilitiesIdentifier = claim.Identifier{
+		Url:     formTestURL(common.AffiliatedCertTestKey, "container-vulnerabilities"),
+		Version: versionOne,
+	}
 	// TestExtractNodeInformationIdentifier is a test which extracts Node information.
 	TestExtractNodeInformationIdentifier = claim.Identifier{
 		Url:     formTestURL(common.DiagnosticTestKey, "extract-node-information"),
@@ -76,6 +82,16 @@ var (
 		Url:     formTestURL(common.DiagnosticTestKey, "nodes-hw-info"),
 		Version: versionOne,
 	}
+	// TestPackageInventoryIdentifier lists the packages installed in the images of the containers under test.
+	TestPackageInventoryIdentifier = claim.Identifier{
+		Url:     formTestURL(common.DiagnosticTestKey, "package-inventory"),
+		Version: versionOne,
+	}
+	// TestBaseImageClassIdentifier classifies the base images of the containers under test.
+	TestBaseImageClassIdentifier = claim.Identifier{
+		Url:     formTestURL(common.DiagnosticTestKey, "base-image-class"),
+		Version: versionOne,
+	}
 	// TestHugepagesNotManuallyManipulated represents the test identifier testing hugepages have not been manipulated.
 	TestHugepagesNotManuallyManipulated = claim.Identifier{
 		Url:     formTestURL(common.PlatformAlterationTestKey, "hugepages-config"),
@@ -86,6 +102,143 @@ var (
 		Url:     formTestURL(common.NetworkingTestKey, "icmpv4-connectivity"),
 		Version: versionOne,
 	}
+	// TestICMPv6ConnectivityIdentifier tests icmpv6 connectivity.
+	TestICMPv6ConnectivityIdentifier = claim.Identifier{
+		Url:     formTestURL(common.NetworkingTestKey, "icmpv6-connectivity"),
+		Version: versionOne,
+	}
+	// TestICMPv4ConnectivityMultusIdentifier tests icmpv4 connectivity over multus networks.
+	TestICMPv4ConnectivityMultusIdentifier = claim.Identifier{
+		Url:     formTestURL(common.NetworkingTestKey, "icmpv4-connectivity-multus"),
+		Version: versionOne,
+	}
+	// TestICMPv6ConnectivityMultusIdentifier tests icmpv6 connectivity over multus networks.
+	TestICMPv6ConnectivityMultusIdentifier = claim.Identifier{
+		Url:     formTestURL(common.NetworkingTestKey, "icmpv6-connectivity-multus"),
+		Version: versionOne,
+	}
+	// TestPodPortsReachableIdentifier tests the declared container ports answer on the pod IP.
+	TestPodPortsReachableIdentifier = claim.Identifier{
+		Url:     formTestURL(common.NetworkingTestKey, "pod-ports-reachable"),
+		Version: versionOne,
+	}
+	// TestServicePortsReachableIdentifier tests the ports of the services of the CNF answer on the cluster IP.
+	TestServicePortsReachableIdentifier = claim.Identifier{
+		Url:     formTestURL(common.NetworkingTestKey, "service-ports-reachable"),
+		Version: versionOne,
+	}
+	// TestListeningPortsDeclaredIdentifier tests the listening sockets of the CNF pods match their declared ports.
+	TestListeningPortsDeclaredIdentifier = claim.Identifier{
+		Url:     formTestURL(common.NetworkingTestKey, "listening-ports-declared"),
+		Version: versionOne,
+	}
+	// TestServiceDNSResolutionIdentifier tests the services of the CNF resolve to their cluster IPs or endpoints.
+	TestServiceDNSResolutionIdentifier = claim.Identifier{
+		Url:     formTestURL(common.NetworkingTestKey, "service-dns-resolution"),
+		Version: versionOne,
+	}
+	// TestServiceDNSSRVRecordsIdentifier tests the named ports of the services of the CNF have SRV records.
+	TestServiceDNSSRVRecordsIdentifier = claim.Identifier{
+		Url:     formTestURL(common.NetworkingTestKey, "service-dns-srv-records"),
+		Version: versionOne,
+	}
+	// TestNetworkPolicyDenyAllIdentifier tests each namespace of the CNF has a default deny ingress network policy.
+	TestNetworkPolicyDenyAllIdentifier = claim.Identifier{
+		Url:     formTestURL(common.NetworkingTestKey, "network-policy-deny-all"),
+		Version: versionOne,
+	}
+	// TestNetworkPolicyCoverageIdentifier tests each pod of the CNF is selected by a network policy.
+	TestNetworkPolicyCoverageIdentifier = claim.Identifier{
+		Url:     formTestURL(common.NetworkingTestKey, "network-policy-coverage"),
+		Version: versionOne,
+	}
+	// TestNetworkPolicyEnforcementIdentifier tests the ports not allowed by the network policies are blocked.
+	TestNetworkPolicyEnforcementIdentifier = claim.Identifier{
+		Url:     formTestURL(common.NetworkingTestKey, "network-policy-enforcement"),
+		Version: versionOne,
+	}
+	// TestPathMTUIdentifier tests the paths between the CNF pods and their peers carry packets of the interface MTU.
+	TestPathMTUIdentifier = claim.Identifier{
+		Url:     formTestURL(common.NetworkingTestKey, "path-mtu"),
+		Version: versionOne,
+	}
+	// TestConnectivityMatrixIdentifier tests each container of the CNF can ping every other.
+	TestConnectivityMatrixIdentifier = claim.Identifier{
+		Url:     formTestURL(common.NetworkingTestKey, "connectivity-matrix"),
+		Version: versionOne,
+	}
+	// TestThroughputIdentifier tests the throughput between the CNF containers and the test orchestrator.
+	TestThroughputIdentifier = claim.Identifier{
+		Url:     formTestURL(common.NetworkingTestKey, "throughput"),
+		Version: versionOne,
+	}
+	// TestNetworkAttachmentDefinitionsIdentifier tests the NetworkAttachmentDefinitions requested by the CNF pods exist
+	// and hold a valid CNI configuration.
+	TestNetworkAttachmentDefinitionsIdentifier = claim.Identifier{
+		Url:     formTestURL(common.NetworkingTestKey, "network-attachment-definitions"),
+		Version: versionOne,
+	}
+	// TestSRIOVDevicesIdentifier tests the SR-IOV VFs of the CNF pods are present, bound and requested as resources.
+	TestSRIOVDevicesIdentifier = claim.Identifier{
+		Url:     formTestURL(common.NetworkingTestKey, "sriov-devices"),
+		Version: versionOne,
+	}
+	// TestNetworkStatusInterfacesIdentifier tests the interfaces reported in the network status of the CNF pods exist
+	// with the reported IPs.
+	TestNetworkStatusInterfacesIdentifier = claim.Identifier{
+		Url:     formTestURL(common.NetworkingTestKey, "network-status-interfaces"),
+		Version: versionOne,
+	}
+	// TestReadOnlyRootFilesystemIdentifier tests that each container of the CNF pods has a read-only root file system.
+	TestReadOnlyRootFilesystemIdentifier = claim.Identifier{
+		Url:     formTestURL(common.AccessControlTestKey, "read-only-root-filesystem"),
+		Version: versionOne,
+	}
+	// TestNoPrivilegeEscalationIdentifier tests that no container of the CNF pods allows privilege escalation.
+	TestNoPrivilegeEscalationIdentifier = claim.Identifier{
+		Url:     formTestURL(common.AccessControlTestKey, "no-privilege-escalation"),
+		Version: versionOne,
+	}
+	// TestRunAsNonRootIdentifier tests that each container of the CNF pods must run as a non root user.
+	TestRunAsNonRootIdentifier = claim.Identifier{
+		Url:     formTestURL(common.AccessControlTestKey, "run-as-non-root"),
+		Version: versionOne,
+	}
+	// TestSeccompProfileIdentifier tests that each container of the CNF pods runs with a seccomp profile.
+	TestSeccompProfileIdentifier = claim.Identifier{
+		Url:     formTestURL(common.AccessControlTestKey, "seccomp-profile"),
+		Version: versionOne,
+	}
+	// TestDropAllCapabilitiesIdentifier tests that each container of the CNF pods drops all capabilities.
+	TestDropAllCapabilitiesIdentifier = claim.Identifier{
+		Url:     formTestURL(common.AccessControlTestKey, "drop-all-capabilities"),
+		Version: versionOne,
+	}
+	// TestPodSCCIdentifier tests that the CNF pods run under the least permissive SecurityContextConstraints which admit them.
+	TestPodSCCIdentifier = claim.Identifier{
+		Url:     formTestURL(common.AccessControlTestKey, "pod-scc"),
+		Version: versionOne,
+	}
+	// TestServiceAccountPermissionsIdentifier tests the effective permissions of the service accounts of the CNF pods.
+	TestServiceAccountPermissionsIdentifier = claim.Identifier{
+		Url:     formTestURL(common.AccessControlTestKey, "service-account-permissions"),
+		Version: versionOne,
+	}
+	// TestServiceAccountTokenIdentifier tests that the CNF pods do not expose the tokens of their service accounts needlessly.
+	TestServiceAccountTokenIdentifier = claim.Identifier{
+		Url:     formTestURL(common.AccessControlTestKey, "service-account-token"),
+		Version: versionOne,
+	}
+	// TestSecretExposureIdentifier tests that the CNF pods do not expose credentials outside of Secrets.
+	TestSecretExposureIdentifier = claim.Identifier{
+		Url:     formTestURL(common.AccessControlTestKey, "secret-exposure"),
+		Version: versionOne,
+	}
+	// TestImageProvenanceIdentifier tests that the CNF images are referenced as the image policy requires.
+	TestImageProvenanceIdentifier = claim.Identifier{
+		Url:     formTestURL(common.AccessControlTestKey, "image-provenance"),
+		Version: versionOne,
+	}
 	// TestNamespaceBestPracticesIdentifier ensures the namespace has followed best namespace practices.
 	TestNamespaceBestPracticesIdentifier = claim.Identifier{
 		Url:     formTestURL(common.AccessControlTestKey, "namespace"),
@@ -209,12 +362,15 @@ cannot be followed.`,
 			`tests several aspects of CNF best practices, including:
 1. The Pod does not have access to Host Node Networking.
 2. The Pod does not have access to Host Node Ports.
-3. The Pod cannot access Host Node IPC space.
-4. The Pod cannot access Host Node PID space.
-5. The Pod is not granted NET_ADMIN SCC.
-6. The Pod is not granted SYS_ADMIN SCC.
-7. The Pod does not run as root.
-8. The Pod does not allow privileged escalation.
+3. The Pod does not mount Host Node paths.
+4. The Pod cannot access Host Node IPC space.
+5. The Pod cannot access Host Node PID space.
+6. The Pod is not granted NET_ADMIN SCC.
+7. The Pod is not granted SYS_ADMIN SCC.
+8. The Pod does not run as root.
+9. The Pod does not allow privileged escalation.
+Each rule is checked against the Pod spec, for every container, init container and volume it applies to, and is
+recorded as a separate result in the claim along with its severity and remediation.
 `),
 	},
 
@@ -226,6 +382,20 @@ cannot be followed.`,
 			`tests whether container images have passed the Red Hat Container Certification Program (CCP).`),
 	},
 
+	TestContainerVulnerabilitiesIdentifier: {
+		Identifier: TestContainerVulnerabilitiesIdentifier,
+		Type:       normativeResult,
+		Remediation: `Rebuild the image on an updated base image, or update the vulnerable packages to the fixed versions,
+so that no fixable vulnerability of the configured severity or higher remains.`,
+		Description: formDescription(TestContainerVulnerabilitiesIdentifier,
+			`lists the rpm packages installed in the image of each container under test, and matches their versions
+against the OVAL definitions and CSAF advisories of the configured vulnerability feed, read from disk so that no network
+access is needed.  Advisories only apply to images of the release they name, and advisories of a module stream only to
+images which enable that stream.  Each fixable CVE is reported per image with its severity and fixed version, and those
+of the configured failure severity or higher, Important by default, fail the test.  The test is skipped when no feed is
+configured.`),
+	},
+
 	TestExtractNodeInformationIdentifier: {
 		Identifier: TestExtractNodeInformationIdentifier,
 		Type:       informativeResult,
@@ -264,6 +434,341 @@ The test ensures that all CNF containers respond to ICMPv4 requests from the Par
 `),
 	},
 
+	TestICMPv6ConnectivityIdentifier: {
+		Identifier: TestICMPv6ConnectivityIdentifier,
+		Type:       normativeResult,
+		Remediation: `Ensure that the CNF is able to communicate via IPv6 on the Default OpenShift network.  In some rare
+cases, CNFs may require routing table changes in order to communicate over the Default network.  Containers without a
+global IPv6 address on the Default network, as on IPv4 single-stack clusters, are skipped.`,
+		Description: formDescription(TestICMPv6ConnectivityIdentifier,
+			`checks that each CNF Container is able to communicate via ICMPv6 on the Default OpenShift network.  This
+test case requires the Deployment of the
+[CNF Certification Test Partner](https://github.com/test-network-function/cnf-certification-test-partner/blob/main/test-partner/partner.yaml).
+The test ensures that all CNF containers respond to ICMPv6 requests from the Partner Pod, and vice-versa, using
+"ping -6".
+`),
+	},
+
+	TestICMPv4ConnectivityMultusIdentifier: {
+		Identifier: TestICMPv4ConnectivityMultusIdentifier,
+		Type:       normativeResult,
+		Remediation: `Ensure that the CNF is able to communicate via the Multus networks it is attached to.  Check the
+"test-network-function.com/multusips" or "k8s.v1.cni.cncf.io/networks-status" pod annotations list the expected
+addresses.`,
+		Description: formDescription(TestICMPv4ConnectivityMultusIdentifier,
+			`checks that each CNF Container is reachable via ICMPv4 on its Multus IPv4 addresses.  This test case
+requires the Deployment of the
+[CNF Certification Test Partner](https://github.com/test-network-function/cnf-certification-test-partner/blob/main/test-partner/partner.yaml),
+attached to the same Multus networks.  The test ensures that all CNF Multus IPv4 addresses respond to ICMPv4 requests
+from the Partner Pod.
+`),
+	},
+
+	TestICMPv6ConnectivityMultusIdentifier: {
+		Identifier: TestICMPv6ConnectivityMultusIdentifier,
+		Type:       normativeResult,
+		Remediation: `Ensure that the CNF is able to communicate via IPv6 on the Multus networks it is attached to.  Check
+the "test-network-function.com/multusips" or "k8s.v1.cni.cncf.io/networks-status" pod annotations list the expected
+addresses.`,
+		Description: formDescription(TestICMPv6ConnectivityMultusIdentifier,
+			`checks that each CNF Container is reachable via ICMPv6 on its Multus IPv6 addresses.  This test case
+requires the Deployment of the
+[CNF Certification Test Partner](https://github.com/test-network-function/cnf-certification-test-partner/blob/main/test-partner/partner.yaml),
+attached to the same Multus networks.  The test ensures that all CNF Multus IPv6 addresses respond to ICMPv6 requests
+from the Partner Pod, using "ping -6".
+`),
+	},
+
+	TestPodPortsReachableIdentifier: {
+		Identifier: TestPodPortsReachableIdentifier,
+		Type:       normativeResult,
+		Remediation: `Ensure that each port declared in the containerPorts of the pod spec is actually listening, or remove
+the declaration.  UDP ports are only checked when a probe is defined for them in the udpProbes configuration section.`,
+		Description: formDescription(TestPodPortsReachableIdentifier,
+			`checks that each TCP port declared in the containerPorts of a CNF Container accepts connections from the
+Partner Pod on the pod IP, and that each UDP port with a configured probe answers it.  Each port is recorded as a
+separate result in the claim.`),
+	},
+
+	TestServicePortsReachableIdentifier: {
+		Identifier: TestServicePortsReachableIdentifier,
+		Type:       normativeResult,
+		Remediation: `Ensure that each port of the services selecting the CNF pods is served by a listening container port.
+Check the targetPort of each service port names or numbers a port the CNF actually listens on.`,
+		Description: formDescription(TestServicePortsReachableIdentifier,
+			`checks that each TCP port of the services selecting CNF pods accepts connections from the Partner Pod on
+the service ClusterIP, and that each UDP port with a configured probe answers it.  Headless services are not checked.
+Each port is recorded as a separate result in the claim.`),
+	},
+
+	TestListeningPortsDeclaredIdentifier: {
+		Identifier: TestListeningPortsDeclaredIdentifier,
+		Type:       normativeResult,
+		Remediation: `Declare every port the CNF listens on in the containerPorts of its pod spec, and remove the
+declarations of ports it never opens.  Ports opened by known sidecars may be listed in the allowedListeningPorts
+configuration section instead.`,
+		Description: formDescription(TestListeningPortsDeclaredIdentifier,
+			`reads the TCP and UDP sockets listening in each CNF pod from /proc/net, and compares them with the
+containerPorts declared by its containers.  Sockets bound to a loopback address are ignored.  Both undeclared listening
+ports and declared ports that nothing listens on are reported, with a separate result for each pod in the claim.`),
+	},
+
+	TestServiceDNSResolutionIdentifier: {
+		Identifier: TestServiceDNSResolutionIdentifier,
+		Type:       normativeResult,
+		Remediation: `Ensure that the cluster DNS is reachable from the CNF pods, and that the services of the CNF select
+ready pods.  The containers resolving the names need the "dig" binary; containers without it can be excluded as for the
+connectivity tests.`,
+		Description: formDescription(TestServiceDNSResolutionIdentifier,
+			`checks, from the Partner Pod and from each CNF pod, that the FQDN of each service selecting CNF pods
+resolves to its ClusterIPs, or to its ready endpoints for a headless service.  A and AAAA records are checked
+separately, so that a missing address family is reported on its own.  Each lookup is recorded as a separate result in
+the claim.`),
+	},
+
+	TestServiceDNSSRVRecordsIdentifier: {
+		Identifier: TestServiceDNSSRVRecordsIdentifier,
+		Type:       normativeResult,
+		Remediation: `Ensure that the named ports of the services of the CNF are published by the cluster DNS, and that the
+CNF resolves them through the cluster DNS.`,
+		Description: formDescription(TestServiceDNSSRVRecordsIdentifier,
+			`checks, from the Partner Pod and from each CNF pod, that each named port of the services selecting CNF
+pods has a SRV record, which points to the service port unless the service is headless.`),
+	},
+
+	TestNetworkPolicyDenyAllIdentifier: {
+		Identifier: TestNetworkPolicyDenyAllIdentifier,
+		Type:       normativeResult,
+		Remediation: `Add a NetworkPolicy with an empty podSelector, the Ingress policy type and no ingress rules to each
+namespace of the CNF, so that only the traffic explicitly allowed by other policies reaches its pods.`,
+		Description: formDescription(TestNetworkPolicyDenyAllIdentifier,
+			`checks that each namespace of the CNF pods has a NetworkPolicy denying all ingress traffic by default.`),
+	},
+
+	TestNetworkPolicyCoverageIdentifier: {
+		Identifier:  TestNetworkPolicyCoverageIdentifier,
+		Type:        normativeResult,
+		Remediation: `Ensure that the podSelector of at least one NetworkPolicy matches the labels of each CNF pod.`,
+		Description: formDescription(TestNetworkPolicyCoverageIdentifier,
+			`checks that each CNF pod is selected by at least one NetworkPolicy of its namespace.  Each pod is recorded
+as a separate result in the claim.`),
+	},
+
+	TestNetworkPolicyEnforcementIdentifier: {
+		Identifier: TestNetworkPolicyEnforcementIdentifier,
+		Type:       normativeResult,
+		Remediation: `Ensure that the cluster network plugin enforces NetworkPolicies, and that the CNF pods are not
+reachable through ports their policies do not allow.`,
+		Description: formDescription(TestNetworkPolicyEnforcementIdentifier,
+			`reads the TCP sockets listening in each CNF pod restricted by a NetworkPolicy, and checks that the Partner
+Pod cannot connect to those that no ingress rule allows.  Each probed port is recorded as a separate result in the
+claim.`),
+	},
+
+	TestPathMTUIdentifier: {
+		Identifier: TestPathMTUIdentifier,
+		Type:       normativeResult,
+		Remediation: `Ensure that the MTU configured on each CNF pod interface, including those attached by Multus, is
+carried by the network all the way to the peers of the interface, for example by configuring the same MTU on the
+underlying host interfaces and NetworkAttachmentDefinitions, and by enabling jumbo frames on the switches.`,
+		Description: formDescription(TestPathMTUIdentifier,
+			`reads the MTU of each interface of the CNF pods, and pings each peer of the interface, either the Partner
+Pod and the other CNF pods on the default network, or those within the same subnet on the other networks, with packets
+the size of the MTU which must not be fragmented.  The largest size that gets through is recorded for each path as a
+separate result in the claim, and the test fails when it is below the interface MTU.`),
+	},
+
+	TestConnectivityMatrixIdentifier: {
+		Identifier: TestConnectivityMatrixIdentifier,
+		Type:       normativeResult,
+		Remediation: `Ensure that the CNF containers are able to communicate with each other over the Default network and
+the Multus networks they share.  Containers which lack the "ping" binary can be excluded from connectivity tests.`,
+		Description: formDescription(TestConnectivityMatrixIdentifier,
+			`pings from each CNF container to every container of the other CNF pods, over the Default network and the
+Multus networks within the subnets of the pinging container.  Each path is recorded as a separate result in the claim,
+along with a table of the whole matrix.  This test only runs when settings.connectivityMatrix is set.`),
+	},
+
+	TestThroughputIdentifier: {
+		Identifier: TestThroughputIdentifier,
+		Type:       normativeResult,
+		Remediation: `Ensure that the "iperf3" binary is available in the CNF containers, and that the networks they are
+attached to, including the Multus networks, provide the configured minimum throughput.`,
+		Description: formDescription(TestThroughputIdentifier,
+			`measures with iperf3 the TCP and UDP throughput between each CNF container and the Partner Pod, over the
+Default network and each Multus network they share.  The Partner Pod runs the server and the CNF container the client,
+unless the Partner Pod is unable to start a server.  Each measurement is recorded as a separate result in the claim, and
+fails when below the minimum configured in throughputTest.  This test is intrusive, as it loads the networks.`),
+	},
+
+	TestNetworkAttachmentDefinitionsIdentifier: {
+		Identifier: TestNetworkAttachmentDefinitionsIdentifier,
+		Type:       normativeResult,
+		Remediation: `Ensure that each network requested in the k8s.v1.cni.cncf.io/networks annotation of the CNF pods names
+an existing NetworkAttachmentDefinition, in the namespace of the pod unless another is given, and that its spec.config
+is a CNI configuration with a cniVersion and a plugin type.`,
+		Description: formDescription(TestNetworkAttachmentDefinitionsIdentifier,
+			`resolves each network requested by the CNF pods to its NetworkAttachmentDefinition, and checks that the
+definition exists and that its CNI configuration, either a single network configuration or a configuration list, is
+valid.  Each requested network is recorded as a separate result in the claim.`),
+	},
+
+	TestSRIOVDevicesIdentifier: {
+		Identifier: TestSRIOVDevicesIdentifier,
+		Type:       normativeResult,
+		Remediation: `Ensure that the containers of the CNF pods attached to SR-IOV networks request, with equal requests and
+limits, at least one device of the resourceName of the NetworkAttachmentDefinition for each attachment, and that the VFs
+allocated to the pod are bound to a driver.`,
+		Description: formDescription(TestSRIOVDevicesIdentifier,
+			`checks each CNF pod attached to SR-IOV networks: the resources of its containers must request as many
+devices of the resource of each SR-IOV NetworkAttachmentDefinition as the pod has attachments to it, with requests equal
+to limits, and each PCI address reported in the network status must be a VF present in the container, bound to a driver,
+and backing the reported interface unless bound to a userspace driver.  Each resource and VF is recorded as a separate
+result in the claim.`),
+	},
+
+	TestNetworkStatusInterfacesIdentifier: {
+		Identifier: TestNetworkStatusInterfacesIdentifier,
+		Type:       normativeResult,
+		Remediation: `Ensure that the networks requested by the CNF pods are attached by Multus with the requested interface
+names, and that the interfaces and IP addresses reported in the network status annotation are those of the pod.`,
+		Description: formDescription(TestNetworkStatusInterfacesIdentifier,
+			`checks that each network requested by a CNF pod is reported in its network status, with the requested
+interface name if any, and that each interface of the network status exists in the pod with the reported IP addresses.
+Interfaces of devices bound to a userspace driver, which have no IP addresses, are not checked.  Each interface is
+recorded as a separate result in the claim.`),
+	},
+
+	TestReadOnlyRootFilesystemIdentifier: {
+		Identifier: TestReadOnlyRootFilesystemIdentifier,
+		Type:       normativeResult,
+		Remediation: `Set securityContext.readOnlyRootFilesystem to true in each container and init container, and mount a volume, such as an
+emptyDir, at each path the container needs to write to.`,
+		Description: formDescription(TestReadOnlyRootFilesystemIdentifier,
+			`tests that each container and init container of the CNF pods sets securityContext.readOnlyRootFilesystem to true,
+so that the container cannot alter its own image.  Each container is recorded as a separate result in the claim.`),
+	},
+
+	TestNoPrivilegeEscalationIdentifier: {
+		Identifier:  TestNoPrivilegeEscalationIdentifier,
+		Type:        normativeResult,
+		Remediation: `Set securityContext.allowPrivilegeEscalation to false in each container and init container.`,
+		Description: formDescription(TestNoPrivilegeEscalationIdentifier,
+			`tests that each container and init container of the CNF pods explicitly sets
+securityContext.allowPrivilegeEscalation to false, so that no process can gain more privileges than its parent, as
+through setuid binaries.  Each container is recorded as a separate result in the claim.`),
+	},
+
+	TestRunAsNonRootIdentifier: {
+		Identifier: TestRunAsNonRootIdentifier,
+		Type:       normativeResult,
+		Remediation: `Set securityContext.runAsNonRoot to true in the Pod, or in each container and init container, and build the images to
+run as a non root user.`,
+		Description: formDescription(TestRunAsNonRootIdentifier,
+			`tests that each container and init container of the CNF pods sets securityContext.runAsNonRoot to true, either
+itself or through the Pod security context, so that the container is refused to start as root.  Each container is
+recorded as a separate result in the claim.`),
+	},
+
+	TestSeccompProfileIdentifier: {
+		Identifier: TestSeccompProfileIdentifier,
+		Type:       normativeResult,
+		Remediation: `Set securityContext.seccompProfile.type to RuntimeDefault, or to Localhost along with a localhostProfile, in the Pod or in
+each container and init container.`,
+		Description: formDescription(TestSeccompProfileIdentifier,
+			`tests that each container and init container of the CNF pods runs with a RuntimeDefault or Localhost seccomp
+profile, set either in the container or in the Pod security context, so that the system calls it can make are
+filtered.  Each container is recorded as a separate result in the claim.`),
+	},
+
+	TestDropAllCapabilitiesIdentifier: {
+		Identifier: TestDropAllCapabilitiesIdentifier,
+		Type:       normativeResult,
+		Remediation: `Add ALL to securityContext.capabilities.drop in each container and init container, and only add back the capabilities
+the container needs.  Adding capabilities other than NET_BIND_SERVICE and those of allowedCapabilities in the
+configuration should be justified on a case-by-case basis.`,
+		Description: formDescription(TestDropAllCapabilitiesIdentifier,
+			`tests that each container and init container of the CNF pods drops ALL capabilities, and only adds back
+NET_BIND_SERVICE and the capabilities within allowedCapabilities of the configuration.  Each container is
+recorded as a separate result in the claim.`),
+	},
+
+	TestPodSCCIdentifier: {
+		Identifier: TestPodSCCIdentifier,
+		Type:       normativeResult,
+		Remediation: `Grant the service account of the Pod the least permissive SCC which admits its spec, and remove it from the
+users of the more permissive SCCs, or remove the settings of the Pod spec which require a more permissive SCC.`,
+		Description: formDescription(TestPodSCCIdentifier,
+			`tests that each CNF Pod runs under the least permissive SecurityContextConstraints (SCC) which admit its
+spec.  The SCC the Pod was admitted with, as recorded by its openshift.io/scc annotation, is classified against the
+sccLadder of the configuration (restricted-v2, restricted, nonroot-v2, nonroot, hostnetwork-v2, hostnetwork, anyuid and
+privileged by default), and the test fails when a less permissive SCC of the ladder admits the Pod spec.  The claim
+records why each less permissive SCC rejects the Pod.`),
+	},
+
+	TestServiceAccountPermissionsIdentifier: {
+		Identifier: TestServiceAccountPermissionsIdentifier,
+		Type:       normativeResult,
+		Remediation: `Bind the service accounts of the CNF pods only to Roles and ClusterRoles which list the verbs, resources and
+apiGroups they need.  Avoid wildcards, access to secrets other than those named in resourceNames, the escalate, bind and
+impersonate verbs, and access to nodes or to pods/exec.`,
+		Description: formDescription(TestServiceAccountPermissionsIdentifier,
+			`resolves every Role and ClusterRole bound to the service account of each CNF pod, through RoleBindings
+and ClusterRoleBindings to the service account, to its user name or to the groups it belongs to, and computes the rules
+it is effectively granted.  The test fails when a rule uses wildcards in its verbs, resources or apiGroups, grants
+access to secrets, grants the escalate, bind or impersonate verbs, or grants access to nodes or to pods/exec.  Rules
+which the default bindings of the cluster, annotated rbac.authorization.kubernetes.io/autoupdate, grant to the groups of
+every service account are reported without failing the test.  The permission report of each service account is added
+to the claim, and each flagged rule is recorded as a separate result.`),
+	},
+
+	TestServiceAccountTokenIdentifier: {
+		Identifier: TestServiceAccountTokenIdentifier,
+		Type:       normativeResult,
+		Remediation: `Set automountServiceAccountToken to false in the Pod spec, or in its ServiceAccount, when the Pod does not call
+the API server.  Delete the legacy token Secrets of the ServiceAccount, and rely on the short-lived projected tokens
+mounted by the kubelet, or requested with the TokenRequest API, instead.`,
+		Description: formDescription(TestServiceAccountTokenIdentifier,
+			`tests that no CNF Pod mounts the token of its ServiceAccount when automountServiceAccountToken is not
+explicitly false in the Pod or in the ServiceAccount, while no RoleBinding or ClusterRoleBinding binds the ServiceAccount
+itself, as the token is then of no use to the Pod.  It also tests that no legacy long-lived token Secret, of type
+kubernetes.io/service-account-token, was tied to the ServiceAccounts of the CNF pods by users.  The token Secrets which
+the cluster generates for every ServiceAccount, before Kubernetes 1.24 and OpenShift 4.16, are left out.  A Pod whose
+ServiceAccount does not exist fails.`),
+	},
+
+	TestSecretExposureIdentifier: {
+		Identifier: TestSecretExposureIdentifier,
+		Type:       normativeResult,
+		Remediation: `Store credentials in Secrets, and reference them with valueFrom.secretKeyRef, envFrom.secretRef or a
+secret volume, rather than as literal environment variables, command line arguments or ConfigMap data.  Rotate any
+credential which was exposed.`,
+		Description: formDescription(TestSecretExposureIdentifier,
+			`tests that no likely credential is set in the literal environment variables, commands or arguments of the
+containers and init containers of the CNF pods, nor in the data of the ConfigMaps they reference through their
+environment or their volumes.  Values are flagged when they match the pattern of a well-known key or token, such as a
+private key, an AWS access key ID or a URL with a password, when they are assigned to a name suggesting a credential,
+such as PASSWORD or TOKEN, or when they look randomly generated.  PEM certificates, and the kube-root-ca.crt and
+openshift-service-ca.crt ConfigMaps which the cluster publishes in every namespace, are left out.  Flagged values are
+redacted in the claim.`),
+	},
+
+	TestImageProvenanceIdentifier: {
+		Identifier: TestImageProvenanceIdentifier,
+		Type:       normativeResult,
+		Remediation: `Reference each image by a release tag, or by digest when the image policy requires it, and pull it
+from a registry of the allowlist.  Do not use the latest tag, nor omit the tag, and leave imagePullPolicy unset or set
+it to Always for images whose tag is expected to change.  Push each release under a new tag, rather than moving an
+existing one.`,
+		Description: formDescription(TestImageProvenanceIdentifier,
+			`tests how the images of the containers and init containers of the CNF pods are referenced.  Images using the
+latest tag or no tag fail, as do images not pinned by digest when imagePolicy.requireDigest is set, and images pulled
+from a registry outside imagePolicy.allowedRegistries when it is set.  An imagePullPolicy other than Always on an image
+using the latest tag also fails, as nodes then keep running whichever image they cached.  The image each container runs
+is resolved from the pod status: it fails when the same tag resolves to different repository digests in different
+containers.`),
+	},
+
 	TestNamespaceBestPracticesIdentifier: {
 		Identifier: TestNamespaceBestPracticesIdentifier,
 		Type:       normativeResult,
@@ -441,6 +946,27 @@ the changes for you.`,
 			`list nodes HW info`),
 	},
 
+	TestPackageInventoryIdentifier: {
+		Identifier:  TestPackageInventoryIdentifier,
+		Type:        informativeResult,
+		Remediation: "",
+		Description: formDescription(TestPackageInventoryIdentifier,
+			`lists the packages installed in the image of each container under test, with rpm, or with dpkg or apk
+when the image has no rpm database, and adds a CycloneDX software bill of materials of each image to the claim, for
+vulnerability triage and license review.`),
+	},
+
+	TestBaseImageClassIdentifier: {
+		Identifier:  TestBaseImageClassIdentifier,
+		Type:        informativeResult,
+		Remediation: "",
+		Description: formDescription(TestBaseImageClassIdentifier,
+			`classifies the base image of each container under test as a standard, minimal or micro Red Hat Universal
+Base Image, Red Hat Enterprise Linux, another distribution or distroless, from its /etc/os-release, the build
+information of Red Hat images under /root/buildinfo, its package managers and the labels of its image config.  The
+classes are added to the claim, so that a grading policy can require particular ones.`),
+	},
+
 	TestShudtownIdentifier: {
 		Identifier: TestShudtownIdentifier,
 		Type:       normativeResult,
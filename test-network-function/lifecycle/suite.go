@@ -86,9 +86,8 @@ var drainTimeout = time.Duration(drainTimeoutMinutes) * time.Minute
 //
 var _ = ginkgo.Describe(common.LifecycleTestKey, func() {
 	configData := common.ConfigurationData{}
-	configData.SetNeedsRefresh()
 	ginkgo.BeforeEach(func() {
-		common.ReloadConfiguration(&configData)
+		common.EnsureConfiguration(common.GetConfigProvider(), &configData)
 	})
 	if testcases.IsInFocus(ginkgoconfig.GinkgoConfig.FocusStrings, common.LifecycleTestKey) {
 
@@ -118,7 +117,7 @@ func waitForAllDeploymentsReady(namespace string, timeout, pollingPeriod time.Du
 }
 
 // restoreDeployments is the last attempt to restore the original test deployments' replicaCount
-func restoreDeployments(configData *common.ConfigurationData, nsDeployments *map[string]dp.DeploymentMap) {
+func restoreDeployments(nsDeployments *map[string]dp.DeploymentMap) {
 	for namespace, originalDeployments := range *nsDeployments {
 		// For each deployment in the namespace, get the current replicas and compare.
 		deployments, notReadyDeployments := getDeployments(namespace)
@@ -137,8 +136,6 @@ func restoreDeployments(configData *common.ConfigurationData, nsDeployments *map
 
 			// Try to scale to the original deployment's replicaCount.
 			runScalingTest(namespace, originalDeploymentName, originalDeployment.Replicas)
-
-			configData.SetNeedsRefresh()
 		}
 	}
 }
@@ -172,8 +169,11 @@ func testScaling(configData *common.ConfigurationData) {
 	ginkgo.It("Testing deployment scaling", func() {
 		defer results.RecordResult(identifiers.TestScalingIdentifier)
 
+		// Ensure next tests receive a refreshed config, once the deployments are restored.
+		defer common.ReloadConfiguration(common.GetConfigProvider(), configData)
+
 		namespaceDeploymentsBackup := make(map[string]dp.DeploymentMap)
-		defer restoreDeployments(configData, &namespaceDeploymentsBackup)
+		defer restoreDeployments(&namespaceDeploymentsBackup)
 
 		// Map to register the deployments that have been already tested
 		deploymentNames := make(map[string]bool)
@@ -203,9 +203,6 @@ func testScaling(configData *common.ConfigurationData) {
 			// Scaleout, restoring the original replicaCount number
 			runScalingTest(namespace, deploymentName, replicaCount)
 
-			// Ensure next tests/test suites receive a refreshed config.
-			configData.SetNeedsRefresh()
-
 			// Set this deployment as tested
 			deploymentNames[deploymentName] = true
 		}
@@ -303,7 +300,8 @@ func testPodsRecreation(configData *common.ConfigurationData) {
 	var notReadyDeployments []string
 	var nodesSorted []node // A slice version of nodes sorted by number of deployments descending
 	ginkgo.It("Testing node draining effect of deployment", func() {
-		configData.SetNeedsRefresh()
+		// The drained pods are recreated, so the next tests need a refreshed config.
+		defer common.ReloadConfiguration(common.GetConfigProvider(), configData)
 		for _, cut := range configData.ContainersUnderTest {
 			namespace := cut.Oc.GetPodNamespace()
 			ginkgo.By(fmt.Sprintf("test deployment in namespace %s", namespace))
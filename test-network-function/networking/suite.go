@@ -18,7 +18,16 @@ package networking
 
 import (
 	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
 
+	"github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
 	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
 
 	"github.com/test-network-function/test-network-function/test-network-function/common"
@@ -30,16 +39,77 @@ import (
 	"github.com/onsi/gomega"
 	log "github.com/sirupsen/logrus"
 	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/dnslookup"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/iperf3"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/listeningsockets"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/netinterfaces"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/nodeport"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/pcidevices"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/ping"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/portprobe"
 	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
 	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
 )
 
 const (
 	defaultNumPings = 5
+	// defaultClusterDomain is the DNS domain of the cluster services, unless configured otherwise.
+	defaultClusterDomain = "cluster.local"
+
+	// mtuProbeCount is the number of requests sent for each packet size probed, one of which may be lost.
+	mtuProbeCount = 2
+	// ipv4HeaderOverhead and ipv6HeaderOverhead are the sizes of the IP and ICMP headers of a ping request, which
+	// its payload leaves room for so that the packet is the size probed.
+	ipv4HeaderOverhead = 28
+	ipv6HeaderOverhead = 48
+	// ipv4MinimumMTU and ipv6MinimumMTU are the smallest MTUs the address families require every link to carry.
+	ipv4MinimumMTU = 576
+	ipv6MinimumMTU = 1280
+
+	// defaultMatrixConcurrency is the number of containers pinging at the same time in the connectivity matrix,
+	// unless configured otherwise.
+	defaultMatrixConcurrency = 4
+	// Cells of the connectivity matrix table.
+	matrixCellPassed        = "ok"
+	matrixCellFailed        = "FAIL"
+	matrixCellNotApplicable = "-"
+
+	// defaultThroughputPort and defaultThroughputDuration apply unless the throughput test is configured otherwise.
+	defaultThroughputPort     = 5201
+	defaultThroughputDuration = 10 * time.Second
+	// throughputServerGrace is how long a throughput server outlives the measurement it is started for, should the
+	// client never connect.
+	throughputServerGrace = 30 * time.Second
+	bitsPerMegabit        = 1e6
 )
 
+// addressFamily selects the addresses and result identifiers of an IP address family, so that connectivity is tested
+// separately over IPv4 and IPv6 on dual-stack clusters.
+type addressFamily struct {
+	name              string
+	defaultAddress    func(*common.Container) string
+	multusAddresses   func(*common.Container) []string
+	defaultIdentifier claim.Identifier
+	multusIdentifier  claim.Identifier
+}
+
+var addressFamilies = []*addressFamily{
+	{
+		name:              "IPv4",
+		defaultAddress:    func(c *common.Container) string { return c.DefaultNetworkIPAddress },
+		multusAddresses:   func(c *common.Container) []string { return c.ContainerConfiguration.MultusIPAddresses },
+		defaultIdentifier: identifiers.TestICMPv4ConnectivityIdentifier,
+		multusIdentifier:  identifiers.TestICMPv4ConnectivityMultusIdentifier,
+	},
+	{
+		name:              "IPv6",
+		defaultAddress:    func(c *common.Container) string { return c.DefaultNetworkIPv6Address },
+		multusAddresses:   func(c *common.Container) []string { return c.ContainerConfiguration.MultusIPv6Addresses },
+		defaultIdentifier: identifiers.TestICMPv6ConnectivityIdentifier,
+		multusIdentifier:  identifiers.TestICMPv6ConnectivityMultusIdentifier,
+	},
+}
+
 //
 // All actual test code belongs below here.  Utilities belong above.
 //
@@ -47,19 +117,53 @@ const (
 // Runs the "generic" CNF test cases.
 var _ = ginkgo.Describe(common.NetworkingTestKey, func() {
 	configData := common.ConfigurationData{}
-	configData.SetNeedsRefresh()
 	ginkgo.BeforeEach(func() {
-		common.ReloadConfiguration(&configData)
+		common.EnsureConfiguration(common.GetConfigProvider(), &configData)
 	})
 	if testcases.IsInFocus(ginkgoconfig.GinkgoConfig.FocusStrings, common.NetworkingTestKey) {
 		ginkgo.Context("Both Pods are on the Default network", func() {
 			// for each container under test, ensure bidirectional ICMP traffic between the container and the orchestrator.
-			testDefaultNetworkConnectivity(&configData, defaultNumPings)
+			for _, family := range addressFamilies {
+				testDefaultNetworkConnectivity(&configData, defaultNumPings, family)
+			}
 		})
 
 		ginkgo.Context("Both Pods are connected via a Multus Overlay Network", func() {
 			// Unidirectional test;  for each container under test, attempt to ping the target Multus IP addresses.
-			testMultusNetworkConnectivity(&configData, defaultNumPings)
+			for _, family := range addressFamilies {
+				testMultusNetworkConnectivity(&configData, defaultNumPings, family)
+			}
+		})
+		ginkgo.Context("Every container under test reaches every other", func() {
+			testConnectivityMatrix(&configData)
+		})
+		ginkgo.Context("Paths between Pods carry packets the size of the interface MTU", func() {
+			testPathMTU(&configData)
+		})
+		if !common.NonIntrusive() {
+			ginkgo.Context("Paths between Pods sustain the minimum throughput", func() {
+				testThroughput(&configData)
+			})
+		}
+		ginkgo.Context("Declared ports are reachable from the test orchestrator", func() {
+			testPodPortReachability(&configData)
+			testServicePortReachability(&configData)
+		})
+		ginkgo.Context("Listening sockets match the declared container ports", func() {
+			testListeningPorts(&configData)
+		})
+		ginkgo.Context("Services resolve through the cluster DNS", func() {
+			testServiceDNSResolution(&configData)
+		})
+		ginkgo.Context("Network policies restrict the ingress traffic to the pods under test", func() {
+			testNetworkPolicyDenyAll()
+			testNetworkPolicyCoverage()
+			testNetworkPolicyEnforcement(&configData)
+		})
+		ginkgo.Context("Secondary networks are attached as their definitions and status describe", func() {
+			testNetworkAttachmentDefinitions()
+			testSRIOVDevices(&configData)
+			testNetworkStatusInterfaces(&configData)
 		})
 		ginkgo.Context("Should not have type of nodePort", func() {
 			testNodePort(&configData)
@@ -67,56 +171,1424 @@ var _ = ginkgo.Describe(common.NetworkingTestKey, func() {
 	}
 })
 
-func testDefaultNetworkConnectivity(configData *common.ConfigurationData, count int) {
-	ginkgo.When("Testing network connectivity", func() {
+func testDefaultNetworkConnectivity(configData *common.ConfigurationData, count int, family *addressFamily) {
+	ginkgo.When(fmt.Sprintf("Testing %s network connectivity", family.name), func() {
 		ginkgo.It("should reply to ping", func() {
+			testOrchestrator := configData.TestOrchestrator
+			if family.defaultAddress(testOrchestrator) == "" {
+				ginkgo.Skip(fmt.Sprintf("the test orchestrator has no %s address on the default network", family.name))
+			}
 			for _, cut := range configData.ContainersUnderTest {
 				if _, ok := common.ContainersToExcludeFromConnectivityTests[cut.ContainerIdentifier]; ok {
 					continue
 				}
+				if family.defaultAddress(cut) == "" {
+					log.Warnf("%s(%s) has no %s address on the default network, skipping it", cut.Oc.GetPodName(),
+						cut.Oc.GetPodContainerName(), family.name)
+					continue
+				}
 				context := cut.Oc
-				testOrchestrator := configData.TestOrchestrator
 				ginkgo.By(fmt.Sprintf("a Ping is issued from %s(%s) to %s(%s) %s", testOrchestrator.Oc.GetPodName(),
 					testOrchestrator.Oc.GetPodContainerName(), cut.Oc.GetPodName(), cut.Oc.GetPodContainerName(),
-					cut.DefaultNetworkIPAddress))
-				defer results.RecordResult(identifiers.TestICMPv4ConnectivityIdentifier)
-				testPing(testOrchestrator.Oc, cut.DefaultNetworkIPAddress, count)
+					family.defaultAddress(cut)))
+				defer results.RecordResult(family.defaultIdentifier)
+				testPing(testOrchestrator.Oc, family.defaultAddress(cut), count, family.defaultIdentifier)
 				ginkgo.By(fmt.Sprintf("a Ping is issued from %s(%s) to %s(%s) %s", cut.Oc.GetPodName(),
 					cut.Oc.GetPodContainerName(), testOrchestrator.Oc.GetPodName(), testOrchestrator.Oc.GetPodContainerName(),
-					testOrchestrator.DefaultNetworkIPAddress))
-				testPing(context, testOrchestrator.DefaultNetworkIPAddress, count)
+					family.defaultAddress(testOrchestrator)))
+				testPing(context, family.defaultAddress(testOrchestrator), count, family.defaultIdentifier)
 			}
 		})
 	})
 }
 
-func testMultusNetworkConnectivity(configData *common.ConfigurationData, count int) {
-	ginkgo.When("Testing network connectivity", func() {
+func testMultusNetworkConnectivity(configData *common.ConfigurationData, count int, family *addressFamily) {
+	ginkgo.When(fmt.Sprintf("Testing %s network connectivity", family.name), func() {
 		ginkgo.It("should reply to ping", func() {
 			for _, cut := range configData.ContainersUnderTest {
-				for _, multusIPAddress := range cut.ContainerConfiguration.MultusIPAddresses {
+				for _, multusIPAddress := range family.multusAddresses(cut) {
 					testOrchestrator := configData.TestOrchestrator
 					ginkgo.By(fmt.Sprintf("a Ping is issued from %s(%s) to %s(%s) %s", testOrchestrator.Oc.GetPodName(),
 						testOrchestrator.Oc.GetPodContainerName(), cut.Oc.GetPodName(), cut.Oc.GetPodContainerName(),
-						cut.DefaultNetworkIPAddress))
-					defer results.RecordResult(identifiers.TestICMPv4ConnectivityIdentifier)
-					testPing(testOrchestrator.Oc, multusIPAddress, count)
+						multusIPAddress))
+					defer results.RecordResult(family.multusIdentifier)
+					testPing(testOrchestrator.Oc, multusIPAddress, count, family.multusIdentifier)
 				}
 			}
 		})
 	})
 }
 
-// Test that a container can ping a target IP address.
-func testPing(initiatingPodOc *interactive.Oc, targetPodIPAddress string, count int) {
+// Test that a container can ping a target IP address.  The round trip time and packet loss of the path are recorded
+// under `identifier`, and checked against the configured latency thresholds.  Unless a packet loss threshold is
+// configured, every request must be answered.
+func testPing(initiatingPodOc *interactive.Oc, targetPodIPAddress string, count int, identifier claim.Identifier) {
 	log.Infof("Sending ICMP traffic(%s to %s)", initiatingPodOc.GetPodName(), targetPodIPAddress)
+	thresholds := common.GetConfigProvider().GetConfig().LatencyThresholds
 	pingTester := ping.NewPing(common.DefaultTimeout, targetPodIPAddress, count)
 	test, err := tnf.NewTest(initiatingPodOc.GetExpecter(), pingTester, []reel.Handler{pingTester}, initiatingPodOc.GetErrorChannel())
 	gomega.Expect(err).To(gomega.BeNil())
-	common.RunAndValidateTest(test)
+	if thresholds.MaxPacketLossPercent == nil {
+		common.RunAndValidateTest(test)
+	} else {
+		// The loss threshold decides which losses are acceptable, rather than the ping handler.
+		result, runErr := test.Run()
+		gomega.Expect(runErr).To(gomega.BeNil())
+		gomega.Expect(result).ToNot(gomega.Equal(tnf.ERROR))
+	}
 	transmitted, received, errors := pingTester.GetStats()
-	gomega.Expect(received).To(gomega.Equal(transmitted))
-	gomega.Expect(errors).To(gomega.BeZero())
+	rtt, _ := pingTester.GetRTTStatistics()
+	loss := pingTester.GetPacketLossPercentage()
+	violations := thresholds.Violations(rtt.Avg, rtt.Mdev, loss)
+	path := fmt.Sprintf("%s(%s) to %s: rtt min/avg/max/mdev = %.3f/%.3f/%.3f/%.3f ms, %.1f%% packet loss",
+		initiatingPodOc.GetPodName(), initiatingPodOc.GetPodContainerName(), targetPodIPAddress, rtt.Min, rtt.Avg, rtt.Max,
+		rtt.Mdev, loss)
+	if thresholds.MaxPacketLossPercent == nil && received != transmitted {
+		violations = append(violations, fmt.Sprintf("%d of %d requests were not answered", transmitted-received, transmitted))
+	}
+	if errors > 0 {
+		violations = append(violations, fmt.Sprintf("%d errors were reported", errors))
+	}
+	results.RecordDetailedResult(identifier, path, len(violations) == 0, strings.Join(violations, "; "))
+	gomega.Expect(violations).To(gomega.BeEmpty(), "ping from %s failed", path)
+}
+
+// matrixContainer is a container under test in the connectivity matrix.
+type matrixContainer struct {
+	label string
+	pod   string
+	cut   *common.Container
+	// subnets are those of the interfaces of the container, which tell the Multus addresses it reaches directly.  They
+	// are only read from the containers which ping.
+	subnets []*net.IPNet
+}
+
+// matrixTarget is an address of a container pinged by the connectivity matrix.
+type matrixTarget struct {
+	network string
+	address string
+}
+
+// matrixPing is the outcome of pinging a single address of a container from another.
+type matrixPing struct {
+	target matrixTarget
+	passed bool
+	reason string
+}
+
+// getMatrixTargets returns the addresses `source` pings `destination` at: those of the default network in the
+// address families `source` has, and the Multus addresses within the subnets of `source`.
+func getMatrixTargets(source, destination *matrixContainer) []matrixTarget {
+	var targets []matrixTarget
+	// The default network addresses of excluded containers are not read, so are not valid addresses.
+	for _, family := range addressFamilies {
+		if net.ParseIP(family.defaultAddress(source.cut)) != nil && net.ParseIP(family.defaultAddress(destination.cut)) != nil {
+			targets = append(targets, matrixTarget{network: "default", address: family.defaultAddress(destination.cut)})
+		}
+	}
+	multusAddresses := append(append([]string{}, destination.cut.ContainerConfiguration.MultusIPAddresses...),
+		destination.cut.ContainerConfiguration.MultusIPv6Addresses...)
+	for _, address := range multusAddresses {
+		ip := net.ParseIP(address)
+		for _, subnet := range source.subnets {
+			if ip != nil && subnet.Contains(ip) {
+				targets = append(targets, matrixTarget{network: "multus", address: address})
+				break
+			}
+		}
+	}
+	return targets
+}
+
+// pingMatrixTargets pings each of `targets` from `source`.  It runs outside of the ginkgo goroutine, so it reports
+// errors through the outcomes rather than through assertions.
+func pingMatrixTargets(source *matrixContainer, targets []matrixTarget) []matrixPing {
+	outcomes := make([]matrixPing, 0, len(targets))
+	for _, target := range targets {
+		outcome := matrixPing{target: target}
+		tester := ping.NewPing(common.DefaultTimeout, target.address, defaultNumPings)
+		test, err := tnf.NewTest(source.cut.Oc.GetExpecter(), tester, []reel.Handler{tester}, source.cut.Oc.GetErrorChannel())
+		if err == nil {
+			var result int
+			result, err = test.Run()
+			transmitted, received, errors := tester.GetStats()
+			outcome.passed = err == nil && result == tnf.SUCCESS && received == transmitted && errors == 0
+			if err == nil && !outcome.passed {
+				outcome.reason = fmt.Sprintf("%d of %d requests answered, %d errors", received, transmitted, errors)
+			}
+		}
+		if err != nil {
+			outcome.reason = fmt.Sprintf("the ping did not complete: %s", err)
+		}
+		outcomes = append(outcomes, outcome)
+	}
+	return outcomes
+}
+
+// getMatrixContainers returns the containers under test, ordered by namespace, pod and name, along with the subnets
+// of those which are able to ping.
+func getMatrixContainers(configData *common.ConfigurationData) []*matrixContainer {
+	var containers []*matrixContainer
+	for _, pod := range groupContainersByPod(configData.ContainersUnderTest) {
+		for _, cut := range pod.containers {
+			container := &matrixContainer{
+				label: fmt.Sprintf("%s/%s/%s", pod.namespace, pod.name, cut.ContainerIdentifier.ContainerName),
+				pod:   pod.namespace + "/" + pod.name,
+				cut:   cut,
+			}
+			if _, ok := common.ContainersToExcludeFromConnectivityTests[cut.ContainerIdentifier]; !ok {
+				tester := netinterfaces.NewNetInterfaces(common.DefaultTimeout)
+				test, err := tnf.NewTest(cut.Oc.GetExpecter(), tester, []reel.Handler{tester}, cut.Oc.GetErrorChannel())
+				gomega.Expect(err).To(gomega.BeNil())
+				common.RunAndValidateTest(test)
+				for _, iface := range tester.GetInterfaces() {
+					container.subnets = append(container.subnets, iface.Addresses...)
+				}
+			}
+			containers = append(containers, container)
+		}
+	}
+	return containers
+}
+
+func testConnectivityMatrix(configData *common.ConfigurationData) {
+	ginkgo.It("should reply to ping from every other container under test", func() {
+		settings := common.GetConfigProvider().GetConfig().Settings
+		if !settings.ConnectivityMatrix {
+			ginkgo.Skip("the connectivity matrix is not enabled")
+		}
+		concurrency := settings.ConnectivityMatrixConcurrency
+		if concurrency <= 0 {
+			concurrency = defaultMatrixConcurrency
+		}
+		containers := getMatrixContainers(configData)
+		defer results.RecordResult(identifiers.TestConnectivityMatrixIdentifier)
+
+		// Each container runs its pings in turn, as its session runs a single command at a time, while up to
+		// `concurrency` containers ping at the same time.
+		outcomes := make([][][]matrixPing, len(containers))
+		semaphore := make(chan struct{}, concurrency)
+		var wg sync.WaitGroup
+		for i, source := range containers {
+			outcomes[i] = make([][]matrixPing, len(containers))
+			if _, ok := common.ContainersToExcludeFromConnectivityTests[source.cut.ContainerIdentifier]; ok {
+				continue
+			}
+			wg.Add(1)
+			go func(i int, source *matrixContainer) {
+				defer wg.Done()
+				semaphore <- struct{}{}
+				defer func() { <-semaphore }()
+				for j, destination := range containers {
+					// Containers of the same pod share its network namespace, so there is no path between them.
+					if destination.pod != source.pod {
+						outcomes[i][j] = pingMatrixTargets(source, getMatrixTargets(source, destination))
+					}
+				}
+			}(i, source)
+		}
+		ginkgo.By(fmt.Sprintf("pinging between %d containers, %d at a time", len(containers), concurrency))
+		wg.Wait()
+
+		var failures []string
+		for i, source := range containers {
+			for j, destination := range containers {
+				for _, outcome := range outcomes[i][j] {
+					item := fmt.Sprintf("%s to %s %s (%s network)", source.label, destination.label, outcome.target.address,
+						outcome.target.network)
+					results.RecordDetailedResult(identifiers.TestConnectivityMatrixIdentifier, item, outcome.passed, outcome.reason)
+					if !outcome.passed {
+						failures = append(failures, item)
+					}
+				}
+			}
+		}
+		table := formatConnectivityMatrix(containers, outcomes)
+		log.Infof("connectivity matrix:\n%s", table)
+		writeInfo := tnf.CreateTestExtraInfoWriter()
+		for _, line := range strings.Split(strings.TrimRight(table, "\n"), "\n") {
+			writeInfo(line)
+		}
+		gomega.Expect(failures).To(gomega.BeEmpty(), "paths without connectivity: %v", failures)
+	})
+}
+
+// formatConnectivityMatrix renders the outcomes as a table with a row per pinging container and a column per pinged
+// container, numbered after the legend which precedes the table.  A cell fails if any of its addresses failed, and is
+// not applicable when no address was pinged.
+func formatConnectivityMatrix(containers []*matrixContainer, outcomes [][][]matrixPing) string {
+	var builder strings.Builder
+	for i, container := range containers {
+		fmt.Fprintf(&builder, "[%d] %s\n", i+1, container.label)
+	}
+	writer := tabwriter.NewWriter(&builder, 0, 0, 1, ' ', tabwriter.AlignRight)
+	fmt.Fprint(writer, "from\\to\t")
+	for j := range containers {
+		fmt.Fprintf(writer, "%d\t", j+1)
+	}
+	fmt.Fprintln(writer)
+	for i := range containers {
+		fmt.Fprintf(writer, "%d\t", i+1)
+		for j := range containers {
+			cell := matrixCellNotApplicable
+			for _, outcome := range outcomes[i][j] {
+				cell = matrixCellPassed
+				if !outcome.passed {
+					cell = matrixCellFailed
+					break
+				}
+			}
+			fmt.Fprintf(writer, "%s\t", cell)
+		}
+		fmt.Fprintln(writer)
+	}
+	// The tabwriter only fails when the builder does, which never happens.
+	_ = writer.Flush()
+	return builder.String()
+}
+
+// mtuEndpoint is a pod under test, or the test orchestrator, with the interfaces the path MTU is probed between.
+type mtuEndpoint struct {
+	name          string
+	oc            *interactive.Oc
+	underTest     bool
+	defaultDevice string
+	interfaces    []*netinterfaces.Interface
+}
+
+// getMTUEndpoints reads the interfaces of the test orchestrator and of each pod under test.  Containers of a pod share
+// its interfaces, so they are read from the first container that is not excluded for lacking binaries.
+func getMTUEndpoints(configData *common.ConfigurationData) []*mtuEndpoint {
+	var endpoints []*mtuEndpoint
+	if configData.TestOrchestrator != nil {
+		endpoints = append(endpoints, &mtuEndpoint{name: "test orchestrator", oc: configData.TestOrchestrator.Oc,
+			defaultDevice: configData.TestOrchestrator.ContainerConfiguration.DefaultNetworkDevice})
+	}
+	for _, pod := range groupContainersByPod(configData.ContainersUnderTest) {
+		for _, cut := range pod.containers {
+			if _, ok := common.ContainersToExcludeFromConnectivityTests[cut.ContainerIdentifier]; !ok {
+				endpoints = append(endpoints, &mtuEndpoint{name: fmt.Sprintf("pod %s/%s", pod.namespace, pod.name),
+					oc: cut.Oc, underTest: true, defaultDevice: cut.ContainerConfiguration.DefaultNetworkDevice})
+				break
+			}
+		}
+	}
+	for _, endpoint := range endpoints {
+		ginkgo.By(fmt.Sprintf("reading the interfaces of %s", endpoint.name))
+		tester := netinterfaces.NewNetInterfaces(common.DefaultTimeout)
+		test, err := tnf.NewTest(endpoint.oc.GetExpecter(), tester, []reel.Handler{tester}, endpoint.oc.GetErrorChannel())
+		gomega.Expect(err).To(gomega.BeNil())
+		common.RunAndValidateTest(test)
+		endpoint.interfaces = tester.GetInterfaces()
+	}
+	return endpoints
+}
+
+// getMTUPeers returns the addresses of the other endpoints that `address` of `iface` reaches directly, sorted.  Those
+// are the addresses of the same family on the default network, which spans several subnets, and those within the
+// subnet of `address` on the other networks.
+func getMTUPeers(source *mtuEndpoint, iface *netinterfaces.Interface, address *net.IPNet, endpoints []*mtuEndpoint) []string {
+	onDefaultNetwork := iface.Name == source.defaultDevice
+	isIPv6 := address.IP.To4() == nil
+	var peers []string
+	for _, endpoint := range endpoints {
+		if endpoint == source {
+			continue
+		}
+		for _, peerIface := range endpoint.interfaces {
+			for _, peerAddress := range peerIface.Addresses {
+				if (peerAddress.IP.To4() == nil) != isIPv6 {
+					continue
+				}
+				if (onDefaultNetwork && peerIface.Name == endpoint.defaultDevice) || address.Contains(peerAddress.IP) {
+					peers = append(peers, peerAddress.IP.String())
+				}
+			}
+		}
+	}
+	sort.Strings(peers)
+	return peers
+}
+
+// findLargestWorkingMTU returns the size of the largest packet that `oc` can send to `peer` without fragmentation, up
+// to `mtu`, or zero if not even a packet of the minimum MTU gets through.  The size is found by bisection, starting from
+// any path MTU reported by the failed probes.
+func findLargestWorkingMTU(oc *interactive.Oc, peer string, mtu int) int {
+	minimumMTU := ipv4MinimumMTU
+	if ping.IsIPv6Address(peer) {
+		minimumMTU = ipv6MinimumMTU
+	}
+	works, reportedMTU := probeMTU(oc, peer, mtu)
+	if works {
+		return mtu
+	}
+	// largest is the largest size known to work, and smallestFailing the smallest size known to fail.
+	largest, smallestFailing := minimumMTU-1, mtu
+	if reportedMTU >= minimumMTU && reportedMTU < mtu {
+		if works, _ = probeMTU(oc, peer, reportedMTU); works {
+			largest = reportedMTU
+		} else {
+			smallestFailing = reportedMTU
+		}
+	}
+	for smallestFailing-largest > 1 {
+		size := (largest + smallestFailing) / 2
+		if works, _ = probeMTU(oc, peer, size); works {
+			largest = size
+		} else {
+			smallestFailing = size
+		}
+	}
+	if largest < minimumMTU {
+		return 0
+	}
+	return largest
+}
+
+// probeMTU pings `peer` from `oc` with packets of `size` bytes that must not be fragmented.  It returns true if the
+// packets got through, along with any path MTU reported when they did not.
+func probeMTU(oc *interactive.Oc, peer string, size int) (works bool, reportedMTU int) {
+	headerOverhead := ipv4HeaderOverhead
+	if ping.IsIPv6Address(peer) {
+		headerOverhead = ipv6HeaderOverhead
+	}
+	options := ping.Options{PayloadSize: size - headerOverhead, DontFragment: true, ReplyTimeoutSeconds: 1}
+	tester := ping.NewPingWithOptions(common.DefaultTimeout, peer, mtuProbeCount, options)
+	test, err := tnf.NewTest(oc.GetExpecter(), tester, []reel.Handler{tester}, oc.GetErrorChannel())
+	gomega.Expect(err).To(gomega.BeNil())
+	result, err := test.Run()
+	gomega.Expect(err).To(gomega.BeNil())
+	return result == tnf.SUCCESS, tester.GetReportedMTU()
+}
+
+func testPathMTU(configData *common.ConfigurationData) {
+	ginkgo.It("should send packets the size of the interface MTU to each peer without fragmentation", func() {
+		endpoints := getMTUEndpoints(configData)
+		defer results.RecordResult(identifiers.TestPathMTUIdentifier)
+		var failures []string
+		for _, source := range endpoints {
+			if !source.underTest {
+				continue
+			}
+			for _, iface := range source.interfaces {
+				for _, address := range iface.Addresses {
+					for _, peer := range getMTUPeers(source, iface, address, endpoints) {
+						path := fmt.Sprintf("%s %s (MTU %d) to %s", source.name, iface.Name, iface.MTU, peer)
+						ginkgo.By(fmt.Sprintf("probing the path MTU from %s", path))
+						largest := findLargestWorkingMTU(source.oc, peer, iface.MTU)
+						log.Infof("the largest packet sent from %s without fragmentation is %d bytes", path, largest)
+						passed := largest >= iface.MTU
+						var reason string
+						switch {
+						case largest == 0:
+							reason = "not even a packet of the minimum MTU reached the peer without fragmentation"
+						case !passed:
+							reason = fmt.Sprintf("the largest packet reaching the peer without fragmentation is %d bytes, "+
+								"below the interface MTU", largest)
+						}
+						results.RecordDetailedResult(identifiers.TestPathMTUIdentifier,
+							fmt.Sprintf("%s: largest working MTU %d", path, largest), passed, reason)
+						if !passed {
+							failures = append(failures, fmt.Sprintf("%s: %d", path, largest))
+						}
+					}
+				}
+			}
+		}
+		gomega.Expect(failures).To(gomega.BeEmpty(), "paths with a largest working MTU below the interface MTU: %v", failures)
+	})
+}
+
+// throughputPath is a path between an address of a container under test and an address of the test orchestrator on
+// the same network, which the throughput is measured over.
+type throughputPath struct {
+	network             string
+	cutAddress          string
+	orchestratorAddress string
+}
+
+// getThroughputPaths returns the paths between `cut` and the test orchestrator, over the default network in each address
+// family they both have, and over each network of `cut` where the orchestrator has an address in the same subnet.
+func getThroughputPaths(cut, orchestrator *common.Container, cutInterfaces, orchestratorInterfaces []*netinterfaces.Interface) []throughputPath {
+	var paths []throughputPath
+	for _, family := range addressFamilies {
+		if net.ParseIP(family.defaultAddress(cut)) != nil && net.ParseIP(family.defaultAddress(orchestrator)) != nil {
+			paths = append(paths, throughputPath{network: cut.ContainerConfiguration.DefaultNetworkDevice,
+				cutAddress: family.defaultAddress(cut), orchestratorAddress: family.defaultAddress(orchestrator)})
+		}
+	}
+	for _, iface := range cutInterfaces {
+		if iface.Name == cut.ContainerConfiguration.DefaultNetworkDevice {
+			continue
+		}
+		for _, address := range iface.Addresses {
+			for _, orchestratorIface := range orchestratorInterfaces {
+				for _, orchestratorAddress := range orchestratorIface.Addresses {
+					if address.Contains(orchestratorAddress.IP) {
+						paths = append(paths, throughputPath{network: iface.Name, cutAddress: address.IP.String(),
+							orchestratorAddress: orchestratorAddress.IP.String()})
+					}
+				}
+			}
+		}
+	}
+	return paths
+}
+
+// readInterfaces returns the interfaces of the container of `oc` which have a global scope address.
+func readInterfaces(oc *interactive.Oc) []*netinterfaces.Interface {
+	tester := netinterfaces.NewNetInterfaces(common.DefaultTimeout)
+	test, err := tnf.NewTest(oc.GetExpecter(), tester, []reel.Handler{tester}, oc.GetErrorChannel())
+	gomega.Expect(err).To(gomega.BeNil())
+	common.RunAndValidateTest(test)
+	return tester.GetInterfaces()
+}
+
+// runIperf3 runs one side of an iperf3 measurement from `oc`, returning why it failed, if it did.
+func runIperf3(oc *interactive.Oc, tester *iperf3.Iperf3) string {
+	test, err := tnf.NewTest(oc.GetExpecter(), tester, []reel.Handler{tester}, oc.GetErrorChannel())
+	gomega.Expect(err).To(gomega.BeNil())
+	result, err := test.Run()
+	switch {
+	case err != nil:
+		return err.Error()
+	case result != tnf.SUCCESS && tester.GetError() == "":
+		return "iperf3 did not complete"
+	}
+	return tester.GetError()
+}
+
+// measureThroughput measures the `protocol` throughput over `path`.  The server runs in the test orchestrator and the
+// client in `cut`, unless the orchestrator is unable to start a server, in which case the roles are reversed.  It
+// returns a description of the path as measured, along with the throughput or why it could not be measured.
+func measureThroughput(cut, orchestrator *common.Container, path throughputPath, protocol string,
+	conf *configsections.ThroughputTest) (description string, throughput iperf3.Throughput, failure string) {
+	port, duration := conf.Port, time.Duration(conf.DurationSeconds)*time.Second
+	if port <= 0 {
+		port = defaultThroughputPort
+	}
+	if duration <= 0 {
+		duration = defaultThroughputDuration
+	}
+	server, client, host := orchestrator, cut, path.orchestratorAddress
+	failure = runIperf3(server.Oc, iperf3.NewServer(common.DefaultTimeout, port, duration+throughputServerGrace))
+	if failure != "" {
+		log.Warnf("unable to start an iperf3 server in the test orchestrator (%s), reversing the roles", failure)
+		server, client, host = cut, orchestrator, path.cutAddress
+		failure = runIperf3(server.Oc, iperf3.NewServer(common.DefaultTimeout, port, duration+throughputServerGrace))
+	}
+	description = fmt.Sprintf("%s throughput from %s(%s) to %s(%s) %s (%s network)", protocol, client.Oc.GetPodName(),
+		client.Oc.GetPodContainerName(), server.Oc.GetPodName(), server.Oc.GetPodContainerName(), host, path.network)
+	if failure != "" {
+		return description, throughput, fmt.Sprintf("no iperf3 server could be started: %s", failure)
+	}
+	tester := iperf3.NewClient(duration+common.DefaultTimeout, host, port, protocol, duration, conf.UDPBitrate)
+	if failure = runIperf3(client.Oc, tester); failure != "" {
+		return description, throughput, failure
+	}
+	return description, tester.GetThroughput(), ""
+}
+
+func testThroughput(configData *common.ConfigurationData) {
+	ginkgo.It("should sustain the minimum TCP and UDP throughput to the test orchestrator", func() {
+		orchestrator := configData.TestOrchestrator
+		if orchestrator == nil {
+			ginkgo.Skip("no test orchestrator is available to measure the throughput with")
+		}
+		conf := common.GetConfigProvider().GetConfig().ThroughputTest
+		defer results.RecordResult(identifiers.TestThroughputIdentifier)
+		orchestratorInterfaces := readInterfaces(orchestrator.Oc)
+		var failures []string
+		for _, pod := range groupContainersByPod(configData.ContainersUnderTest) {
+			for _, cut := range pod.containers {
+				if _, ok := common.ContainersToExcludeFromConnectivityTests[cut.ContainerIdentifier]; ok {
+					continue
+				}
+				for _, path := range getThroughputPaths(cut, orchestrator, readInterfaces(cut.Oc), orchestratorInterfaces) {
+					for _, protocol := range []string{iperf3.ProtocolTCP, iperf3.ProtocolUDP} {
+						ginkgo.By(fmt.Sprintf("measuring the %s throughput between %s(%s) %s and %s", protocol,
+							cut.Oc.GetPodName(), cut.Oc.GetPodContainerName(), path.cutAddress, path.orchestratorAddress))
+						description, throughput, failure := measureThroughput(cut, orchestrator, path, protocol, &conf)
+						minimum := conf.MinTCPMbps
+						if protocol == iperf3.ProtocolUDP {
+							minimum = conf.MinUDPMbps
+						}
+						mbps := throughput.BitsPerSecond / bitsPerMegabit
+						if failure == "" && minimum != nil && mbps < *minimum {
+							failure = fmt.Sprintf("%.1f Mbit/s is below the minimum of %.1f Mbit/s", mbps, *minimum)
+						}
+						item := fmt.Sprintf("%s: %.1f Mbit/s", description, mbps)
+						if protocol == iperf3.ProtocolUDP {
+							item += fmt.Sprintf(", %.2f%% lost, %.3f ms jitter", throughput.LostPercent, throughput.JitterMilliseconds)
+						}
+						results.RecordDetailedResult(identifiers.TestThroughputIdentifier, item, failure == "", failure)
+						if failure != "" {
+							failures = append(failures, fmt.Sprintf("%s: %s", description, failure))
+						}
+					}
+				}
+			}
+		}
+		gomega.Expect(failures).To(gomega.BeEmpty(), "throughput measurements failed: %v", failures)
+	})
+}
+
+func testPodPortReachability(configData *common.ConfigurationData) {
+	ginkgo.It("should answer on the container ports declared in the pod spec", func() {
+		testOrchestrator := configData.TestOrchestrator
+		if testOrchestrator == nil {
+			ginkgo.Skip("no test orchestrator is available to probe the container ports from")
+		}
+		udpProbes := common.GetConfigProvider().GetConfig().UDPProbes
+		defer results.RecordResult(identifiers.TestPodPortsReachableIdentifier)
+		var unreachable []string
+		for _, cut := range configData.ContainersUnderTest {
+			host := cut.DefaultNetworkIPAddress
+			if host == "" || host == "UNKNOWN" {
+				host = cut.DefaultNetworkIPv6Address
+			}
+			if host == "" {
+				log.Warnf("%s(%s) has no known pod IP, its ports are not probed", cut.Oc.GetPodName(), cut.Oc.GetPodContainerName())
+				continue
+			}
+			for _, port := range cut.ContainerConfiguration.Ports {
+				item := fmt.Sprintf("pod %s/%s %s %s", cut.Oc.GetPodNamespace(), cut.Oc.GetPodName(), port.Protocol,
+					net.JoinHostPort(host, strconv.Itoa(port.Port)))
+				if !probePort(testOrchestrator.Oc, identifiers.TestPodPortsReachableIdentifier, item, host, port.Port,
+					port.Protocol, udpProbes) {
+					unreachable = append(unreachable, item)
+				}
+			}
+		}
+		gomega.Expect(unreachable).To(gomega.BeEmpty(), "ports declared but not listening: %v", unreachable)
+	})
+}
+
+func testServicePortReachability(configData *common.ConfigurationData) {
+	ginkgo.It("should answer on the ports of the services selecting the pods under test", func() {
+		testOrchestrator := configData.TestOrchestrator
+		if testOrchestrator == nil {
+			ginkgo.Skip("no test orchestrator is available to probe the service ports from")
+		}
+		conf := common.GetConfigProvider().GetConfig()
+		defer results.RecordResult(identifiers.TestServicePortsReachableIdentifier)
+		var unreachable []string
+		for _, service := range conf.Services {
+			if service.ClusterIP == "" || service.IsHeadless() {
+				log.Infof("service %s/%s has no cluster IP, its ports are not probed", service.Namespace, service.Name)
+				continue
+			}
+			for _, port := range service.Ports {
+				item := fmt.Sprintf("service %s/%s %s %s", service.Namespace, service.Name, port.Protocol,
+					net.JoinHostPort(service.ClusterIP, strconv.Itoa(port.Port)))
+				if !probePort(testOrchestrator.Oc, identifiers.TestServicePortsReachableIdentifier, item, service.ClusterIP,
+					port.Port, port.Protocol, conf.UDPProbes) {
+					unreachable = append(unreachable, item)
+				}
+			}
+		}
+		gomega.Expect(unreachable).To(gomega.BeEmpty(), "ports declared but not listening: %v", unreachable)
+	})
+}
+
+// probePort checks a single port from the test orchestrator, and records the outcome as a detailed result of
+// `identifier`.  A UDP port is only checked if it has a probe, and ports of other protocols are not checked.  Returns
+// false only when the port was checked and did not answer.
+func probePort(oc *interactive.Oc, identifier claim.Identifier, item, host string, port int, protocol string,
+	udpProbes []configsections.UDPProbe) bool {
+	var tester *portprobe.PortProbe
+	var failureReason string
+	switch protocol {
+	case configsections.ProtocolTCP:
+		tester = portprobe.NewTCPPortProbe(common.DefaultTimeout, host, port)
+		failureReason = "the port is declared but does not accept connections"
+	case configsections.ProtocolUDP:
+		probe := findUDPProbe(udpProbes, port)
+		if probe == nil {
+			log.Infof("%s is not probed, as no UDP probe is defined for port %d", item, port)
+			return true
+		}
+		tester = portprobe.NewUDPPortProbe(common.DefaultTimeout, host, port, probe.Payload)
+		failureReason = "the port is declared but did not answer the UDP probe"
+	default:
+		log.Infof("%s is not probed, as %s ports are not supported", item, protocol)
+		return true
+	}
+	ginkgo.By(fmt.Sprintf("probing %s from %s(%s)", item, oc.GetPodName(), oc.GetPodContainerName()))
+	result, err := runPortProbe(oc, tester)
+	if err != nil {
+		failureReason = fmt.Sprintf("the probe did not complete: %s", err)
+	} else if result == tnf.ERROR {
+		failureReason = "the probe did not complete"
+		if reason := tester.GetError(); reason != "" {
+			failureReason += ": " + reason
+		}
+	}
+	passed := err == nil && result == tnf.SUCCESS
+	if passed {
+		failureReason = ""
+	} else {
+		log.Warnf("%s: %s", item, failureReason)
+	}
+	results.RecordDetailedResult(identifier, item, passed, failureReason)
+	return passed
+}
+
+// runPortProbe runs a port probe from `oc`, returning its result.
+func runPortProbe(oc *interactive.Oc, tester *portprobe.PortProbe) (int, error) {
+	test, err := tnf.NewTest(oc.GetExpecter(), tester, []reel.Handler{tester}, oc.GetErrorChannel())
+	gomega.Expect(err).To(gomega.BeNil())
+	return test.Run()
+}
+
+// findUDPProbe returns the probe defined for a UDP port, or nil if there is none.
+func findUDPProbe(udpProbes []configsections.UDPProbe, port int) *configsections.UDPProbe {
+	for i := range udpProbes {
+		if udpProbes[i].Port == port {
+			return &udpProbes[i]
+		}
+	}
+	return nil
+}
+
+// podUnderTest groups the containers under test of a single pod, which share its network namespace.
+type podUnderTest struct {
+	namespace  string
+	name       string
+	containers []*common.Container
+}
+
+// groupContainersByPod returns the pods of the containers under test, ordered by namespace and name.
+func groupContainersByPod(containers map[configsections.ContainerIdentifier]*common.Container) []*podUnderTest {
+	pods := make(map[string]*podUnderTest)
+	for _, cut := range containers {
+		key := cut.ContainerIdentifier.Namespace + "/" + cut.ContainerIdentifier.PodName
+		if _, ok := pods[key]; !ok {
+			pods[key] = &podUnderTest{namespace: cut.ContainerIdentifier.Namespace, name: cut.ContainerIdentifier.PodName}
+		}
+		pods[key].containers = append(pods[key].containers, cut)
+	}
+	sorted := make([]*podUnderTest, 0, len(pods))
+	for _, pod := range pods {
+		sort.Slice(pod.containers, func(i, j int) bool {
+			return pod.containers[i].ContainerIdentifier.ContainerName < pod.containers[j].ContainerIdentifier.ContainerName
+		})
+		sorted = append(sorted, pod)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].namespace != sorted[j].namespace {
+			return sorted[i].namespace < sorted[j].namespace
+		}
+		return sorted[i].name < sorted[j].name
+	})
+	return sorted
+}
+
+// toSockets converts container ports to the sockets the listeningsockets handler compares.  Only TCP and UDP sockets
+// are listed by the handler, so ports of other protocols are left out.
+func toSockets(ports []configsections.ContainerPort) []listeningsockets.Socket {
+	sockets := make([]listeningsockets.Socket, 0, len(ports))
+	for _, port := range ports {
+		if port.Protocol == configsections.ProtocolTCP || port.Protocol == configsections.ProtocolUDP {
+			sockets = append(sockets, listeningsockets.Socket{Protocol: port.Protocol, Port: port.Port})
+		}
+	}
+	return sockets
+}
+
+func testListeningPorts(configData *common.ConfigurationData) {
+	ginkgo.It("should listen on the declared container ports only", func() {
+		allowed := toSockets(common.GetConfigProvider().GetConfig().AllowedListeningPorts)
+		defer results.RecordResult(identifiers.TestListeningPortsDeclaredIdentifier)
+		var mismatches []string
+		for _, pod := range groupContainersByPod(configData.ContainersUnderTest) {
+			item := fmt.Sprintf("pod %s/%s", pod.namespace, pod.name)
+			// The socket tables are those of the pod network namespace, so they are compared with the ports declared
+			// by all of its containers, and read from the first container that is not excluded for lacking binaries.
+			var declared []configsections.ContainerPort
+			var oc *interactive.Oc
+			for _, cut := range pod.containers {
+				declared = append(declared, cut.ContainerConfiguration.Ports...)
+				if _, ok := common.ContainersToExcludeFromConnectivityTests[cut.ContainerIdentifier]; !ok && oc == nil {
+					oc = cut.Oc
+				}
+			}
+			if oc == nil {
+				log.Warnf("%s only has containers excluded from connectivity tests, its sockets are not audited", item)
+				continue
+			}
+			ginkgo.By(fmt.Sprintf("reading the listening sockets of %s", item))
+			listening, reason := getListeningSockets(oc)
+			if reason != "" {
+				results.RecordDetailedResult(identifiers.TestListeningPortsDeclaredIdentifier, item, false, reason)
+				mismatches = append(mismatches, fmt.Sprintf("%s: %s", item, reason))
+				continue
+			}
+
+			undeclared, notListening := listeningsockets.FindMismatches(listening, toSockets(declared), allowed)
+			var reasons []string
+			if len(undeclared) > 0 {
+				reasons = append(reasons, fmt.Sprintf("listening on undeclared ports %v", undeclared))
+			}
+			if len(notListening) > 0 {
+				reasons = append(reasons, fmt.Sprintf("not listening on declared ports %v", notListening))
+			}
+			results.RecordDetailedResult(identifiers.TestListeningPortsDeclaredIdentifier, item, len(reasons) == 0,
+				strings.Join(reasons, "; "))
+			if len(reasons) > 0 {
+				mismatches = append(mismatches, fmt.Sprintf("%s is %s", item, strings.Join(reasons, " and ")))
+			}
+		}
+		gomega.Expect(mismatches).To(gomega.BeEmpty(), "listening sockets do not match the declared ports: %v", mismatches)
+	})
+}
+
+// dnsResolver is a container the cluster DNS is queried from.
+type dnsResolver struct {
+	name string
+	oc   *interactive.Oc
+}
+
+// getDNSResolvers returns the test orchestrator along with one container of each pod under test, as the DNS
+// configuration is shared by the containers of a pod.
+func getDNSResolvers(configData *common.ConfigurationData) []dnsResolver {
+	var resolvers []dnsResolver
+	if configData.TestOrchestrator != nil {
+		resolvers = append(resolvers, dnsResolver{name: "test orchestrator", oc: configData.TestOrchestrator.Oc})
+	}
+	for _, pod := range groupContainersByPod(configData.ContainersUnderTest) {
+		for _, cut := range pod.containers {
+			if _, ok := common.ContainersToExcludeFromConnectivityTests[cut.ContainerIdentifier]; !ok {
+				resolvers = append(resolvers, dnsResolver{name: fmt.Sprintf("pod %s/%s", pod.namespace, pod.name), oc: cut.Oc})
+				break
+			}
+		}
+	}
+	return resolvers
+}
+
+// filterAddressFamily returns the IPv4 or the IPv6 addresses of `ips`, sorted.
+func filterAddressFamily(ips []string, ipv6 bool) []string {
+	filtered := []string{}
+	for _, ip := range ips {
+		if parsed := net.ParseIP(ip); parsed != nil && (parsed.To4() == nil) == ipv6 {
+			filtered = append(filtered, parsed.String())
+		}
+	}
+	sort.Strings(filtered)
+	return filtered
+}
+
+// lookupDNS resolves the `recordType` records of `name` from `resolver`.  The lookup is expected to complete, even if
+// it finds no record.
+func lookupDNS(resolver dnsResolver, recordType, name string) *dnslookup.DNSLookup {
+	tester := dnslookup.NewDNSLookup(common.DefaultTimeout, recordType, name)
+	test, err := tnf.NewTest(resolver.oc.GetExpecter(), tester, []reel.Handler{tester}, resolver.oc.GetErrorChannel())
+	gomega.Expect(err).To(gomega.BeNil())
+	common.RunAndValidateTest(test)
+	return tester
+}
+
+func testServiceDNSResolution(configData *common.ConfigurationData) {
+	ginkgo.It("should resolve the service names to their cluster IPs or endpoints", func() {
+		conf := common.GetConfigProvider().GetConfig()
+		if len(conf.Services) == 0 {
+			ginkgo.Skip("no service selects the pods under test")
+		}
+		clusterDomain := conf.Settings.ClusterDomain
+		if clusterDomain == "" {
+			clusterDomain = defaultClusterDomain
+		}
+		defer results.RecordResult(identifiers.TestServiceDNSResolutionIdentifier)
+		defer results.RecordResult(identifiers.TestServiceDNSSRVRecordsIdentifier)
+		var failures []string
+		for _, resolver := range getDNSResolvers(configData) {
+			for i := range conf.Services {
+				service := &conf.Services[i]
+				fqdn := fmt.Sprintf("%s.%s.svc.%s", service.Name, service.Namespace, clusterDomain)
+				// A headless service resolves to its endpoints, and any other to its cluster IPs.
+				expected := service.ClusterIPs
+				if service.IsHeadless() {
+					expected = service.Endpoints
+				} else if len(expected) == 0 {
+					expected = []string{service.ClusterIP}
+				}
+				for _, recordType := range []string{dnslookup.RecordTypeA, dnslookup.RecordTypeAAAA} {
+					item := fmt.Sprintf("%s %s %s", resolver.name, recordType, fqdn)
+					ginkgo.By(fmt.Sprintf("resolving %s", item))
+					want := filterAddressFamily(expected, recordType == dnslookup.RecordTypeAAAA)
+					got := lookupDNS(resolver, recordType, fqdn).GetAddresses()
+					if got == nil {
+						got = []string{}
+					}
+					passed := fmt.Sprint(want) == fmt.Sprint(got)
+					var reason string
+					if !passed {
+						reason = fmt.Sprintf("resolved to %v, expected %v", got, want)
+						failures = append(failures, fmt.Sprintf("%s %s", item, reason))
+					}
+					results.RecordDetailedResult(identifiers.TestServiceDNSResolutionIdentifier, item, passed, reason)
+				}
+				failures = append(failures, checkSRVRecords(resolver, service, fqdn)...)
+			}
+		}
+		gomega.Expect(failures).To(gomega.BeEmpty(), "service DNS records do not match: %v", failures)
+	})
+}
+
+// checkSRVRecords resolves the SRV record of each named port of `service`, which must point to the service port, or
+// to the endpoints of a headless service.  Returns the failures found.
+func checkSRVRecords(resolver dnsResolver, service *configsections.Service, fqdn string) (failures []string) {
+	for _, port := range service.Ports {
+		if port.Name == "" {
+			continue
+		}
+		name := fmt.Sprintf("_%s._%s.%s", port.Name, strings.ToLower(port.Protocol), fqdn)
+		item := fmt.Sprintf("%s %s %s", resolver.name, dnslookup.RecordTypeSRV, name)
+		ginkgo.By(fmt.Sprintf("resolving %s", item))
+		records := lookupDNS(resolver, dnslookup.RecordTypeSRV, name).GetSRVRecords()
+		passed := len(records) > 0
+		reason := "no SRV record was found"
+		if passed && !service.IsHeadless() {
+			for _, record := range records {
+				if record.Port != port.Port {
+					passed = false
+					reason = fmt.Sprintf("the SRV record points to port %d, expected %d", record.Port, port.Port)
+				}
+			}
+		}
+		if passed {
+			reason = ""
+		} else {
+			failures = append(failures, fmt.Sprintf("%s %s", item, reason))
+		}
+		results.RecordDetailedResult(identifiers.TestServiceDNSSRVRecordsIdentifier, item, passed, reason)
+	}
+	return failures
+}
+
+// getTargetNamespaces returns the namespaces of the pods under test, sorted.
+func getTargetNamespaces(conf *configsections.TestConfiguration) []string {
+	seen := make(map[string]bool)
+	var namespaces []string
+	for _, pod := range conf.PodsUnderTest {
+		if !seen[pod.Namespace] {
+			seen[pod.Namespace] = true
+			namespaces = append(namespaces, pod.Namespace)
+		}
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+// getIngressPolicies returns the network policies restricting the ingress traffic to `pod`.
+func getIngressPolicies(conf *configsections.TestConfiguration, pod *configsections.Pod) []*configsections.NetworkPolicy {
+	var policies []*configsections.NetworkPolicy
+	for i := range conf.NetworkPolicies {
+		if conf.NetworkPolicies[i].AppliesToIngress() && conf.NetworkPolicies[i].SelectsPod(pod) {
+			policies = append(policies, &conf.NetworkPolicies[i])
+		}
+	}
+	return policies
+}
+
+func testNetworkPolicyDenyAll() {
+	ginkgo.It("should have a default deny ingress network policy in each namespace under test", func() {
+		conf := common.GetConfigProvider().GetConfig()
+		defer results.RecordResult(identifiers.TestNetworkPolicyDenyAllIdentifier)
+		var missing []string
+		for _, namespace := range getTargetNamespaces(&conf) {
+			found := false
+			for i := range conf.NetworkPolicies {
+				if conf.NetworkPolicies[i].Namespace == namespace && conf.NetworkPolicies[i].IsDefaultDenyIngress() {
+					found = true
+					break
+				}
+			}
+			var reason string
+			if !found {
+				reason = "no network policy denies the ingress traffic to all pods by default"
+				missing = append(missing, namespace)
+			}
+			results.RecordDetailedResult(identifiers.TestNetworkPolicyDenyAllIdentifier, "namespace "+namespace, found, reason)
+		}
+		gomega.Expect(missing).To(gomega.BeEmpty(), "namespaces without a default deny ingress policy: %v", missing)
+	})
+}
+
+func testNetworkPolicyCoverage() {
+	ginkgo.It("should select each pod under test with a network policy", func() {
+		conf := common.GetConfigProvider().GetConfig()
+		defer results.RecordResult(identifiers.TestNetworkPolicyCoverageIdentifier)
+		var uncovered []string
+		for i := range conf.PodsUnderTest {
+			pod := &conf.PodsUnderTest[i]
+			item := fmt.Sprintf("pod %s/%s", pod.Namespace, pod.Name)
+			covered := false
+			for j := range conf.NetworkPolicies {
+				if conf.NetworkPolicies[j].SelectsPod(pod) {
+					covered = true
+					break
+				}
+			}
+			var reason string
+			if !covered {
+				reason = "no network policy selects the pod"
+				uncovered = append(uncovered, item)
+			}
+			results.RecordDetailedResult(identifiers.TestNetworkPolicyCoverageIdentifier, item, covered, reason)
+		}
+		gomega.Expect(uncovered).To(gomega.BeEmpty(), "pods not selected by any network policy: %v", uncovered)
+	})
+}
+
+func testNetworkPolicyEnforcement(configData *common.ConfigurationData) {
+	ginkgo.It("should block the listening ports that the network policies do not allow", func() {
+		testOrchestrator := configData.TestOrchestrator
+		if testOrchestrator == nil {
+			ginkgo.Skip("no test orchestrator is available to probe the blocked ports from")
+		}
+		conf := common.GetConfigProvider().GetConfig()
+		defer results.RecordResult(identifiers.TestNetworkPolicyEnforcementIdentifier)
+		pods := make(map[string]*podUnderTest)
+		for _, pod := range groupContainersByPod(configData.ContainersUnderTest) {
+			pods[pod.namespace+"/"+pod.name] = pod
+		}
+		var unblocked []string
+		for i := range conf.PodsUnderTest {
+			pod := &conf.PodsUnderTest[i]
+			item := fmt.Sprintf("pod %s/%s", pod.Namespace, pod.Name)
+			policies := getIngressPolicies(&conf, pod)
+			cut, ok := pods[pod.Namespace+"/"+pod.Name]
+			if len(policies) == 0 || !ok {
+				log.Infof("%s is not restricted by a network policy, or has no container under test, enforcement is not checked", item)
+				continue
+			}
+			host, oc, declared := getPodAddressAndPorts(cut)
+			if host == "" || oc == nil {
+				log.Warnf("%s has no known pod IP or no container to read its sockets from, enforcement is not checked", item)
+				continue
+			}
+			unblocked = append(unblocked, probeBlockedPorts(testOrchestrator.Oc, oc, item, host, policies, declared)...)
+		}
+		gomega.Expect(unblocked).To(gomega.BeEmpty(), "ports not shown to be blocked by the network policies: %v", unblocked)
+	})
+}
+
+// probeBlockedPorts probes from `orchestratorOc` each TCP port that the pod `item` listens on, as read through `oc`,
+// and that none of `policies` allows, recording the outcome of each.  A refused connection and a timed out one are both
+// treated as blocked, as policies usually drop the packets they deny; a probe which did not complete checked nothing.
+// Returns the ports which were not blocked or could not be checked, or the pod itself when its sockets could not be
+// read.
+func probeBlockedPorts(orchestratorOc, oc *interactive.Oc, item, host string, policies []*configsections.NetworkPolicy,
+	declared []configsections.ContainerPort) []string {
+	listening, reason := getListeningSockets(oc)
+	if reason != "" {
+		results.RecordDetailedResult(identifiers.TestNetworkPolicyEnforcementIdentifier, item, false, reason)
+		return []string{fmt.Sprintf("%s: %s", item, reason)}
+	}
+	var unblocked []string
+	for _, socket := range listening {
+		if socket.Protocol != listeningsockets.ProtocolTCP || isIngressPortAllowed(policies, socket.Port, declared) {
+			continue
+		}
+		portItem := fmt.Sprintf("%s TCP %s", item, net.JoinHostPort(host, strconv.Itoa(socket.Port)))
+		ginkgo.By(fmt.Sprintf("probing %s, which no network policy allows", portItem))
+		tester := portprobe.NewTCPPortProbe(common.DefaultTimeout, host, socket.Port)
+		result, err := runPortProbe(orchestratorOc, tester)
+		// Only a failed connection shows that the port is blocked; a probe which did not complete checked nothing.
+		var reason string
+		switch {
+		case err != nil:
+			reason = fmt.Sprintf("could not check the port, the probe did not complete: %s", err)
+		case result == tnf.SUCCESS:
+			reason = "the port accepted a connection although no network policy allows it"
+		case result != tnf.FAILURE:
+			reason = "could not check the port, the probe did not complete"
+			if tester.GetError() != "" {
+				reason += ": " + tester.GetError()
+			}
+		}
+		if reason != "" {
+			unblocked = append(unblocked, fmt.Sprintf("%s: %s", portItem, reason))
+		}
+		results.RecordDetailedResult(identifiers.TestNetworkPolicyEnforcementIdentifier, portItem, reason == "", reason)
+	}
+	return unblocked
+}
+
+// getPodAddressAndPorts returns the pod IP, preferring IPv4, the session of a container able to read the pod sockets,
+// and the ports declared by the containers of the pod.
+func getPodAddressAndPorts(pod *podUnderTest) (host string, oc *interactive.Oc, declared []configsections.ContainerPort) {
+	for _, cut := range pod.containers {
+		declared = append(declared, cut.ContainerConfiguration.Ports...)
+		if _, ok := common.ContainersToExcludeFromConnectivityTests[cut.ContainerIdentifier]; ok {
+			continue
+		}
+		if oc == nil {
+			oc = cut.Oc
+		}
+		if host == "" && cut.DefaultNetworkIPAddress != "" && cut.DefaultNetworkIPAddress != "UNKNOWN" {
+			host = cut.DefaultNetworkIPAddress
+		}
+		if host == "" {
+			host = cut.DefaultNetworkIPv6Address
+		}
+	}
+	return host, oc, declared
+}
+
+// getListeningSockets reads the sockets listening in the network namespace of the pod of `oc`.  When they cannot be
+// read, the reason is returned instead.
+func getListeningSockets(oc *interactive.Oc) (sockets []listeningsockets.Socket, reason string) {
+	tester := listeningsockets.NewListeningSockets(common.DefaultTimeout)
+	test, err := tnf.NewTest(oc.GetExpecter(), tester, []reel.Handler{tester}, oc.GetErrorChannel())
+	gomega.Expect(err).To(gomega.BeNil())
+	testResult, err := test.Run()
+	gomega.Expect(err).To(gomega.BeNil())
+	if testResult != tnf.SUCCESS {
+		reason = "unable to read the listening sockets"
+		if tester.GetError() != "" {
+			reason += ": " + tester.GetError()
+		}
+		return nil, reason
+	}
+	return tester.GetSockets(), ""
+}
+
+// isIngressPortAllowed returns true if one of `policies` allows the ingress traffic to a TCP `port`, which may be
+// allowed by the name it is declared with.
+func isIngressPortAllowed(policies []*configsections.NetworkPolicy, port int, declared []configsections.ContainerPort) bool {
+	var portName string
+	for _, declaredPort := range declared {
+		if declaredPort.Protocol == configsections.ProtocolTCP && declaredPort.Port == port {
+			portName = declaredPort.Name
+		}
+	}
+	for _, policy := range policies {
+		if policy.AllowsIngressPort(configsections.ProtocolTCP, port, portName) {
+			return true
+		}
+	}
+	return false
+}
+
+// getPodSession returns the session of the first container of `pod` that is not excluded from connectivity tests for
+// lacking binaries, or nil if there is none.
+func getPodSession(pod *podUnderTest) *interactive.Oc {
+	for _, cut := range pod.containers {
+		if _, ok := common.ContainersToExcludeFromConnectivityTests[cut.ContainerIdentifier]; !ok {
+			return cut.Oc
+		}
+	}
+	return nil
+}
+
+// hasSecondaryNetworks returns true if a pod under test requests a network, or has one reported in its status.
+func hasSecondaryNetworks(conf *configsections.TestConfiguration) bool {
+	for i := range conf.PodsUnderTest {
+		if len(conf.PodsUnderTest[i].Networks) > 0 || len(conf.PodsUnderTest[i].NetworksStatus) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// getNetworkAttachmentDefinition returns the definition of the requested `network`, or nil if it does not exist.
+func getNetworkAttachmentDefinition(conf *configsections.TestConfiguration, network *configsections.NetworkAttachment) *configsections.NetworkAttachmentDefinition {
+	for i := range conf.NetworkAttachmentDefinitions {
+		definition := &conf.NetworkAttachmentDefinitions[i]
+		if definition.Namespace == network.Namespace && definition.Name == network.Name {
+			return definition
+		}
+	}
+	return nil
+}
+
+// isStatusOf returns true if `status` is that of the network attachment definition `namespace`/`name` of `pod`.
+// Earlier versions of Multus report networks in the namespace of the pod without their namespace.
+func isStatusOf(status *configsections.NetworkStatus, pod *configsections.Pod, namespace, name string) bool {
+	return status.Name == namespace+"/"+name || (status.Name == name && namespace == pod.Namespace)
+}
+
+// getNetworkStatus returns the status of the requested `network` of `pod`, or nil if it is not reported.
+func getNetworkStatus(pod *configsections.Pod, network *configsections.NetworkAttachment) *configsections.NetworkStatus {
+	for i := range pod.NetworksStatus {
+		if isStatusOf(&pod.NetworksStatus[i], pod, network.Namespace, network.Name) {
+			return &pod.NetworksStatus[i]
+		}
+	}
+	return nil
+}
+
+func testNetworkAttachmentDefinitions() {
+	ginkgo.It("should request existing network attachment definitions with a valid CNI configuration", func() {
+		conf := common.GetConfigProvider().GetConfig()
+		if !hasSecondaryNetworks(&conf) {
+			ginkgo.Skip("no pod under test requests a secondary network")
+		}
+		defer results.RecordResult(identifiers.TestNetworkAttachmentDefinitionsIdentifier)
+		var invalid []string
+		for i := range conf.PodsUnderTest {
+			pod := &conf.PodsUnderTest[i]
+			for j := range pod.Networks {
+				network := &pod.Networks[j]
+				item := fmt.Sprintf("pod %s/%s network %s/%s", pod.Namespace, pod.Name, network.Namespace, network.Name)
+				var reason string
+				if definition := getNetworkAttachmentDefinition(&conf, network); definition == nil {
+					reason = "the network attachment definition does not exist"
+				} else if _, err := definition.PluginTypes(); err != nil {
+					reason = err.Error()
+				}
+				if reason != "" {
+					invalid = append(invalid, item)
+				}
+				results.RecordDetailedResult(identifiers.TestNetworkAttachmentDefinitionsIdentifier, item, reason == "", reason)
+			}
+		}
+		gomega.Expect(invalid).To(gomega.BeEmpty(), "networks without a valid network attachment definition: %v", invalid)
+	})
+}
+
+// getSRIOVAttachments returns the SR-IOV network attachment definitions requested by `pod`, once for each request.
+func getSRIOVAttachments(conf *configsections.TestConfiguration, pod *configsections.Pod) []*configsections.NetworkAttachmentDefinition {
+	var attachments []*configsections.NetworkAttachmentDefinition
+	for i := range pod.Networks {
+		if definition := getNetworkAttachmentDefinition(conf, &pod.Networks[i]); definition != nil && definition.IsSRIOV() {
+			attachments = append(attachments, definition)
+		}
+	}
+	return attachments
+}
+
+// checkSRIOVResources checks that the containers of `pod` request, with requests equal to limits, at least as many
+// devices of each SR-IOV resource as the pod has attachments using it.  It returns the items which failed.
+func checkSRIOVResources(podItem string, pod *podUnderTest, attachments []*configsections.NetworkAttachmentDefinition) (failures []string) {
+	needed := make(map[string]int)
+	var resourceNames []string
+	for _, attachment := range attachments {
+		if attachment.ResourceName == "" {
+			item := fmt.Sprintf("%s network %s", podItem, attachment.NamespacedName())
+			results.RecordDetailedResult(identifiers.TestSRIOVDevicesIdentifier, item, false,
+				"the SR-IOV network attachment definition names no resource to allocate VFs from")
+			failures = append(failures, item)
+			continue
+		}
+		if needed[attachment.ResourceName] == 0 {
+			resourceNames = append(resourceNames, attachment.ResourceName)
+		}
+		needed[attachment.ResourceName]++
+	}
+	sort.Strings(resourceNames)
+	for _, resourceName := range resourceNames {
+		item := fmt.Sprintf("%s resource %s", podItem, resourceName)
+		var reasons []string
+		requested := 0
+		for _, cut := range pod.containers {
+			request := cut.ContainerConfiguration.ResourceRequests[resourceName]
+			limit := cut.ContainerConfiguration.ResourceLimits[resourceName]
+			if request != limit {
+				reasons = append(reasons, fmt.Sprintf("container %s requests %q but is limited to %q",
+					cut.ContainerIdentifier.ContainerName, request, limit))
+			}
+			if request == "" {
+				continue
+			}
+			count, err := strconv.Atoi(request)
+			if err != nil {
+				reasons = append(reasons, fmt.Sprintf("container %s requests %q, which is not a number of devices",
+					cut.ContainerIdentifier.ContainerName, request))
+				continue
+			}
+			requested += count
+		}
+		if requested < needed[resourceName] {
+			reasons = append(reasons, fmt.Sprintf("%d devices are requested for %d attachments", requested, needed[resourceName]))
+		}
+		results.RecordDetailedResult(identifiers.TestSRIOVDevicesIdentifier, item, len(reasons) == 0, strings.Join(reasons, "; "))
+		if len(reasons) > 0 {
+			failures = append(failures, item)
+		}
+	}
+	return failures
+}
+
+// checkSRIOVFunctions checks that each PCI address reported in the status of the SR-IOV networks of `pod` is a VF
+// present in the container of `oc`, bound to a driver, and backing the reported interface unless bound to a userspace
+// driver.  It returns the items which failed.
+func checkSRIOVFunctions(podItem string, pod *configsections.Pod, attachments []*configsections.NetworkAttachmentDefinition, oc *interactive.Oc) (failures []string) {
+	var statuses []*configsections.NetworkStatus
+	var addresses []string
+	for i := range pod.NetworksStatus {
+		status := &pod.NetworksStatus[i]
+		for _, attachment := range attachments {
+			if isStatusOf(status, pod, attachment.Namespace, attachment.Name) {
+				statuses = append(statuses, status)
+				addresses = append(addresses, status.PCIAddress)
+				break
+			}
+		}
+	}
+	if len(statuses) == 0 {
+		return nil
+	}
+	tester := pcidevices.NewPCIDevices(common.DefaultTimeout, addresses)
+	test, err := tnf.NewTest(oc.GetExpecter(), tester, []reel.Handler{tester}, oc.GetErrorChannel())
+	gomega.Expect(err).To(gomega.BeNil())
+	common.RunAndValidateTest(test)
+	for _, status := range statuses {
+		item := fmt.Sprintf("%s network %s interface %s VF %s", podItem, status.Name, status.Interface, status.PCIAddress)
+		device := tester.GetDevice(status.PCIAddress)
+		var reason string
+		switch {
+		case status.PCIAddress == "":
+			reason = "no PCI address is reported in the network status"
+		case !pcidevices.IsValidAddress(status.PCIAddress):
+			reason = "the reported PCI address is not valid"
+		case device == nil || !device.Present:
+			reason = "the device is not present in the container"
+		case !device.VirtualFunction:
+			reason = "the device is not a virtual function"
+		case device.Driver == "":
+			reason = "no driver is bound to the device"
+		case device.NetInterface != "" && status.Interface != "" && device.NetInterface != status.Interface:
+			reason = fmt.Sprintf("the device backs interface %s rather than the reported one", device.NetInterface)
+		}
+		if reason != "" {
+			failures = append(failures, item)
+		} else {
+			log.Infof("%s is bound to %s", item, device.Driver)
+		}
+		results.RecordDetailedResult(identifiers.TestSRIOVDevicesIdentifier, item, reason == "", reason)
+	}
+	return failures
+}
+
+func testSRIOVDevices(configData *common.ConfigurationData) {
+	ginkgo.It("should request and be allocated bound SR-IOV VFs for each SR-IOV network", func() {
+		conf := common.GetConfigProvider().GetConfig()
+		pods := make(map[string]*podUnderTest)
+		for _, pod := range groupContainersByPod(configData.ContainersUnderTest) {
+			pods[pod.namespace+"/"+pod.name] = pod
+		}
+		attachments := make(map[string][]*configsections.NetworkAttachmentDefinition)
+		for i := range conf.PodsUnderTest {
+			pod := &conf.PodsUnderTest[i]
+			if sriov := getSRIOVAttachments(&conf, pod); len(sriov) > 0 {
+				attachments[pod.Namespace+"/"+pod.Name] = sriov
+			}
+		}
+		if len(attachments) == 0 {
+			ginkgo.Skip("no pod under test is attached to an SR-IOV network")
+		}
+		defer results.RecordResult(identifiers.TestSRIOVDevicesIdentifier)
+		var failures []string
+		for i := range conf.PodsUnderTest {
+			pod := &conf.PodsUnderTest[i]
+			key := pod.Namespace + "/" + pod.Name
+			podItem := "pod " + key
+			cut, ok := pods[key]
+			if len(attachments[key]) == 0 || !ok {
+				continue
+			}
+			ginkgo.By(fmt.Sprintf("checking the SR-IOV devices of %s", podItem))
+			failures = append(failures, checkSRIOVResources(podItem, cut, attachments[key])...)
+			oc := getPodSession(cut)
+			if oc == nil {
+				log.Warnf("%s only has containers excluded from connectivity tests, its VFs are not inspected", podItem)
+				continue
+			}
+			failures = append(failures, checkSRIOVFunctions(podItem, pod, attachments[key], oc)...)
+		}
+		gomega.Expect(failures).To(gomega.BeEmpty(), "SR-IOV resources or VFs which are not as expected: %v", failures)
+	})
+}
+
+// checkStatusInterface checks that the interface reported in `status` exists among `links` of the pod, with the
+// reported IP addresses among those of `interfaces`.  It returns why it does not, if it does not.
+func checkStatusInterface(status *configsections.NetworkStatus, links []string, interfaces []*netinterfaces.Interface) string {
+	if i := sort.SearchStrings(links, status.Interface); i == len(links) || links[i] != status.Interface {
+		return "the interface does not exist in the pod"
+	}
+	assigned := make(map[string]bool)
+	for _, iface := range interfaces {
+		if iface.Name == status.Interface {
+			for _, address := range iface.Addresses {
+				assigned[address.IP.String()] = true
+			}
+		}
+	}
+	var missing []string
+	for _, ip := range status.IPs {
+		if parsed := net.ParseIP(ip); parsed == nil || !assigned[parsed.String()] {
+			missing = append(missing, ip)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Sprintf("the interface does not have the addresses %v", missing)
+	}
+	return ""
+}
+
+func testNetworkStatusInterfaces(configData *common.ConfigurationData) {
+	ginkgo.It("should have the requested networks attached with the interfaces and IPs reported in their status", func() {
+		conf := common.GetConfigProvider().GetConfig()
+		if !hasSecondaryNetworks(&conf) {
+			ginkgo.Skip("no pod under test has a network status to check")
+		}
+		pods := make(map[string]*podUnderTest)
+		for _, pod := range groupContainersByPod(configData.ContainersUnderTest) {
+			pods[pod.namespace+"/"+pod.name] = pod
+		}
+		defer results.RecordResult(identifiers.TestNetworkStatusInterfacesIdentifier)
+		var failures []string
+		record := func(item, reason string) {
+			if reason != "" {
+				failures = append(failures, item)
+			}
+			results.RecordDetailedResult(identifiers.TestNetworkStatusInterfacesIdentifier, item, reason == "", reason)
+		}
+		for i := range conf.PodsUnderTest {
+			pod := &conf.PodsUnderTest[i]
+			podItem := fmt.Sprintf("pod %s/%s", pod.Namespace, pod.Name)
+			for j := range pod.Networks {
+				network := &pod.Networks[j]
+				var reason string
+				status := getNetworkStatus(pod, network)
+				switch {
+				case status == nil:
+					reason = "the network is not reported in the network status"
+				case network.Interface != "" && status.Interface != network.Interface:
+					reason = fmt.Sprintf("the network is attached as interface %s rather than the requested one", status.Interface)
+				}
+				record(fmt.Sprintf("%s network %s/%s", podItem, network.Namespace, network.Name), reason)
+			}
+			cut, ok := pods[pod.Namespace+"/"+pod.Name]
+			if len(pod.NetworksStatus) == 0 || !ok {
+				continue
+			}
+			oc := getPodSession(cut)
+			if oc == nil {
+				log.Warnf("%s only has containers excluded from connectivity tests, its interfaces are not checked", podItem)
+				continue
+			}
+			ginkgo.By(fmt.Sprintf("reading the interfaces of %s", podItem))
+			tester := netinterfaces.NewNetInterfaces(common.DefaultTimeout)
+			test, err := tnf.NewTest(oc.GetExpecter(), tester, []reel.Handler{tester}, oc.GetErrorChannel())
+			gomega.Expect(err).To(gomega.BeNil())
+			common.RunAndValidateTest(test)
+			for j := range pod.NetworksStatus {
+				status := &pod.NetworksStatus[j]
+				// Devices bound to a userspace driver have no interface in the pod, which their missing IPs tell.
+				if status.Interface == "" || (status.PCIAddress != "" && len(status.IPs) == 0) {
+					continue
+				}
+				record(fmt.Sprintf("%s interface %s", podItem, status.Interface),
+					checkStatusInterface(status, tester.GetLinkNames(), tester.GetInterfaces()))
+			}
+		}
+		gomega.Expect(failures).To(gomega.BeEmpty(), "networks not attached as reported in their status: %v", failures)
+	})
 }
 
 func testNodePort(configData *common.ConfigurationData) {
@@ -45,9 +45,8 @@ var (
 var _ = ginkgo.Describe(common.ObservabilityTestKey, func() {
 	if testcases.IsInFocus(ginkgoconfig.GinkgoConfig.FocusStrings, common.ObservabilityTestKey) {
 		configData := common.ConfigurationData{}
-		configData.SetNeedsRefresh()
 		ginkgo.BeforeEach(func() {
-			common.ReloadConfiguration(&configData)
+			common.EnsureConfiguration(common.GetConfigProvider(), &configData)
 		})
 		testLogging(&configData)
 	}
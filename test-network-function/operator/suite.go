@@ -31,13 +31,13 @@ import (
 	ginkgoconfig "github.com/onsi/ginkgo/config"
 	"github.com/onsi/gomega"
 	"github.com/test-network-function/test-network-function/internal/api"
-	"github.com/test-network-function/test-network-function/pkg/config"
 	"github.com/test-network-function/test-network-function/pkg/config/configsections"
 	"github.com/test-network-function/test-network-function/pkg/tnf"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/operator"
 	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
 	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
 	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
+	"github.com/test-network-function/test-network-function/test-network-function/common"
 )
 
 const (
@@ -79,7 +79,7 @@ var _ = ginkgo.Describe(testSpecName, func() {
 		ginkgo.When("a local shell is spawned", func() {
 			goExpectSpawner := interactive.NewGoExpectSpawner()
 			var spawner interactive.Spawner = goExpectSpawner
-			context, err = interactive.SpawnShell(&spawner, defaultTimeout, interactive.Verbose(true))
+			context, err = interactive.SpawnShell(&spawner, defaultTimeout, append(common.GetSpawnerOptions(), interactive.Verbose(true))...)
 			ginkgo.It("should be created without error", func() {
 				gomega.Expect(err).To(gomega.BeNil())
 				gomega.Expect(context).ToNot(gomega.BeNil())
@@ -128,7 +128,7 @@ func testOperatorIsInstalledViaOLM(subscriptionName, subscriptionNamespace strin
 }
 
 func getConfig() ([]configsections.CertifiedOperatorRequestInfo, []configsections.Operator) {
-	conf := config.GetConfigInstance()
+	conf := common.GetConfigProvider().GetConfig()
 	operatorsToQuery := conf.CertifiedOperatorInfo
 	operatorsInTest := conf.Operators
 	return operatorsToQuery, operatorsInTest
@@ -57,9 +57,8 @@ var _ = ginkgo.Describe(common.PlatformAlterationTestKey, func() {
 	if testcases.IsInFocus(ginkgoconfig.GinkgoConfig.FocusStrings, common.PlatformAlterationTestKey) {
 
 		configData := common.ConfigurationData{}
-		configData.SetNeedsRefresh()
 		ginkgo.BeforeEach(func() {
-			common.ReloadConfiguration(&configData)
+			common.EnsureConfiguration(common.GetConfigProvider(), &configData)
 		})
 		ginkgo.Context("Container does not have additional packages installed", func() {
 			// use this boolean to turn off tests that require OS packages
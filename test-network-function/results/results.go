@@ -26,6 +26,10 @@ import (
 
 var results = map[claim.Identifier][]claim.Result{}
 
+// detailedResults hold the results of the individual items checked by a test, which are known up front rather than
+// gleaned from JUnit output.
+var detailedResults = map[claim.Identifier][]claim.Result{}
+
 // RecordResult is a hook provided to save aspects of the ginkgo.GinkgoTestDescription for a given claim.Identifier.
 // Multiple results for a given identifier are aggregated as an array under the same key.
 func RecordResult(identifier claim.Identifier) {
@@ -39,6 +43,22 @@ func RecordResult(identifier claim.Identifier) {
 	})
 }
 
+// RecordDetailedResult saves the outcome of a single item checked by a test, such as one port, for a given
+// claim.Identifier.  The item is appended to the test text, so that each item has its own entry in the claim alongside
+// the result of the test as a whole.
+func RecordDetailedResult(identifier claim.Identifier, item string, passed bool, failureReason string) {
+	testContext := ginkgo.CurrentGinkgoTestDescription()
+	detailedResults[identifier] = append(detailedResults[identifier], claim.Result{
+		Duration:      int(testContext.Duration.Nanoseconds()),
+		FailureReason: failureReason,
+		Filename:      testContext.FileName,
+		IsMeasurement: testContext.IsMeasurement,
+		LineNumber:    testContext.LineNumber,
+		Passed:        passed,
+		TestText:      fmt.Sprintf("%s [%s]", testContext.FullTestText, item),
+	})
+}
+
 // GetReconciledResults is a function added to aggregate a Claim's results.  Due to the limitations of
 // test-network-function-claim's Go Client, results are generalized to map[string]interface{}.  This method is needed
 // to take the results gleaned from JUnit output, and to combine them with the contexts built up by subsequent calls to
@@ -61,5 +81,12 @@ func GetReconciledResults(testResults map[string]junit.TestResult) map[string]in
 			resultMap[strKey] = append(resultMap[strKey].([]claim.Result), val)
 		}
 	}
+	for key, vals := range detailedResults {
+		strKey := fmt.Sprintf("{\"url\":\"%s\",\"version\":\"%s\"}", key.Url, key.Version)
+		if _, ok := resultMap[strKey]; !ok {
+			resultMap[strKey] = make([]claim.Result, 0)
+		}
+		resultMap[strKey] = append(resultMap[strKey].([]claim.Result), vals...)
+	}
 	return resultMap
 }
@@ -19,9 +19,11 @@ package suite
 import (
 	j "encoding/json"
 	"flag"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -32,11 +34,13 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/test-network-function/test-network-function-claim/pkg/claim"
 	"github.com/test-network-function/test-network-function/pkg/config"
+	"github.com/test-network-function/test-network-function/pkg/config/autodiscover"
 	"github.com/test-network-function/test-network-function/pkg/junit"
 	"github.com/test-network-function/test-network-function/pkg/tnf"
 
 	_ "github.com/test-network-function/test-network-function/test-network-function/accesscontrol"
 	_ "github.com/test-network-function/test-network-function/test-network-function/certification"
+	"github.com/test-network-function/test-network-function/test-network-function/common"
 	"github.com/test-network-function/test-network-function/test-network-function/diagnostic"
 	_ "github.com/test-network-function/test-network-function/test-network-function/generic"
 	_ "github.com/test-network-function/test-network-function/test-network-function/lifecycle"
@@ -51,10 +55,13 @@ const (
 	claimFileName                        = "claim.json"
 	claimFilePermissions                 = 0644
 	claimPathFlagKey                     = "claimloc"
+	configFlagKey                        = "config"
 	CnfCertificationTestSuiteName        = "CNF Certification Test Suite"
 	defaultClaimPath                     = ".."
 	defaultCliArgValue                   = ""
 	junitFlagKey                         = "junit"
+	setFlagKey                           = "set"
+	snapshotFlagKey                      = "snapshot"
 	TNFJunitXMLFileName                  = "cnf-certification-tests_junit.xml"
 	TNFReportKey                         = "cnf-certification-test"
 	CNFFeatureValidationJunitXMLFileName = "validation_junit.xml"
@@ -62,18 +69,44 @@ const (
 	// dateTimeFormatDirective is the directive used to format date/time according to ISO 8601.
 	dateTimeFormatDirective = "2006-01-02T15:04:05+00:00"
 	extraInfoKey            = "testsExtraInfo"
+	// sbomsKey is the key of the software bills of materials of the images under test in the raw results of the claim.
+	sbomsKey = "sboms"
+	// baseImagesKey is the key of the classes of the base images of the containers under test in the raw results of the
+	// claim.
+	baseImagesKey = "baseImages"
 )
 
 var (
-	claimPath *string
-	junitPath *string
+	claimPath    *string
+	junitPath    *string
+	snapshotPath *string
+	configFiles  stringListFlag
+	overrides    stringListFlag
 )
 
+// stringListFlag is a command line flag that may be given several times.
+type stringListFlag []string
+
+func (s *stringListFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringListFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
 func init() {
 	claimPath = flag.String(claimPathFlagKey, defaultClaimPath,
 		"the path where the claimfile will be output")
 	junitPath = flag.String(junitFlagKey, defaultCliArgValue,
 		"the path for the junit format report")
+	snapshotPath = flag.String(snapshotFlagKey, defaultCliArgValue,
+		"a file to also write the discovery snapshot to, which the claim file holds in any case")
+	flag.Var(&configFiles, configFlagKey,
+		"a configuration file, replacing those named by TNF_CONFIGURATION_PATH; repeat to apply overlays in order")
+	flag.Var(&overrides, setFlagKey,
+		"a configuration value as path=value, e.g. settings.nonIntrusiveOnly=true, applied over files and environment")
 }
 
 // createClaimRoot creates the claim based on the model created in
@@ -93,7 +126,7 @@ func createClaimRoot() *claim.Root {
 
 // loadJUnitXMLIntoMap converts junitFilename's XML-formatted JUnit test results into a Go map, and adds the result to
 // the result Map.
-func loadJUnitXMLIntoMap(result map[string]interface{}, junitFilename, key string) {
+func loadJUnitXMLIntoMap(result map[string]interface{}, junitFilename, key string) error {
 	var err error
 	if key == "" {
 		var extension = filepath.Ext(junitFilename)
@@ -101,8 +134,9 @@ func loadJUnitXMLIntoMap(result map[string]interface{}, junitFilename, key strin
 	}
 	result[key], err = junit.ExportJUnitAsMap(junitFilename)
 	if err != nil {
-		log.Fatalf("error reading JUnit XML file into JSON: %v", err)
+		return fmt.Errorf("error reading JUnit XML file into JSON: %v", err)
 	}
+	return nil
 }
 
 // TestTest invokes the CNF Certification Test Suite.
@@ -118,31 +152,86 @@ func TestTest(t *testing.T) {
 	claimData.Nodes = make(map[string]interface{})
 	incorporateTNFVersion(claimData)
 
+	// load the test configuration and hand it to the test suites.
+	configProvider, err := loadConfigProvider()
+	if err != nil {
+		log.Fatalf("unable to load configuration: %v", err)
+	}
+	// remove anything that was deployed for the run, such as the test partner, however the run ends.
+	defer closeConfigProvider(configProvider)
+
 	// run the test suite
 	ginkgo.RunSpecs(t, CnfCertificationTestSuiteName)
 	endTime := time.Now()
 
-	// process the test results from this test suite, the cnf-features-deploy test suite, and any extra informational
-	// messages.
-	junitMap := make(map[string]interface{})
-	cnfCertificationJUnitFilename := filepath.Join(*junitPath, TNFJunitXMLFileName)
-	loadJUnitXMLIntoMap(junitMap, cnfCertificationJUnitFilename, TNFReportKey)
-	appendCNFFeatureValidationReportResults(junitPath, junitMap)
-	junitMap[extraInfoKey] = tnf.TestsExtraInfo
+	err = writeClaim(claimRoot, configProvider, endTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the claim records the discovered topology, so that a later run can reuse it; a separate file is only written
+	// when asked for.
+	if *snapshotPath != "" {
+		err = writeDiscoverySnapshot(configProvider, *snapshotPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// writeClaim fills out the claim with the results of the run, which ended at `endTime`, and writes it to the claim
+// file.
+func writeClaim(claimRoot *claim.Root, provider config.Provider, endTime time.Time) error {
+	junitMap, err := collectRawResults()
+	if err != nil {
+		return err
+	}
+	junitMap[autodiscover.ClaimRawResultsKey] = config.GetDiscoverySnapshot(provider)
 
 	// fill out the remaining claim information.
+	claimData := claimRoot.Claim
 	claimData.RawResults = junitMap
-	resultMap := generateResultMap(junitMap)
+	resultMap, err := generateResultMap(junitMap)
+	if err != nil {
+		return err
+	}
 	claimData.Results = results.GetReconciledResults(resultMap)
-	configurations := marshalConfigurations()
+	configurations, err := marshalConfigurations(provider)
+	if err != nil {
+		return err
+	}
 	claimData.Nodes = generateNodes()
-	unmarshalConfigurations(configurations, claimData.Configurations)
+	err = unmarshalConfigurations(configurations, claimData.Configurations)
+	if err != nil {
+		return err
+	}
 	claimData.Metadata.EndTime = endTime.UTC().Format(dateTimeFormatDirective)
 
 	// marshal the claim and output to file
-	payload := marshalClaimOutput(claimRoot)
-	claimOutputFile := filepath.Join(*claimPath, claimFileName)
-	writeClaimOutput(claimOutputFile, payload)
+	payload, err := marshalClaimOutput(claimRoot)
+	if err != nil {
+		return err
+	}
+	return writeClaimOutput(filepath.Join(*claimPath, claimFileName), payload)
+}
+
+// collectRawResults processes the test results from this test suite, the cnf-features-deploy test suite, and any
+// extra informational messages.
+func collectRawResults() (map[string]interface{}, error) {
+	junitMap := make(map[string]interface{})
+	cnfCertificationJUnitFilename := filepath.Join(*junitPath, TNFJunitXMLFileName)
+	err := loadJUnitXMLIntoMap(junitMap, cnfCertificationJUnitFilename, TNFReportKey)
+	if err != nil {
+		return nil, err
+	}
+	err = appendCNFFeatureValidationReportResults(junitPath, junitMap)
+	if err != nil {
+		return nil, err
+	}
+	junitMap[extraInfoKey] = tnf.TestsExtraInfo
+	junitMap[sbomsKey] = diagnostic.GetSBOMs()
+	junitMap[baseImagesKey] = diagnostic.GetBaseImages()
+	return junitMap, nil
 }
 
 // getTNFVersion gets the TNF version, or fatally fails.
@@ -162,60 +251,87 @@ func incorporateTNFVersion(claimData *claim.Claim) {
 	}
 }
 
-// generateResultMap is a conversion utility to generate results.  If an error is encountered, than this method fails
-// fatally.
-func generateResultMap(junitMap map[string]interface{}) map[string]junit.TestResult {
+// generateResultMap is a conversion utility to generate results.
+func generateResultMap(junitMap map[string]interface{}) (map[string]junit.TestResult, error) {
 	resultMap, err := junit.ExtractTestSuiteResults(junitMap, TNFReportKey)
 	if err != nil {
-		log.Fatalf("Could not extract the test suite results: %s", err)
+		return nil, fmt.Errorf("could not extract the test suite results: %s", err)
 	}
-	return resultMap
+	return resultMap, nil
 }
 
 // appendCNFFeatureValidationReportResults is a helper method to add the results of running the cnf-features-deploy
 // test suite to the claim file.
-func appendCNFFeatureValidationReportResults(junitPath *string, junitMap map[string]interface{}) {
+func appendCNFFeatureValidationReportResults(junitPath *string, junitMap map[string]interface{}) error {
 	cnfFeaturesDeployJUnitFile := filepath.Join(*junitPath, CNFFeatureValidationJunitXMLFileName)
 	if _, err := os.Stat(cnfFeaturesDeployJUnitFile); err == nil {
-		loadJUnitXMLIntoMap(junitMap, cnfFeaturesDeployJUnitFile, CNFFeatureValidationReportKey)
+		return loadJUnitXMLIntoMap(junitMap, cnfFeaturesDeployJUnitFile, CNFFeatureValidationReportKey)
+	}
+	return nil
+}
+
+// loadConfigProvider loads the test configuration described by the command line and environment, and makes it
+// available to the test suites.  A provider which fails to load has removed anything it deployed.
+func loadConfigProvider() (config.Provider, error) {
+	provider := config.NewLayeredProvider(configFiles, overrides)
+	err := provider.Load()
+	if err != nil {
+		return nil, err
+	}
+	common.SetConfigProvider(provider)
+	return provider, nil
+}
+
+// closeConfigProvider removes anything that `provider` created for the run.
+func closeConfigProvider(provider config.Provider) {
+	if err := provider.Close(); err != nil {
+		log.Errorf("error cleaning up after the run: %v", err)
 	}
 }
 
-// marshalConfigurations creates a byte stream representation of the test configurations.  In the event of an error,
-// this method fatally fails.
-func marshalConfigurations() []byte {
-	configurations, err := j.Marshal(config.GetConfigInstance())
+// marshalConfigurations creates a byte stream representation of the test configurations.
+func marshalConfigurations(provider config.Provider) ([]byte, error) {
+	configurations, err := j.Marshal(provider.GetConfig())
 	if err != nil {
-		log.Fatalf("error converting configurations to JSON: %v", err)
+		return nil, fmt.Errorf("error converting configurations to JSON: %v", err)
 	}
-	return configurations
+	return configurations, nil
 }
 
-// unmarshalConfigurations creates a map from configurations byte stream.  In the event of an error, this method fatally
-// fails.
-func unmarshalConfigurations(configurations []byte, claimConfigurations map[string]interface{}) {
+// unmarshalConfigurations creates a map from configurations byte stream.
+func unmarshalConfigurations(configurations []byte, claimConfigurations map[string]interface{}) error {
 	err := j.Unmarshal(configurations, &claimConfigurations)
 	if err != nil {
-		log.Fatalf("error unmarshalling configurations: %v", err)
+		return fmt.Errorf("error unmarshalling configurations: %v", err)
 	}
+	return nil
 }
 
-// marshalClaimOutput is a helper function to serialize a claim as JSON for output.  In the event of an error, this
-// method fatally fails.
-func marshalClaimOutput(claimRoot *claim.Root) []byte {
+// marshalClaimOutput is a helper function to serialize a claim as JSON for output.
+func marshalClaimOutput(claimRoot *claim.Root) ([]byte, error) {
 	payload, err := j.MarshalIndent(claimRoot, "", "  ")
 	if err != nil {
-		log.Fatalf("Failed to generate the claim: %v", err)
+		return nil, fmt.Errorf("failed to generate the claim: %v", err)
 	}
-	return payload
+	return payload, nil
 }
 
-// writeClaimOutput writes the output payload to the claim file.  In the event of an error, this method fatally fails.
-func writeClaimOutput(claimOutputFile string, payload []byte) {
+// writeClaimOutput writes the output payload to the claim file.
+func writeClaimOutput(claimOutputFile string, payload []byte) error {
 	err := ioutil.WriteFile(claimOutputFile, payload, claimFilePermissions)
 	if err != nil {
-		log.Fatalf("Error writing claim data:\n%s", string(payload))
+		return fmt.Errorf("error writing claim data:\n%s", string(payload))
+	}
+	return nil
+}
+
+// writeDiscoverySnapshot writes the discovered test target and partner to a snapshot file.
+func writeDiscoverySnapshot(provider config.Provider, snapshotOutputFile string) error {
+	err := config.GetDiscoverySnapshot(provider).Save(snapshotOutputFile)
+	if err != nil {
+		return fmt.Errorf("error writing discovery snapshot: %v", err)
 	}
+	return nil
 }
 
 func generateNodes() map[string]interface{} {